@@ -3,11 +3,13 @@ module github.com/cookbook
 go 1.16
 
 require (
-	github.com/go-sql-driver/mysql v1.6.0
 	github.com/gorilla/mux v1.8.0
-	github.com/jackc/pgx/v4 v4.13.0 // indirect
-	github.com/kelseyhightower/envconfig v1.4.0 // indirect
+	github.com/graph-gophers/graphql-go v1.3.0
+	github.com/jackc/pgx/v4 v4.13.0
+	github.com/kelseyhightower/envconfig v1.4.0
 	golang.org/x/crypto v0.0.0-20210921155107-089bfa567519 // indirect
 	golang.org/x/text v0.3.7 // indirect
-	gopkg.in/yaml.v3 v3.0.0-20210107192922-496545a6307b // indirect
+	google.golang.org/grpc v1.41.0
+	google.golang.org/protobuf v1.27.1
+	gopkg.in/yaml.v3 v3.0.0-20210107192922-496545a6307b
 )
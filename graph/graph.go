@@ -0,0 +1,154 @@
+package graph
+
+import (
+	_ "embed"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/cookbook/service"
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+)
+
+//go:embed schema.graphql
+var schema string
+
+type Resolver struct {
+	ingredients service.IngredientService
+	recipes     service.RecipeService
+	meals       service.MealService
+	mealPlans   service.MealPlanService
+}
+
+// Request is the body of a GraphQL request.
+type Request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// Response is the body of a GraphQL response.
+type Response struct {
+	Data   map[string]interface{} `json:"data"`
+	Errors []ResponseError        `json:"errors"`
+}
+
+type ResponseError struct {
+	Message    string                 `json:"message"`
+	Path       []interface{}          `json:"path"`
+	Extensions map[string]interface{} `json:"extensions"`
+}
+
+func NewHandler(is service.IngredientService, rs service.RecipeService, ms service.MealService, mps service.MealPlanService) http.Handler {
+	resolver := &Resolver{
+		ingredients: is,
+		recipes:     rs,
+		meals:       ms,
+		mealPlans:   mps,
+	}
+	return &relay.Handler{Schema: graphql.MustParseSchema(schema, resolver)}
+}
+
+// lazy runs a batch load once, the first time any resolver of a list asks for
+// it, and shares the result between all of them. This keeps relationship
+// fields to a single query per list instead of one per element.
+type lazy struct {
+	once sync.Once
+	load func() error
+	err  error
+}
+
+func (l *lazy) do() error {
+	l.once.Do(func() {
+		l.err = l.load()
+	})
+	return l.err
+}
+
+type resolverError struct {
+	message string
+	code    string
+}
+
+func (e *resolverError) Error() string {
+	return e.message
+}
+
+func (e *resolverError) Extensions() map[string]interface{} {
+	return map[string]interface{}{"code": e.code}
+}
+
+func handleError(err error) error {
+	switch x := err.(type) {
+	case *service.NotFound:
+		return &resolverError{x.Error(), "NOT_FOUND"}
+	case *service.ValidationError:
+		return &resolverError{x.Error(), "BAD_USER_INPUT"}
+	case *service.Conflict:
+		return &resolverError{x.Error(), "CONFLICT"}
+	case *resolverError:
+		return x
+	default:
+		return &resolverError{"Internal server error, if the error persists contact server admin", "INTERNAL"}
+	}
+}
+
+type deleteArgs struct {
+	ID          graphql.ID
+	Cascade     *bool
+	ReplaceWith *graphql.ID
+}
+
+func (args deleteArgs) parse() (id int64, opts service.DeleteOptions, err error) {
+	id, err = parseId(args.ID)
+	if err != nil {
+		return
+	}
+	if args.Cascade != nil {
+		opts.Cascade = *args.Cascade
+	}
+	if args.ReplaceWith != nil {
+		opts.ReplaceWith, err = parseId(*args.ReplaceWith)
+	}
+	return
+}
+
+func parseId(id graphql.ID) (int64, error) {
+	parsed, err := strconv.ParseInt(string(id), 10, 64)
+	if err != nil {
+		return 0, &resolverError{"invalid id " + string(id), "BAD_USER_INPUT"}
+	}
+	return parsed, nil
+}
+
+func parseIds(ids []graphql.ID) ([]int64, error) {
+	parsed := make([]int64, len(ids))
+	for i, id := range ids {
+		var err error
+		parsed[i], err = parseId(id)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return parsed, nil
+}
+
+func formatId(id int64) graphql.ID {
+	return graphql.ID(strconv.FormatInt(id, 10))
+}
+
+// uniqueIds flattens an id map into a list of distinct ids.
+func uniqueIds(ids map[int64][]int64) []int64 {
+	seen := make(map[int64]bool)
+	var unique []int64
+	for _, list := range ids {
+		for _, id := range list {
+			if !seen[id] {
+				seen[id] = true
+				unique = append(unique, id)
+			}
+		}
+	}
+	return unique
+}
@@ -0,0 +1,197 @@
+package graph
+
+import (
+	"github.com/cookbook/service"
+	"github.com/graph-gophers/graphql-go"
+)
+
+type IngredientResolver struct {
+	ingredient service.Ingredient
+	group      *ingredientGroup
+}
+
+type ingredientGroup struct {
+	root        *Resolver
+	ids         []int64
+	recipesLoad lazy
+	recipes     map[int64][]*RecipeResolver
+}
+
+type QuantityResolver struct {
+	quantity service.Quantity
+}
+
+type quantityInput struct {
+	Amount float64
+	Unit   string
+}
+
+type ingredientInput struct {
+	Name      string
+	Quantity  quantityInput
+	Calories  float64
+	Protein   float64
+	Carbs     float64
+	Fat       float64
+	Allergens *[]string
+	Diets     *[]string
+}
+
+func (r *Resolver) newIngredients(ingredients []service.Ingredient) []*IngredientResolver {
+	group := &ingredientGroup{root: r}
+	group.recipesLoad.load = group.loadRecipes
+	resolvers := make([]*IngredientResolver, len(ingredients))
+	for i, ing := range ingredients {
+		group.ids = append(group.ids, ing.Id)
+		resolvers[i] = &IngredientResolver{ingredient: ing, group: group}
+	}
+	return resolvers
+}
+
+func (g *ingredientGroup) loadRecipes() error {
+	ids, err := g.root.recipes.GetIdsByIngredients(g.ids)
+	if err != nil {
+		return handleError(err)
+	}
+	recipes, err := g.root.recipeResolvers(uniqueIds(ids))
+	if err != nil {
+		return err
+	}
+	g.recipes = make(map[int64][]*RecipeResolver)
+	for ingredientId, recipeIds := range ids {
+		for _, recipeId := range recipeIds {
+			g.recipes[ingredientId] = append(g.recipes[ingredientId], recipes[recipeId])
+		}
+	}
+	return nil
+}
+
+func (r *Resolver) Ingredient(args struct{ ID graphql.ID }) (*IngredientResolver, error) {
+	id, err := parseId(args.ID)
+	if err != nil {
+		return nil, err
+	}
+	ing, err := r.ingredients.Get(id)
+	if err != nil {
+		return nil, handleError(err)
+	}
+	return r.newIngredients([]service.Ingredient{ing})[0], nil
+}
+
+func (r *Resolver) Ingredients() ([]*IngredientResolver, error) {
+	ings, err := r.ingredients.GetAll()
+	if err != nil {
+		return nil, handleError(err)
+	}
+	return r.newIngredients(ings), nil
+}
+
+func (r *Resolver) CreateIngredient(args struct{ Input ingredientInput }) (*IngredientResolver, error) {
+	id, err := r.ingredients.Create(args.Input.toService(0))
+	if err != nil {
+		return nil, handleError(err)
+	}
+	return r.Ingredient(struct{ ID graphql.ID }{formatId(id)})
+}
+
+func (r *Resolver) UpdateIngredient(args struct {
+	ID    graphql.ID
+	Input ingredientInput
+}) (*IngredientResolver, error) {
+	id, err := parseId(args.ID)
+	if err != nil {
+		return nil, err
+	}
+	err = r.ingredients.Update(args.Input.toService(id))
+	if err != nil {
+		return nil, handleError(err)
+	}
+	return r.Ingredient(struct{ ID graphql.ID }{args.ID})
+}
+
+func (r *Resolver) DeleteIngredient(args deleteArgs) (graphql.ID, error) {
+	id, opts, err := args.parse()
+	if err != nil {
+		return "", err
+	}
+	err = r.ingredients.Delete(id, opts)
+	if err != nil {
+		return "", handleError(err)
+	}
+	return args.ID, nil
+}
+
+func (i ingredientInput) toService(id int64) service.Ingredient {
+	ingredient := service.Ingredient{
+		Id:   id,
+		Name: i.Name,
+		NutritionalValue: service.NutritionalValue{
+			Quantity: service.Quantity{
+				Amount: float32(i.Quantity.Amount),
+				Unit:   i.Quantity.Unit,
+			},
+			Calories: float32(i.Calories),
+			Protein:  float32(i.Protein),
+			Carbs:    float32(i.Carbs),
+			Fat:      float32(i.Fat),
+		},
+	}
+	if i.Allergens != nil {
+		ingredient.Allergens = *i.Allergens
+	}
+	if i.Diets != nil {
+		ingredient.Diets = *i.Diets
+	}
+	return ingredient
+}
+
+func (r *IngredientResolver) ID() graphql.ID {
+	return formatId(r.ingredient.Id)
+}
+
+func (r *IngredientResolver) Name() string {
+	return r.ingredient.Name
+}
+
+func (r *IngredientResolver) Quantity() *QuantityResolver {
+	return &QuantityResolver{r.ingredient.Quantity}
+}
+
+func (r *IngredientResolver) Calories() float64 {
+	return float64(r.ingredient.Calories)
+}
+
+func (r *IngredientResolver) Protein() float64 {
+	return float64(r.ingredient.Protein)
+}
+
+func (r *IngredientResolver) Carbs() float64 {
+	return float64(r.ingredient.Carbs)
+}
+
+func (r *IngredientResolver) Fat() float64 {
+	return float64(r.ingredient.Fat)
+}
+
+func (r *IngredientResolver) Allergens() []string {
+	return r.ingredient.Allergens
+}
+
+func (r *IngredientResolver) Diets() []string {
+	return r.ingredient.Diets
+}
+
+func (r *IngredientResolver) Recipes() ([]*RecipeResolver, error) {
+	if err := r.group.recipesLoad.do(); err != nil {
+		return nil, err
+	}
+	return r.group.recipes[r.ingredient.Id], nil
+}
+
+func (r *QuantityResolver) Amount() float64 {
+	return float64(r.quantity.Amount)
+}
+
+func (r *QuantityResolver) Unit() string {
+	return r.quantity.Unit
+}
@@ -0,0 +1,227 @@
+package graph
+
+import (
+	"github.com/cookbook/service"
+	"github.com/graph-gophers/graphql-go"
+)
+
+type MealResolver struct {
+	meal    service.MealGet
+	recipes []*RecipeResolver
+	group   *mealGroup
+}
+
+type mealGroup struct {
+	root          *Resolver
+	ids           []int64
+	mealPlansLoad lazy
+	mealPlans     map[int64][]*MealPlanResolver
+}
+
+type mealServingsInput struct {
+	Recipe   graphql.ID
+	Servings float64
+}
+
+type mealInput struct {
+	Name     string
+	Recipes  []graphql.ID
+	Servings *[]mealServingsInput
+}
+
+func (r *Resolver) newMeals(meals []service.MealGet) []*MealResolver {
+	group := &mealGroup{root: r}
+	group.mealPlansLoad.load = group.loadMealPlans
+	var recipes []service.RecipeGet
+	for _, meal := range meals {
+		recipes = append(recipes, meal.Recipes...)
+	}
+	recipeResolvers := r.newRecipes(recipes)
+	resolvers := make([]*MealResolver, len(meals))
+	for i, meal := range meals {
+		group.ids = append(group.ids, meal.Id)
+		resolvers[i] = &MealResolver{
+			meal:    meal,
+			recipes: recipeResolvers[:len(meal.Recipes)],
+			group:   group,
+		}
+		recipeResolvers = recipeResolvers[len(meal.Recipes):]
+	}
+	return resolvers
+}
+
+// mealResolvers loads the given meals as a single list.
+func (r *Resolver) mealResolvers(ids []int64) (map[int64]*MealResolver, error) {
+	resolvers := make(map[int64]*MealResolver)
+	if len(ids) == 0 {
+		return resolvers, nil
+	}
+	meals, err := r.meals.GetList(ids)
+	if err != nil {
+		return nil, handleError(err)
+	}
+	for _, meal := range r.newMeals(meals) {
+		resolvers[meal.meal.Id] = meal
+	}
+	return resolvers, nil
+}
+
+func (g *mealGroup) loadMealPlans() error {
+	ids, err := g.root.mealPlans.GetIdsByMeals(g.ids)
+	if err != nil {
+		return handleError(err)
+	}
+	mealPlans, err := g.root.mealPlanResolvers(uniqueIds(ids))
+	if err != nil {
+		return err
+	}
+	g.mealPlans = make(map[int64][]*MealPlanResolver)
+	for mealId, mealPlanIds := range ids {
+		for _, mealPlanId := range mealPlanIds {
+			g.mealPlans[mealId] = append(g.mealPlans[mealId], mealPlans[mealPlanId])
+		}
+	}
+	return nil
+}
+
+func (r *Resolver) Meal(args struct{ ID graphql.ID }) (*MealResolver, error) {
+	id, err := parseId(args.ID)
+	if err != nil {
+		return nil, err
+	}
+	meal, err := r.meals.Get(id)
+	if err != nil {
+		return nil, handleError(err)
+	}
+	return r.newMeals([]service.MealGet{meal})[0], nil
+}
+
+func (r *Resolver) Meals() ([]*MealResolver, error) {
+	meals, err := r.meals.GetAll()
+	if err != nil {
+		return nil, handleError(err)
+	}
+	return r.newMeals(meals), nil
+}
+
+func (r *Resolver) CreateMeal(args struct{ Input mealInput }) (*MealResolver, error) {
+	meal, err := args.Input.toService(0)
+	if err != nil {
+		return nil, err
+	}
+	id, err := r.meals.Create(meal)
+	if err != nil {
+		return nil, handleError(err)
+	}
+	return r.Meal(struct{ ID graphql.ID }{formatId(id)})
+}
+
+func (r *Resolver) UpdateMeal(args struct {
+	ID    graphql.ID
+	Input mealInput
+}) (*MealResolver, error) {
+	id, err := parseId(args.ID)
+	if err != nil {
+		return nil, err
+	}
+	meal, err := args.Input.toService(id)
+	if err != nil {
+		return nil, err
+	}
+	err = r.meals.Update(meal)
+	if err != nil {
+		return nil, handleError(err)
+	}
+	return r.Meal(struct{ ID graphql.ID }{args.ID})
+}
+
+func (r *Resolver) DeleteMeal(args deleteArgs) (graphql.ID, error) {
+	id, opts, err := args.parse()
+	if err != nil {
+		return "", err
+	}
+	err = r.meals.Delete(id, opts)
+	if err != nil {
+		return "", handleError(err)
+	}
+	return args.ID, nil
+}
+
+func (i mealInput) toService(id int64) (service.MealCreate, error) {
+	recipes, err := parseIds(i.Recipes)
+	if err != nil {
+		return service.MealCreate{}, err
+	}
+	meal := service.MealCreate{
+		Id:      id,
+		Name:    i.Name,
+		Recipes: recipes,
+	}
+	if i.Servings != nil {
+		meal.Servings = make(map[int64]float32)
+		for _, servings := range *i.Servings {
+			recipeId, err := parseId(servings.Recipe)
+			if err != nil {
+				return service.MealCreate{}, err
+			}
+			meal.Servings[recipeId] = float32(servings.Servings)
+		}
+	}
+	return meal, nil
+}
+
+func (r *MealResolver) ID() graphql.ID {
+	return formatId(r.meal.Id)
+}
+
+func (r *MealResolver) Name() string {
+	return r.meal.Name
+}
+
+func (r *MealResolver) Recipes() []*RecipeResolver {
+	return r.recipes
+}
+
+// Servings lists the servings of the meal's recipes in a portion in recipe
+// order, recipes included whole are left out.
+func (r *MealResolver) Servings() []*MealServingsResolver {
+	var servings []*MealServingsResolver
+	for _, recipe := range r.meal.Recipes {
+		if value, ok := r.meal.Servings[recipe.Id]; ok {
+			servings = append(servings, &MealServingsResolver{recipe.Id, value})
+		}
+	}
+	return servings
+}
+
+func (r *MealResolver) Cost() *CostResolver {
+	return &CostResolver{r.meal.Cost}
+}
+
+func (r *MealResolver) Allergens() []string {
+	return r.meal.Allergens
+}
+
+func (r *MealResolver) Diets() []string {
+	return r.meal.Diets
+}
+
+func (r *MealResolver) MealPlans() ([]*MealPlanResolver, error) {
+	if err := r.group.mealPlansLoad.do(); err != nil {
+		return nil, err
+	}
+	return r.group.mealPlans[r.meal.Id], nil
+}
+
+type MealServingsResolver struct {
+	recipe   int64
+	servings float32
+}
+
+func (r *MealServingsResolver) Recipe() graphql.ID {
+	return formatId(r.recipe)
+}
+
+func (r *MealServingsResolver) Servings() float64 {
+	return float64(r.servings)
+}
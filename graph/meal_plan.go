@@ -0,0 +1,310 @@
+package graph
+
+import (
+	"time"
+
+	"github.com/cookbook/service"
+	"github.com/graph-gophers/graphql-go"
+)
+
+type MealPlanResolver struct {
+	mealPlan service.MealPlanGet
+	entries  []*MealPlanEntryResolver
+}
+
+type MealPlanEntryResolver struct {
+	entry service.MealPlanEntryGet
+	meal  *MealResolver
+}
+
+type mealPlanEntryInput struct {
+	Date     string
+	Slot     string
+	Meal     graphql.ID
+	Servings *float64
+	People   *int32
+	Cooked   *bool
+	Batch    *int32
+	Portions *float64
+	Members  *[]graphql.ID
+}
+
+type mealPlanInput struct {
+	Name        string
+	DateStarted string
+	Followed    *bool
+	Entries     []mealPlanEntryInput
+}
+
+func (r *Resolver) newMealPlans(mealPlans []service.MealPlanGet) []*MealPlanResolver {
+	var meals []service.MealGet
+	for _, mealPlan := range mealPlans {
+		for _, entry := range mealPlan.Entries {
+			meals = append(meals, entry.Meal)
+		}
+	}
+	mealResolvers := r.newMeals(meals)
+	resolvers := make([]*MealPlanResolver, len(mealPlans))
+	for i, mealPlan := range mealPlans {
+		resolvers[i] = &MealPlanResolver{mealPlan: mealPlan}
+		for _, entry := range mealPlan.Entries {
+			resolvers[i].entries = append(resolvers[i].entries, &MealPlanEntryResolver{
+				entry: entry,
+				meal:  mealResolvers[0],
+			})
+			mealResolvers = mealResolvers[1:]
+		}
+	}
+	return resolvers
+}
+
+// mealPlanResolvers loads the given meal plans as a single list.
+func (r *Resolver) mealPlanResolvers(ids []int64) (map[int64]*MealPlanResolver, error) {
+	resolvers := make(map[int64]*MealPlanResolver)
+	if len(ids) == 0 {
+		return resolvers, nil
+	}
+	mealPlans, err := r.mealPlans.GetList(ids)
+	if err != nil {
+		return nil, handleError(err)
+	}
+	for _, mealPlan := range r.newMealPlans(mealPlans) {
+		resolvers[mealPlan.mealPlan.Id] = mealPlan
+	}
+	return resolvers, nil
+}
+
+func (r *Resolver) MealPlan(args struct{ ID graphql.ID }) (*MealPlanResolver, error) {
+	id, err := parseId(args.ID)
+	if err != nil {
+		return nil, err
+	}
+	mealPlan, err := r.mealPlans.Get(id)
+	if err != nil {
+		return nil, handleError(err)
+	}
+	return r.newMealPlans([]service.MealPlanGet{mealPlan})[0], nil
+}
+
+func (r *Resolver) MealPlans() ([]*MealPlanResolver, error) {
+	mealPlans, err := r.mealPlans.GetAll()
+	if err != nil {
+		return nil, handleError(err)
+	}
+	return r.newMealPlans(mealPlans), nil
+}
+
+func (r *Resolver) CreateMealPlan(args struct{ Input mealPlanInput }) (*MealPlanResolver, error) {
+	mealPlan, err := args.Input.toService(0)
+	if err != nil {
+		return nil, err
+	}
+	id, err := r.mealPlans.Create(mealPlan)
+	if err != nil {
+		return nil, handleError(err)
+	}
+	return r.MealPlan(struct{ ID graphql.ID }{formatId(id)})
+}
+
+func (r *Resolver) UpdateMealPlan(args struct {
+	ID    graphql.ID
+	Input mealPlanInput
+}) (*MealPlanResolver, error) {
+	id, err := parseId(args.ID)
+	if err != nil {
+		return nil, err
+	}
+	mealPlan, err := args.Input.toService(id)
+	if err != nil {
+		return nil, err
+	}
+	// Leaving out followed or cooked keeps the current value, an entry keeps
+	// the cooked flag of the current entry with the same date, slot and meal.
+	if args.Input.keepsCurrent() {
+		current, err := r.mealPlans.Get(id)
+		if err != nil {
+			return nil, handleError(err)
+		}
+		args.Input.keepCurrent(&mealPlan, current)
+	}
+	err = r.mealPlans.Update(mealPlan)
+	if err != nil {
+		return nil, handleError(err)
+	}
+	return r.MealPlan(struct{ ID graphql.ID }{args.ID})
+}
+
+func (r *Resolver) DeleteMealPlan(args struct{ ID graphql.ID }) (graphql.ID, error) {
+	id, err := parseId(args.ID)
+	if err != nil {
+		return "", err
+	}
+	err = r.mealPlans.Delete(id)
+	if err != nil {
+		return "", handleError(err)
+	}
+	return args.ID, nil
+}
+
+func (i mealPlanInput) toService(id int64) (service.MealPlanCreate, error) {
+	dateStarted, err := time.Parse(time.RFC3339, i.DateStarted)
+	if err != nil {
+		return service.MealPlanCreate{}, &resolverError{"dateStarted must be an RFC 3339 timestamp", "BAD_USER_INPUT"}
+	}
+	mealPlan := service.MealPlanCreate{
+		Id:          id,
+		Name:        i.Name,
+		DateStarted: dateStarted,
+		Followed:    i.Followed != nil && *i.Followed,
+	}
+	for _, entry := range i.Entries {
+		date, err := service.ParseDate(entry.Date)
+		if err != nil {
+			return service.MealPlanCreate{}, &resolverError{"entry date must be formatted as YYYY-MM-DD", "BAD_USER_INPUT"}
+		}
+		mealId, err := parseId(entry.Meal)
+		if err != nil {
+			return service.MealPlanCreate{}, err
+		}
+		servings := float32(1)
+		if entry.Servings != nil {
+			servings = float32(*entry.Servings)
+		}
+		created := service.MealPlanEntryCreate{
+			Date:     date,
+			Slot:     entry.Slot,
+			MealId:   mealId,
+			Servings: servings,
+			Cooked:   entry.Cooked != nil && *entry.Cooked,
+		}
+		if entry.People != nil {
+			created.People = int(*entry.People)
+		}
+		if entry.Batch != nil {
+			created.Batch = int(*entry.Batch)
+		}
+		if entry.Portions != nil {
+			created.Portions = float32(*entry.Portions)
+		}
+		if entry.Members != nil {
+			created.Members, err = parseIds(*entry.Members)
+			if err != nil {
+				return service.MealPlanCreate{}, err
+			}
+		}
+		mealPlan.Entries = append(mealPlan.Entries, created)
+	}
+	return mealPlan, nil
+}
+
+// keepsCurrent reports whether the input leaves out followed or the cooked
+// flag of an entry.
+func (i mealPlanInput) keepsCurrent() bool {
+	if i.Followed == nil {
+		return true
+	}
+	for _, entry := range i.Entries {
+		if entry.Cooked == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// keepCurrent sets the values the input leaves out to those of the current
+// meal plan. Each current entry passes its cooked flag to one entry only.
+func (i mealPlanInput) keepCurrent(mealPlan *service.MealPlanCreate, current service.MealPlanGet) {
+	if i.Followed == nil {
+		mealPlan.Followed = current.Followed
+	}
+	used := make([]bool, len(current.Entries))
+	for index, entry := range i.Entries {
+		if entry.Cooked != nil {
+			continue
+		}
+		created := &mealPlan.Entries[index]
+		for j, currentEntry := range current.Entries {
+			if !used[j] && currentEntry.Date.Equal(created.Date.Time) && currentEntry.Slot == created.Slot && currentEntry.Meal.Id == created.MealId {
+				used[j] = true
+				created.Cooked = currentEntry.Cooked
+				break
+			}
+		}
+	}
+}
+
+func (r *MealPlanResolver) ID() graphql.ID {
+	return formatId(r.mealPlan.Id)
+}
+
+func (r *MealPlanResolver) Name() string {
+	return r.mealPlan.Name
+}
+
+func (r *MealPlanResolver) DateStarted() string {
+	return r.mealPlan.DateStarted.Format(time.RFC3339)
+}
+
+func (r *MealPlanResolver) Followed() bool {
+	return r.mealPlan.Followed
+}
+
+func (r *MealPlanResolver) Cost() *CostResolver {
+	return &CostResolver{r.mealPlan.Cost}
+}
+
+func (r *MealPlanResolver) Allergens() []string {
+	return r.mealPlan.Allergens
+}
+
+func (r *MealPlanResolver) Diets() []string {
+	return r.mealPlan.Diets
+}
+
+func (r *MealPlanResolver) Entries() []*MealPlanEntryResolver {
+	return r.entries
+}
+
+func (r *MealPlanEntryResolver) Date() string {
+	return r.entry.Date.String()
+}
+
+func (r *MealPlanEntryResolver) Slot() string {
+	return r.entry.Slot
+}
+
+func (r *MealPlanEntryResolver) Servings() float64 {
+	return float64(r.entry.Servings)
+}
+
+func (r *MealPlanEntryResolver) People() int32 {
+	return int32(r.entry.People)
+}
+
+func (r *MealPlanEntryResolver) Cooked() bool {
+	return r.entry.Cooked
+}
+
+func (r *MealPlanEntryResolver) Batch() int32 {
+	return int32(r.entry.Batch)
+}
+
+func (r *MealPlanEntryResolver) Portions() float64 {
+	return float64(r.entry.Portions)
+}
+
+func (r *MealPlanEntryResolver) Leftover() bool {
+	return r.entry.Leftover
+}
+
+func (r *MealPlanEntryResolver) Members() []graphql.ID {
+	ids := make([]graphql.ID, len(r.entry.Members))
+	for i, id := range r.entry.Members {
+		ids[i] = formatId(id)
+	}
+	return ids
+}
+
+func (r *MealPlanEntryResolver) Meal() *MealResolver {
+	return r.meal
+}
@@ -0,0 +1,373 @@
+package graph
+
+import (
+	"github.com/cookbook/service"
+	"github.com/graph-gophers/graphql-go"
+)
+
+type RecipeResolver struct {
+	recipe      service.RecipeGet
+	ingredients []*IngredientResolver
+	group       *recipeGroup
+}
+
+type recipeGroup struct {
+	root      *Resolver
+	ids       []int64
+	mealsLoad lazy
+	meals     map[int64][]*MealResolver
+}
+
+type recipeIngredientInput struct {
+	ID     graphql.ID
+	Amount float64
+	Unit   string
+}
+
+type recipeInput struct {
+	Name        string
+	Steps       string
+	PrepMinutes *int32
+	CookMinutes *int32
+	Servings    *int32
+	Tags        *[]string
+	Ingredients []recipeIngredientInput
+}
+
+func (r *Resolver) newRecipes(recipes []service.RecipeGet) []*RecipeResolver {
+	group := &recipeGroup{root: r}
+	group.mealsLoad.load = group.loadMeals
+	var ingredients []service.Ingredient
+	for _, recipe := range recipes {
+		ingredients = append(ingredients, recipe.Ingredients...)
+	}
+	ingredientResolvers := r.newIngredients(ingredients)
+	resolvers := make([]*RecipeResolver, len(recipes))
+	for i, recipe := range recipes {
+		group.ids = append(group.ids, recipe.Id)
+		resolvers[i] = &RecipeResolver{
+			recipe:      recipe,
+			ingredients: ingredientResolvers[:len(recipe.Ingredients)],
+			group:       group,
+		}
+		ingredientResolvers = ingredientResolvers[len(recipe.Ingredients):]
+	}
+	return resolvers
+}
+
+// recipeResolvers loads the given recipes as a single list.
+func (r *Resolver) recipeResolvers(ids []int64) (map[int64]*RecipeResolver, error) {
+	resolvers := make(map[int64]*RecipeResolver)
+	if len(ids) == 0 {
+		return resolvers, nil
+	}
+	recipes, err := r.recipes.GetList(ids)
+	if err != nil {
+		return nil, handleError(err)
+	}
+	for _, recipe := range r.newRecipes(recipes) {
+		resolvers[recipe.recipe.Id] = recipe
+	}
+	return resolvers, nil
+}
+
+func (g *recipeGroup) loadMeals() error {
+	ids, err := g.root.meals.GetIdsByRecipes(g.ids)
+	if err != nil {
+		return handleError(err)
+	}
+	meals, err := g.root.mealResolvers(uniqueIds(ids))
+	if err != nil {
+		return err
+	}
+	g.meals = make(map[int64][]*MealResolver)
+	for recipeId, mealIds := range ids {
+		for _, mealId := range mealIds {
+			g.meals[recipeId] = append(g.meals[recipeId], meals[mealId])
+		}
+	}
+	return nil
+}
+
+func (r *Resolver) Recipe(args struct{ ID graphql.ID }) (*RecipeResolver, error) {
+	id, err := parseId(args.ID)
+	if err != nil {
+		return nil, err
+	}
+	recipe, err := r.recipes.Get(id)
+	if err != nil {
+		return nil, handleError(err)
+	}
+	return r.newRecipes([]service.RecipeGet{recipe})[0], nil
+}
+
+func (r *Resolver) Recipes() ([]*RecipeResolver, error) {
+	recipes, err := r.recipes.GetAll()
+	if err != nil {
+		return nil, handleError(err)
+	}
+	return r.newRecipes(recipes), nil
+}
+
+func (r *Resolver) CreateRecipe(args struct{ Input recipeInput }) (*RecipeResolver, error) {
+	recipe, err := args.Input.toService(0)
+	if err != nil {
+		return nil, err
+	}
+	id, err := r.recipes.Create(recipe)
+	if err != nil {
+		return nil, handleError(err)
+	}
+	return r.Recipe(struct{ ID graphql.ID }{formatId(id)})
+}
+
+func (r *Resolver) UpdateRecipe(args struct {
+	ID    graphql.ID
+	Input recipeInput
+}) (*RecipeResolver, error) {
+	id, err := parseId(args.ID)
+	if err != nil {
+		return nil, err
+	}
+	recipe, err := args.Input.toService(id)
+	if err != nil {
+		return nil, err
+	}
+	// Leaving out tags keeps the recipe's current tags.
+	if args.Input.Tags == nil {
+		current, err := r.recipes.Get(id)
+		if err != nil {
+			return nil, handleError(err)
+		}
+		recipe.Tags = current.Tags
+	}
+	err = r.recipes.Update(recipe)
+	if err != nil {
+		return nil, handleError(err)
+	}
+	return r.Recipe(struct{ ID graphql.ID }{args.ID})
+}
+
+func (r *Resolver) DeleteRecipe(args deleteArgs) (graphql.ID, error) {
+	id, opts, err := args.parse()
+	if err != nil {
+		return "", err
+	}
+	err = r.recipes.Delete(id, opts)
+	if err != nil {
+		return "", handleError(err)
+	}
+	return args.ID, nil
+}
+
+func (i recipeInput) toService(id int64) (service.RecipeCreate, error) {
+	recipe := service.RecipeCreate{
+		Id:    id,
+		Name:  i.Name,
+		Steps: i.Steps,
+	}
+	if i.PrepMinutes != nil {
+		recipe.PrepMinutes = *i.PrepMinutes
+	}
+	if i.CookMinutes != nil {
+		recipe.CookMinutes = *i.CookMinutes
+	}
+	if i.Servings != nil {
+		recipe.Servings = *i.Servings
+	}
+	if i.Tags != nil {
+		recipe.Tags = *i.Tags
+	}
+	for _, ing := range i.Ingredients {
+		ingredientId, err := parseId(ing.ID)
+		if err != nil {
+			return service.RecipeCreate{}, err
+		}
+		recipe.Ingredients = append(recipe.Ingredients, service.IngredientShort{
+			Id:     ingredientId,
+			Amount: float32(ing.Amount),
+			Unit:   ing.Unit,
+		})
+	}
+	return recipe, nil
+}
+
+func (r *RecipeResolver) ID() graphql.ID {
+	return formatId(r.recipe.Id)
+}
+
+func (r *RecipeResolver) Name() string {
+	return r.recipe.Name
+}
+
+func (r *RecipeResolver) Steps() string {
+	return r.recipe.Steps
+}
+
+func (r *RecipeResolver) PrepMinutes() int32 {
+	return r.recipe.PrepMinutes
+}
+
+func (r *RecipeResolver) CookMinutes() int32 {
+	return r.recipe.CookMinutes
+}
+
+func (r *RecipeResolver) Servings() int32 {
+	return r.recipe.Servings
+}
+
+func (r *RecipeResolver) Cost() *CostResolver {
+	return &CostResolver{r.recipe.Cost}
+}
+
+func (r *RecipeResolver) CostPerServing() float64 {
+	return float64(r.recipe.CostPerServing)
+}
+
+func (r *RecipeResolver) Allergens() []string {
+	return r.recipe.Allergens
+}
+
+func (r *RecipeResolver) Diets() []string {
+	return r.recipe.Diets
+}
+
+func (r *RecipeResolver) Tags() []string {
+	return r.recipe.Tags
+}
+
+func (r *RecipeResolver) Rating() *float64 {
+	if r.recipe.Rating == nil {
+		return nil
+	}
+	rating := float64(*r.recipe.Rating)
+	return &rating
+}
+
+func (r *RecipeResolver) RatingCount() int32 {
+	return int32(r.recipe.RatingCount)
+}
+
+func (r *RecipeResolver) LastCooked() *string {
+	if r.recipe.LastCooked == nil {
+		return nil
+	}
+	lastCooked := r.recipe.LastCooked.String()
+	return &lastCooked
+}
+
+func (r *RecipeResolver) TimesCooked() int32 {
+	return int32(r.recipe.TimesCooked)
+}
+
+func (r *RecipeResolver) Images() []*RecipeImageResolver {
+	images := make([]*RecipeImageResolver, len(r.recipe.Images))
+	for i, image := range r.recipe.Images {
+		images[i] = &RecipeImageResolver{image}
+	}
+	return images
+}
+
+func (r *RecipeResolver) Calories() float64 {
+	return float64(r.recipe.Calories)
+}
+
+func (r *RecipeResolver) Protein() float64 {
+	return float64(r.recipe.Protein)
+}
+
+func (r *RecipeResolver) Carbs() float64 {
+	return float64(r.recipe.Carbs)
+}
+
+func (r *RecipeResolver) Fat() float64 {
+	return float64(r.recipe.Fat)
+}
+
+func (r *RecipeResolver) Ingredients() []*IngredientResolver {
+	return r.ingredients
+}
+
+func (r *RecipeResolver) Meals() ([]*MealResolver, error) {
+	if err := r.group.mealsLoad.do(); err != nil {
+		return nil, err
+	}
+	return r.group.meals[r.recipe.Id], nil
+}
+
+type RecipeImageResolver struct {
+	image service.RecipeImage
+}
+
+func (r *RecipeImageResolver) ID() graphql.ID {
+	return formatId(r.image.Id)
+}
+
+func (r *RecipeImageResolver) Step() int32 {
+	return r.image.Step
+}
+
+func (r *RecipeImageResolver) ContentType() string {
+	return r.image.ContentType
+}
+
+func (r *RecipeImageResolver) Width() int32 {
+	return r.image.Width
+}
+
+func (r *RecipeImageResolver) Height() int32 {
+	return r.image.Height
+}
+
+// Size is a Float as GraphQL integers only have 32 bits.
+func (r *RecipeImageResolver) Size() float64 {
+	return float64(r.image.Size)
+}
+
+func (r *RecipeImageResolver) Url() string {
+	return r.image.Url
+}
+
+func (r *RecipeImageResolver) Thumbnails() []*ThumbnailResolver {
+	thumbnails := make([]*ThumbnailResolver, len(r.image.Thumbnails))
+	for i, thumbnail := range r.image.Thumbnails {
+		thumbnails[i] = &ThumbnailResolver{thumbnail}
+	}
+	return thumbnails
+}
+
+type ThumbnailResolver struct {
+	thumbnail service.Thumbnail
+}
+
+func (r *ThumbnailResolver) Size() string {
+	return r.thumbnail.Size
+}
+
+func (r *ThumbnailResolver) Width() int32 {
+	return r.thumbnail.Width
+}
+
+func (r *ThumbnailResolver) Height() int32 {
+	return r.thumbnail.Height
+}
+
+func (r *ThumbnailResolver) Url() string {
+	return r.thumbnail.Url
+}
+
+type CostResolver struct {
+	cost service.Cost
+}
+
+func (r *CostResolver) Total() float64 {
+	return float64(r.cost.Total)
+}
+
+func (r *CostResolver) Unpriced() []graphql.ID {
+	ids := make([]graphql.ID, len(r.cost.Unpriced))
+	for i, id := range r.cost.Unpriced {
+		ids[i] = formatId(id)
+	}
+	return ids
+}
@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/cookbook/graph"
+	"github.com/cookbook/service"
+)
+
+// Services are the services the REST API is served by.
+type Services struct {
+	Ingredients   service.IngredientService
+	Recipes       service.RecipeService
+	Meals         service.MealService
+	MealPlans     service.MealPlanService
+	Pantry        service.PantryService
+	Prices        service.IngredientPriceService
+	Substitutions service.SubstitutionService
+	Collections   service.CollectionService
+	Reviews       service.ReviewService
+	CookLog       service.CookLogService
+	Images        service.RecipeImageService
+	Archive       service.ArchiveService
+	Members       service.MemberService
+}
+
+// NewAPI registers every route of the REST API on a new router together with
+// its documentation. The docs page loads its assets from docsAssetsUrl.
+func NewAPI(s Services, docsAssetsUrl string) RestRouter {
+	router := NewRestRouter()
+	imageHandler := ImageHandler{Service: s.Images}
+	mealPlanHandler := MealPlanHandler{Service: s.MealPlans}
+	archiveHandler := ArchiveHandler{Service: s.Archive}
+
+	router.Register("ingredients", IngredientHandler{Service: s.Ingredients, Substitutions: s.Substitutions})
+	router.Register("recipes", RecipeHandler{Service: s.Recipes, Images: imageHandler})
+	router.Register("meals", MealHandler{Service: s.Meals})
+	router.Register("meal-plans", mealPlanHandler)
+	router.Register("pantry", PantryHandler{Service: s.Pantry})
+	router.Register("prices", IngredientPriceHandler{Service: s.Prices})
+	router.Register("substitutions", SubstitutionHandler{Service: s.Substitutions})
+	router.Register("collections", CollectionHandler{Service: s.Collections})
+	router.Register("reviews", ReviewHandler{Service: s.Reviews})
+	router.Register("cook-log", CookLogHandler{Service: s.CookLog})
+	router.Register("members", MemberHandler{Service: s.Members})
+	router.Handle(http.MethodGet, "/calendar", CalendarHandler{Service: s.MealPlans}.Get, Operation{
+		Summary:    "List the meals planned by followed meal plans per day",
+		Response:   []service.CalendarDay{},
+		Parameters: CalendarParameters,
+	})
+	router.Handle(http.MethodGet, "/images/{id}", imageHandler.Get, Operation{
+		Summary:     "Get an image or one of its thumbnails",
+		ContentType: "image/*",
+		Parameters:  ImageParameters,
+	})
+	router.Handle(http.MethodDelete, "/images/{id}", imageHandler.Delete, Operation{Summary: "Delete an image"})
+	router.Handle(http.MethodGet, "/export", archiveHandler.Export, Operation{
+		Summary:     "Export all ingredients, recipes, meals, meal plans and members as a zip archive",
+		ContentType: "application/zip",
+	})
+	router.Handle(http.MethodPost, "/import", archiveHandler.Import, Operation{
+		Summary:            "Import an archive written by the export",
+		Request:            File{},
+		RequestContentType: "application/zip",
+		Response:           service.ImportResult{},
+		Parameters:         ArchiveImportParameters,
+		Errors:             []int{http.StatusConflict, http.StatusRequestEntityTooLarge},
+	})
+	router.Handle(http.MethodGet, "/feeds/{token}.ics", mealPlanHandler.Feed, Operation{
+		Summary:     "Meal plan calendar feed",
+		ContentType: "text/calendar",
+		Parameters:  []Parameter{{Name: "token", Type: "string", Description: "Token of the feed URL returned when creating the feed"}},
+	})
+	router.Handle(http.MethodPost, "/graphql", graph.NewHandler(s.Ingredients, s.Recipes, s.Meals, s.MealPlans).ServeHTTP, Operation{
+		Summary:  "GraphQL endpoint",
+		Request:  graph.Request{},
+		Response: graph.Response{},
+	})
+	router.ServeDocs(docsAssetsUrl)
+	return router
+}
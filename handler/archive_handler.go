@@ -0,0 +1,91 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/cookbook/service"
+)
+
+const (
+	archiveContentType = "application/zip"
+	// maxArchiveSize limits the size of imported archives.
+	maxArchiveSize = 1 << 30
+)
+
+type ArchiveHandler struct {
+	Service service.ArchiveService
+}
+
+var ArchiveImportParameters = []Parameter{
+	{Name: "conflict", Type: "string", Description: "What to do with entities named like existing ones: " + strings.Join(service.ConflictPolicies, ", ") + ", fail by default"},
+}
+
+// archiveWriter sets the archive headers on the first write, errors before
+// it can still be sent as a problem.
+type archiveWriter struct {
+	http.ResponseWriter
+	started bool
+}
+
+func (w *archiveWriter) Write(p []byte) (int, error) {
+	if !w.started {
+		w.started = true
+		w.Header().Set("Content-Type", archiveContentType)
+		w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="cookbook-%s.zip"`, time.Now().Format("2006-01-02")))
+	}
+	return w.ResponseWriter.Write(p)
+}
+
+// Export streams the archive. Once it has started an error can only cut it
+// short, which leaves it unreadable as the zip directory comes last.
+func (handler ArchiveHandler) Export(w http.ResponseWriter, r *http.Request) {
+	writer := &archiveWriter{ResponseWriter: w}
+	err := handler.Service.Export(writer)
+	if err != nil {
+		if writer.started {
+			log.Println("Export failed: " + err.Error())
+			return
+		}
+		handleError(w, r, err)
+	}
+}
+
+// Import spools the archive to a temporary file, as zip archives are read
+// from the end.
+func (handler ArchiveHandler) Import(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Content-Type") != archiveContentType {
+		problemResponse(w, r, newProblem(http.StatusUnsupportedMediaType, "unsupported_media_type", "Content Type is not "+archiveContentType))
+		return
+	}
+	file, err := ioutil.TempFile("", "cookbook-import-*.zip")
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+	defer os.Remove(file.Name())
+	defer file.Close()
+	size, err := io.Copy(file, http.MaxBytesReader(w, r.Body, maxArchiveSize))
+	if err != nil {
+		if strings.Contains(err.Error(), "request body too large") {
+			problemResponse(w, r, newProblem(http.StatusRequestEntityTooLarge, "payload_too_large", fmt.Sprintf("Archives must not be larger than %d MB", maxArchiveSize>>20)))
+		} else {
+			problemResponse(w, r, newProblem(http.StatusBadRequest, "malformed_body", "Bad Request "+err.Error()))
+		}
+		return
+	}
+	result, err := handler.Service.Import(file, size, r.URL.Query().Get("conflict"))
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
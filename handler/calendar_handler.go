@@ -0,0 +1,43 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/cookbook/service"
+)
+
+type CalendarHandler struct {
+	Service service.MealPlanService
+}
+
+var CalendarParameters = []Parameter{
+	{Name: "from", Format: "date", Required: true, Description: "First day of the calendar, YYYY-MM-DD"},
+	{Name: "to", Format: "date", Required: true, Description: "Last day of the calendar, YYYY-MM-DD"},
+}
+
+// Get responds with the entries of all followed meal plans between the from
+// and to dates, grouped by day.
+func (handler CalendarHandler) Get(w http.ResponseWriter, r *http.Request) {
+	var fields []service.FieldError
+	query := r.URL.Query()
+	from, err := service.ParseDate(query.Get("from"))
+	if err != nil {
+		fields = append(fields, service.FieldError{Field: "from", Message: "must be a date formatted as YYYY-MM-DD"})
+	}
+	to, err := service.ParseDate(query.Get("to"))
+	if err != nil {
+		fields = append(fields, service.FieldError{Field: "to", Message: "must be a date formatted as YYYY-MM-DD"})
+	}
+	if len(fields) > 0 {
+		invalidParameters(w, r, fields)
+		return
+	}
+	calendar, err := handler.Service.GetCalendar(from, to)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(calendar)
+}
@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/cookbook/service"
+)
+
+type CollectionHandler struct {
+	Service service.CollectionService
+}
+
+func (handler CollectionHandler) Resource() Resource {
+	return Resource{Name: "collection", Get: service.CollectionGet{}, Create: service.CollectionCreate{}}
+}
+
+func (handler CollectionHandler) Get(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	collections, err := handler.Service.GetAll()
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+	json.NewEncoder(w).Encode(collections)
+}
+
+func (handler CollectionHandler) GetById(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseId(w, r)
+	if !ok {
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	collection, err := handler.Service.Get(id)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+	json.NewEncoder(w).Encode(collection)
+}
+
+func (handler CollectionHandler) Post(w http.ResponseWriter, r *http.Request) {
+	var collection service.CollectionCreate
+	if !decodeBody(w, r, &collection) {
+		return
+	}
+	_, err := handler.Service.Create(collection)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+	messageResponse(w, "Success", http.StatusOK)
+}
+
+func (handler CollectionHandler) Put(w http.ResponseWriter, r *http.Request) {
+	var collection service.CollectionCreate
+	if !decodeBody(w, r, &collection) {
+		return
+	}
+	err := handler.Service.Update(collection)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+	messageResponse(w, "Success", http.StatusOK)
+}
+
+func (handler CollectionHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseId(w, r)
+	if !ok {
+		return
+	}
+	err := handler.Service.Delete(id)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+}
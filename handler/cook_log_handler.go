@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/cookbook/service"
+)
+
+type CookLogHandler struct {
+	Service service.CookLogService
+}
+
+func (handler CookLogHandler) Resource() Resource {
+	return Resource{Name: "cook log record", Get: service.CookLogEntry{}, Create: service.CookLogEntry{}, ListParameters: recipeIdParameters}
+}
+
+func (handler CookLogHandler) Get(w http.ResponseWriter, r *http.Request) {
+	recipeId, ok := parseRecipeQuery(w, r)
+	if !ok {
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	entries, err := handler.Service.GetAll(recipeId)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+	json.NewEncoder(w).Encode(entries)
+}
+
+func (handler CookLogHandler) GetById(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseId(w, r)
+	if !ok {
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	entry, err := handler.Service.Get(id)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+	json.NewEncoder(w).Encode(entry)
+}
+
+func (handler CookLogHandler) Post(w http.ResponseWriter, r *http.Request) {
+	var entry service.CookLogEntry
+	if !decodeBody(w, r, &entry) {
+		return
+	}
+	_, err := handler.Service.Create(entry)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+	messageResponse(w, "Success", http.StatusOK)
+}
+
+func (handler CookLogHandler) Put(w http.ResponseWriter, r *http.Request) {
+	var entry service.CookLogEntry
+	if !decodeBody(w, r, &entry) {
+		return
+	}
+	err := handler.Service.Update(entry)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+	messageResponse(w, "Success", http.StatusOK)
+}
+
+func (handler CookLogHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseId(w, r)
+	if !ok {
+		return
+	}
+	err := handler.Service.Delete(id)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+}
@@ -0,0 +1,36 @@
+package handler
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/cookbook/service"
+)
+
+var dietParameters = []Parameter{
+	{Name: "exclude_allergens", Type: "string", Description: "Comma separated allergens none of which may be contained: " + strings.Join(service.Allergens, ", ")},
+	{Name: "diet", Type: "string", Description: "Comma separated diets that must all be met: " + strings.Join(service.Diets, ", ")},
+}
+
+// parseDietFilter reads the exclude_allergens and diet query parameters,
+// responding with a problem if they name unknown allergens or diets.
+func parseDietFilter(w http.ResponseWriter, r *http.Request) (service.DietFilter, bool) {
+	filter, fields := readDietFilter(r.URL.Query())
+	if len(fields) > 0 {
+		invalidParameters(w, r, fields)
+		return filter, false
+	}
+	return filter, true
+}
+
+func readDietFilter(query url.Values) (filter service.DietFilter, fields []service.FieldError) {
+	filter.ExcludeAllergens = splitList(query.Get("exclude_allergens"))
+	filter.Diets = splitList(query.Get("diet"))
+	if err := filter.Validate(); err != nil {
+		if v, ok := err.(*service.ValidationError); ok {
+			fields = v.Fields()
+		}
+	}
+	return filter, fields
+}
@@ -0,0 +1,228 @@
+package handler
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/cookbook/service"
+	"github.com/gorilla/mux"
+)
+
+const problemContentType = "application/problem+json"
+
+type requestIdKey struct{}
+
+// requestIdRegex matches the request ids accepted from clients: up to 128
+// characters safe to echo in headers, problem bodies and logs.
+var requestIdRegex = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+type Message struct {
+	Message string `json:"message"`
+}
+
+// Problem is an RFC 7807 problem details response.
+type Problem struct {
+	Type      string               `json:"type"`
+	Title     string               `json:"title"`
+	Status    int                  `json:"status"`
+	Detail    string               `json:"detail,omitempty"`
+	Instance  string               `json:"instance,omitempty"`
+	Code      string               `json:"code"`
+	RequestId string               `json:"request_id,omitempty"`
+	Errors    []service.FieldError `json:"errors,omitempty"`
+	// Dependents lists the entities preventing a delete, by type.
+	Dependents []Dependents `json:"dependents,omitempty"`
+}
+
+type Dependents struct {
+	Type string  `json:"type"`
+	Ids  []int64 `json:"ids"`
+}
+
+func newProblem(status int, code, detail string) Problem {
+	return Problem{
+		Type:   "urn:cookbook:problem:" + code,
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: detail,
+		Code:   code,
+	}
+}
+
+func messageResponse(w http.ResponseWriter, message string, httpStatusCode int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(httpStatusCode)
+	jsonResp, _ := json.Marshal(Message{Message: message})
+	w.Write(jsonResp)
+}
+
+func problemResponse(w http.ResponseWriter, r *http.Request, problem Problem) {
+	problem.Instance = r.URL.Path
+	problem.RequestId = requestId(r)
+	w.Header().Set("Content-Type", problemContentType)
+	w.WriteHeader(problem.Status)
+	jsonResp, _ := json.Marshal(problem)
+	w.Write(jsonResp)
+}
+
+func handleError(w http.ResponseWriter, r *http.Request, err error) {
+	switch x := err.(type) {
+	case *service.NotFound:
+		problemResponse(w, r, newProblem(http.StatusNotFound, "not_found", x.Error()))
+	case *service.Conflict:
+		problem := newProblem(http.StatusConflict, "conflict", x.Error())
+		if len(x.Dependents) > 0 {
+			problem.Detail += ", delete with cascade=true or replace_with=ID to update them"
+			for _, dependents := range x.Dependents {
+				problem.Dependents = append(problem.Dependents, Dependents{Type: dependents.Type, Ids: dependents.Ids})
+			}
+		}
+		problemResponse(w, r, problem)
+	case *service.ValidationError:
+		problem := newProblem(http.StatusBadRequest, "validation_failed", "The request body contains invalid values")
+		problem.Errors = x.Fields()
+		problemResponse(w, r, problem)
+	default:
+		log.Printf("request %s: %v", requestId(r), err)
+		problemResponse(w, r, newProblem(http.StatusInternalServerError, "internal_error", "Internal server error, if the error persists contact server admin"))
+	}
+}
+
+// parseId reads the id path parameter, responding with 400 if it is not an
+// integer.
+func parseId(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	return parsePathInt(w, r, "id")
+}
+
+func parsePathInt(w http.ResponseWriter, r *http.Request, name string) (int64, bool) {
+	value, err := strconv.ParseInt(mux.Vars(r)[name], 10, 64)
+	if err != nil {
+		problem := newProblem(http.StatusBadRequest, "invalid_parameter", "Path parameter "+name+" must be an integer")
+		problem.Errors = []service.FieldError{{Field: name, Message: "must be an integer"}}
+		problemResponse(w, r, problem)
+		return 0, false
+	}
+	return value, true
+}
+
+// parseDeleteOptions reads the cascade and replace_with query parameters.
+func parseDeleteOptions(w http.ResponseWriter, r *http.Request) (opts service.DeleteOptions, ok bool) {
+	var fields []service.FieldError
+	query := r.URL.Query()
+	if cascade := query.Get("cascade"); cascade != "" {
+		var err error
+		opts.Cascade, err = strconv.ParseBool(cascade)
+		if err != nil {
+			fields = append(fields, service.FieldError{Field: "cascade", Message: "must be a boolean"})
+		}
+	}
+	if replaceWith := query.Get("replace_with"); replaceWith != "" {
+		var err error
+		opts.ReplaceWith, err = strconv.ParseInt(replaceWith, 10, 64)
+		if err != nil {
+			fields = append(fields, service.FieldError{Field: "replace_with", Message: "must be an integer"})
+		}
+	}
+	if len(fields) > 0 {
+		problem := newProblem(http.StatusBadRequest, "invalid_parameter", "Invalid query parameters")
+		problem.Errors = fields
+		problemResponse(w, r, problem)
+		return opts, false
+	}
+	return opts, true
+}
+
+// splitList splits a comma separated query parameter, ignoring blank items.
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// splitIds splits a comma separated query parameter into ids, ok is false if
+// any of them isn't an integer.
+func splitIds(value string) (ids []int64, ok bool) {
+	for _, item := range splitList(value) {
+		id, err := strconv.ParseInt(item, 10, 64)
+		if err != nil {
+			return nil, false
+		}
+		ids = append(ids, id)
+	}
+	return ids, true
+}
+
+// invalidParameters responds with a problem listing the invalid query
+// parameters.
+func invalidParameters(w http.ResponseWriter, r *http.Request, fields []service.FieldError) {
+	problem := newProblem(http.StatusBadRequest, "invalid_parameter", "Invalid query parameters")
+	problem.Errors = fields
+	problemResponse(w, r, problem)
+}
+
+// decodeBody decodes a JSON request body into v, responding with a problem
+// if the content type is wrong or the body doesn't match v.
+func decodeBody(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	headerContentTtype := r.Header.Get("Content-Type")
+	if headerContentTtype != "application/json" {
+		problemResponse(w, r, newProblem(http.StatusUnsupportedMediaType, "unsupported_media_type", "Content Type is not application/json"))
+		return false
+	}
+	var unmarshalErr *json.UnmarshalTypeError
+
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	err := decoder.Decode(v)
+	if err != nil {
+		problem := newProblem(http.StatusBadRequest, "malformed_body", "Bad Request "+err.Error())
+		if errors.As(err, &unmarshalErr) {
+			problem.Detail = "Bad Request. Wrong Type provided for field " + unmarshalErr.Field
+			problem.Errors = []service.FieldError{{Field: unmarshalErr.Field, Message: "expected " + unmarshalErr.Type.String() + ", got " + unmarshalErr.Value}}
+		} else if strings.HasPrefix(err.Error(), "json: unknown field ") {
+			field := strings.Trim(strings.TrimPrefix(err.Error(), "json: unknown field "), `"`)
+			problem.Errors = []service.FieldError{{Field: field, Message: "unknown field"}}
+		}
+		problemResponse(w, r, problem)
+		return false
+	}
+	return true
+}
+
+// requestIdMiddleware tags every request with an id, taken from the
+// X-Request-Id header if the client sent a valid one, and echoes it in the
+// response.
+func requestIdMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get("X-Request-Id")
+		if !requestIdRegex.MatchString(id) {
+			id = newRequestId()
+		}
+		w.Header().Set("X-Request-Id", id)
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), requestIdKey{}, id)))
+	})
+}
+
+func requestId(r *http.Request) string {
+	if id, ok := r.Context().Value(requestIdKey{}).(string); ok {
+		return id
+	}
+	return ""
+}
+
+func newRequestId() string {
+	b := make([]byte, 8)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRequestIdMiddleware(t *testing.T) {
+	tests := []struct {
+		sent string
+		kept bool
+	}{
+		{"", false},
+		{"4bf92f3577b34da6", true},
+		{"client:42_retry-1.a", true},
+		{strings.Repeat("a", 128), true},
+		{strings.Repeat("a", 129), false},
+		{"id with spaces", false},
+		{"id\r\nX-Injected: 1", false},
+		{"<script>", false},
+	}
+	for _, test := range tests {
+		var seen string
+		handler := requestIdMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			seen = requestId(r)
+		}))
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Set("X-Request-Id", test.sent)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		echoed := w.Header().Get("X-Request-Id")
+		if echoed != seen {
+			t.Errorf("sent %q: echoed %q but tagged the request with %q", test.sent, echoed, seen)
+		}
+		if kept := echoed == test.sent; kept != test.kept {
+			t.Errorf("sent %q: echoed %q", test.sent, echoed)
+		}
+		if !requestIdRegex.MatchString(echoed) {
+			t.Errorf("sent %q: echoed unsafe id %q", test.sent, echoed)
+		}
+	}
+}
@@ -12,21 +12,85 @@ type RestHandler interface {
 	Post(w http.ResponseWriter, r *http.Request)
 	Put(w http.ResponseWriter, r *http.Request)
 	Delete(w http.ResponseWriter, r *http.Request)
+	Resource() Resource
+}
+
+// Resource describes the models a RestHandler reads and writes. It is used to
+// document the CRUD routes created by Register. Dependents is set if other
+// resources reference this one, its deletes then accept the cascade and
+// replace_with parameters and may conflict. ListParameters documents the
+// query parameters accepted when listing.
+type Resource struct {
+	Name           string
+	Get            interface{}
+	Create         interface{}
+	Dependents     bool
+	ListParameters []Parameter
+}
+
+var deleteParameters = []Parameter{
+	{Name: "cascade", Type: "boolean", Description: "Remove the deleted entity from the entities referencing it"},
+	{Name: "replace_with", Type: "integer", Description: "Reference the entity with this id instead of the deleted one, merged into the references of entities already using it"},
+}
+
+// ExtendedHandler is implemented by handlers serving routes beyond the CRUD
+// set. Its routes are registered before the CRUD ones so static paths such as
+// /recipes/search take precedence over /recipes/{id}.
+type ExtendedHandler interface {
+	Routes(router SubRouter)
 }
 
 type RestRouter struct {
 	*mux.Router
+	docs map[string]Operation
+}
+
+// SubRouter registers routes below an endpoint prefix.
+type SubRouter struct {
+	*mux.Router
+	prefix string
+	docs   map[string]Operation
 }
 
 func NewRestRouter() RestRouter {
-	return RestRouter{mux.NewRouter()}
+	router := RestRouter{mux.NewRouter(), make(map[string]Operation)}
+	router.Use(requestIdMiddleware)
+	router.NotFoundHandler = requestIdMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		problemResponse(w, r, newProblem(http.StatusNotFound, "not_found", "No route matches "+r.URL.Path))
+	}))
+	router.MethodNotAllowedHandler = requestIdMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		problemResponse(w, r, newProblem(http.StatusMethodNotAllowed, "method_not_allowed", "Method "+r.Method+" is not allowed on "+r.URL.Path))
+	}))
+	return router
+}
+
+// Handle registers a route together with its documentation.
+func (router RestRouter) Handle(method, path string, f http.HandlerFunc, op Operation) {
+	router.Path(path).Methods(method).HandlerFunc(f)
+	router.docs[method+" "+path] = op
+}
+
+// Handle registers a route relative to the subrouter prefix together with its
+// documentation.
+func (router SubRouter) Handle(method, path string, f http.HandlerFunc, op Operation) {
+	router.Path(path).Methods(method).HandlerFunc(f)
+	router.docs[method+" "+router.prefix+path] = op
 }
 
 func (router RestRouter) Register(endpoint string, handler RestHandler) {
-	subrouter := router.PathPrefix("/" + endpoint).Subrouter()
-	subrouter.Path("").Methods(http.MethodGet).HandlerFunc(handler.Get)
-	subrouter.Path("").Methods(http.MethodPost).HandlerFunc(handler.Post)
-	subrouter.Path("/{id}").Methods(http.MethodGet).HandlerFunc(handler.GetById)
-	subrouter.Path("/{id}").Methods(http.MethodPut).HandlerFunc(handler.Put)
-	subrouter.Path("/{id}").Methods(http.MethodDelete).HandlerFunc(handler.Delete)
+	subrouter := SubRouter{router.PathPrefix("/" + endpoint).Subrouter(), "/" + endpoint, router.docs}
+	if h, ok := handler.(ExtendedHandler); ok {
+		h.Routes(subrouter)
+	}
+	res := handler.Resource()
+	subrouter.Handle(http.MethodGet, "", handler.Get, Operation{Summary: "List " + res.Name + "s", Response: listOf(res.Get), Parameters: res.ListParameters})
+	subrouter.Handle(http.MethodPost, "", handler.Post, Operation{Summary: "Create " + res.Name, Request: res.Create, Response: Message{}})
+	subrouter.Handle(http.MethodGet, "/{id}", handler.GetById, Operation{Summary: "Get " + res.Name, Response: res.Get})
+	subrouter.Handle(http.MethodPut, "/{id}", handler.Put, Operation{Summary: "Update " + res.Name, Request: res.Create, Response: Message{}})
+	deleteOp := Operation{Summary: "Delete " + res.Name}
+	if res.Dependents {
+		deleteOp.Parameters = deleteParameters
+		deleteOp.Errors = []int{http.StatusConflict}
+	}
+	subrouter.Handle(http.MethodDelete, "/{id}", handler.Delete, deleteOp)
 }
@@ -0,0 +1,164 @@
+package handler
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/cookbook/service"
+)
+
+const icalContentType = "text/calendar; charset=utf-8"
+
+// slotTime is the time of day a meal slot starts at and how long it lasts.
+// Entries in custom slots become all day events.
+type slotTime struct {
+	hour, minute int
+	duration     time.Duration
+}
+
+var slotTimes = map[string]slotTime{
+	service.SlotBreakfast: {8, 0, 30 * time.Minute},
+	service.SlotLunch:     {12, 30, time.Hour},
+	service.SlotSnack:     {16, 0, 30 * time.Minute},
+	service.SlotDinner:    {19, 0, time.Hour},
+}
+
+// writeMealPlanCalendar writes the meal plan as an iCalendar with an event per
+// planned meal. Events of timed slots remind when to start preparing the meal,
+// based on the longest preparation and cooking time of its recipes. Links
+// point to the meals and recipes below baseUrl.
+func writeMealPlanCalendar(w io.Writer, mealPlan service.MealPlanGet, baseUrl string) error {
+	cal := &icalWriter{w: w}
+	cal.line("BEGIN", "VCALENDAR")
+	cal.line("VERSION", "2.0")
+	cal.line("PRODID", "-//Cookbook//Meal plans//EN")
+	cal.line("CALSCALE", "GREGORIAN")
+	cal.line("METHOD", "PUBLISH")
+	cal.text("X-WR-CALNAME", mealPlan.Name)
+	stamp := time.Now().UTC().Format("20060102T150405Z")
+	// UIDs are built from what identifies an entry rather than its position,
+	// so adding or removing entries doesn't change the events of the others.
+	// The same meal planned again in a slot is numbered from 2.
+	uids := make(map[string]int)
+	for _, entry := range mealPlan.Entries {
+		meal := entry.Meal
+		uid := fmt.Sprintf("meal-plan-%d-%s-%s-%d", mealPlan.Id, entry.Date.Format("20060102"), url.QueryEscape(entry.Slot), meal.Id)
+		uids[uid]++
+		if n := uids[uid]; n > 1 {
+			uid = fmt.Sprintf("%s-%d", uid, n)
+		}
+		cal.line("BEGIN", "VEVENT")
+		cal.line("UID", uid+"@cookbook")
+		cal.line("DTSTAMP", stamp)
+		slot, timed := slotTimes[entry.Slot]
+		if timed {
+			start := time.Date(entry.Date.Year(), entry.Date.Month(), entry.Date.Day(), slot.hour, slot.minute, 0, 0, time.UTC)
+			cal.line("DTSTART", start.Format("20060102T150405"))
+			cal.line("DTEND", start.Add(slot.duration).Format("20060102T150405"))
+		} else {
+			cal.line("DTSTART;VALUE=DATE", entry.Date.Format("20060102"))
+			cal.line("DTEND;VALUE=DATE", entry.Date.AddDays(1).Format("20060102"))
+		}
+		cal.text("SUMMARY", strings.Title(entry.Slot)+": "+meal.Name)
+		cal.text("DESCRIPTION", mealDescription(entry, baseUrl))
+		cal.line("URL", fmt.Sprintf("%s/meals/%d", baseUrl, meal.Id))
+		if lead := prepMinutes(meal); timed && lead > 0 {
+			cal.line("BEGIN", "VALARM")
+			cal.line("ACTION", "DISPLAY")
+			cal.line("TRIGGER", fmt.Sprintf("-PT%dM", lead))
+			cal.text("DESCRIPTION", "Start preparing "+meal.Name)
+			cal.line("END", "VALARM")
+		}
+		cal.line("END", "VEVENT")
+	}
+	cal.line("END", "VCALENDAR")
+	return cal.err
+}
+
+func mealDescription(entry service.MealPlanEntryGet, baseUrl string) string {
+	var b strings.Builder
+	switch {
+	case entry.Leftover:
+		fmt.Fprintf(&b, "Leftovers: %g servings\n", entry.Portions)
+	case entry.Batch > 0:
+		fmt.Fprintf(&b, "Servings: %g, eating %g servings\n", entry.Servings, entry.Portions)
+	default:
+		fmt.Fprintf(&b, "Servings: %g\n", entry.Servings)
+	}
+	if entry.People > 1 {
+		fmt.Fprintf(&b, "People: %d\n", entry.People)
+	}
+	for _, recipe := range entry.Meal.Recipes {
+		fmt.Fprintf(&b, "\n%s", recipe.Name)
+		if recipe.PrepMinutes > 0 || recipe.CookMinutes > 0 {
+			fmt.Fprintf(&b, " (prep %d min, cook %d min)", recipe.PrepMinutes, recipe.CookMinutes)
+		}
+		fmt.Fprintf(&b, "\n%s/recipes/%d\n", baseUrl, recipe.Id)
+	}
+	return b.String()
+}
+
+// prepMinutes returns how long before serving the meal preparation has to
+// start, recipes are assumed to be prepared in parallel.
+func prepMinutes(meal service.MealGet) int32 {
+	var minutes int32
+	for _, recipe := range meal.Recipes {
+		if total := recipe.PrepMinutes + recipe.CookMinutes; total > minutes {
+			minutes = total
+		}
+	}
+	return minutes
+}
+
+// icalWriter writes content lines as defined by RFC 5545, folding lines
+// longer than 75 octets. The first write error is kept in err and stops any
+// further output.
+type icalWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (cal *icalWriter) line(name, value string) {
+	if cal.err != nil {
+		return
+	}
+	line := name + ":" + value
+	var b strings.Builder
+	for len(line) > 75 {
+		cut := 75
+		if b.Len() > 0 {
+			cut = 74
+		}
+		// Don't split multi-byte characters.
+		for cut > 0 && line[cut]&0xC0 == 0x80 {
+			cut--
+		}
+		b.WriteString(line[:cut] + "\r\n ")
+		line = line[cut:]
+	}
+	b.WriteString(line + "\r\n")
+	_, cal.err = io.WriteString(cal.w, b.String())
+}
+
+var icalTextEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+
+func (cal *icalWriter) text(name, value string) {
+	cal.line(name, icalTextEscaper.Replace(value))
+}
+
+// baseUrl returns the scheme and host the request was sent to, honouring
+// X-Forwarded-Proto set by proxies.
+func baseUrl(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	if proto := r.Header.Get("X-Forwarded-Proto"); proto != "" {
+		scheme = proto
+	}
+	return scheme + "://" + r.Host
+}
@@ -0,0 +1,159 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/cookbook/service"
+	"github.com/gorilla/mux"
+)
+
+// maxFormOverhead is the room left in upload requests for the multipart
+// framing around the image.
+const maxFormOverhead = 1 << 20
+
+// File documents a file sent in a multipart form.
+type File struct{}
+
+// ImageUpload documents the multipart form images are uploaded with.
+type ImageUpload struct {
+	Image File `json:"image" validate:"required"`
+}
+
+type ImageHandler struct {
+	Service service.RecipeImageService
+}
+
+var ImageParameters = []Parameter{
+	{Name: "size", Type: "string", Description: "Serve the thumbnail of this size, " + strings.Join(thumbnailSizes(), ", ") + ", instead of the image as uploaded"},
+}
+
+// recipeRoutes registers the image routes below the recipes endpoint.
+func (handler ImageHandler) recipeRoutes(router SubRouter) {
+	router.Handle(http.MethodGet, "/{id}/images", handler.GetByRecipe, Operation{Summary: "List the images of a recipe", Response: []service.RecipeImage{}})
+	router.Handle(http.MethodPost, "/{id}/images", handler.Upload, Operation{
+		Summary:            "Upload an image of the dish",
+		Request:            ImageUpload{},
+		RequestContentType: "multipart/form-data",
+		Response:           service.RecipeImage{},
+		Errors:             []int{http.StatusRequestEntityTooLarge},
+	})
+	router.Handle(http.MethodPost, "/{id}/steps/{step}/images", handler.Upload, Operation{
+		Summary:            "Upload an image of a step",
+		Request:            ImageUpload{},
+		RequestContentType: "multipart/form-data",
+		Response:           service.RecipeImage{},
+		Parameters:         []Parameter{{Name: "step", Type: "integer", Format: "int32", Description: "Number of the step, counting from 1"}},
+		Errors:             []int{http.StatusRequestEntityTooLarge},
+	})
+}
+
+func (handler ImageHandler) GetByRecipe(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseId(w, r)
+	if !ok {
+		return
+	}
+	images, err := handler.Service.GetAll(id)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(images)
+}
+
+// Upload stores the image sent in the image field of a multipart form, of
+// the dish or of the step given in the path.
+func (handler ImageHandler) Upload(w http.ResponseWriter, r *http.Request) {
+	recipeId, ok := parseId(w, r)
+	if !ok {
+		return
+	}
+	var step int64
+	if _, ok := mux.Vars(r)["step"]; ok {
+		if step, ok = parsePathInt(w, r, "step"); !ok {
+			return
+		}
+		if step < 1 {
+			problem := newProblem(http.StatusBadRequest, "invalid_parameter", "Path parameter step must be a positive integer")
+			problem.Errors = []service.FieldError{{Field: "step", Message: "must be a positive integer"}}
+			problemResponse(w, r, problem)
+			return
+		}
+	}
+	r.Body = http.MaxBytesReader(w, r.Body, service.MaxImageSize+maxFormOverhead)
+	file, _, err := r.FormFile("image")
+	if err != nil {
+		switch {
+		case err == http.ErrNotMultipart:
+			problemResponse(w, r, newProblem(http.StatusUnsupportedMediaType, "unsupported_media_type", "Content Type is not multipart/form-data"))
+		case err == http.ErrMissingFile:
+			problem := newProblem(http.StatusBadRequest, "validation_failed", "The request body contains invalid values")
+			problem.Errors = []service.FieldError{{Field: "image", Message: "Image must be provided"}}
+			problemResponse(w, r, problem)
+		case strings.Contains(err.Error(), "request body too large"):
+			problemResponse(w, r, newProblem(http.StatusRequestEntityTooLarge, "payload_too_large", fmt.Sprintf("Images must not be larger than %d MB", service.MaxImageSize>>20)))
+		default:
+			problemResponse(w, r, newProblem(http.StatusBadRequest, "malformed_body", "Bad Request "+err.Error()))
+		}
+		return
+	}
+	defer file.Close()
+	image, err := handler.Service.Upload(recipeId, int32(step), file)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(image)
+}
+
+// Get serves the image data. Images never change once uploaded so clients
+// may cache them for good.
+func (handler ImageHandler) Get(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseId(w, r)
+	if !ok {
+		return
+	}
+	size := r.URL.Query().Get("size")
+	valid := size == ""
+	for _, name := range thumbnailSizes() {
+		valid = valid || name == size
+	}
+	if !valid {
+		invalidParameters(w, r, []service.FieldError{{Field: "size", Message: "must be one of " + strings.Join(thumbnailSizes(), ", ")}})
+		return
+	}
+	blob, contentType, err := handler.Service.Open(id, size)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+	defer blob.Close()
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+	io.Copy(w, blob)
+}
+
+func (handler ImageHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseId(w, r)
+	if !ok {
+		return
+	}
+	err := handler.Service.Delete(id)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+}
+
+func thumbnailSizes() []string {
+	var names []string
+	for _, size := range service.ThumbnailSizes {
+		names = append(names, size.Name)
+	}
+	return names
+}
@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/cookbook/service"
+)
+
+type IngredientPriceHandler struct {
+	Service service.IngredientPriceService
+}
+
+func (handler IngredientPriceHandler) Resource() Resource {
+	return Resource{Name: "ingredient price", Get: service.IngredientPrice{}, Create: service.IngredientPrice{}}
+}
+
+func (handler IngredientPriceHandler) Get(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	prices, err := handler.Service.GetAll()
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+	json.NewEncoder(w).Encode(prices)
+}
+
+func (handler IngredientPriceHandler) GetById(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseId(w, r)
+	if !ok {
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	price, err := handler.Service.Get(id)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+	json.NewEncoder(w).Encode(price)
+}
+
+func (handler IngredientPriceHandler) Post(w http.ResponseWriter, r *http.Request) {
+	var price service.IngredientPrice
+	if !decodeBody(w, r, &price) {
+		return
+	}
+	_, err := handler.Service.Create(price)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+	messageResponse(w, "Success", http.StatusOK)
+}
+
+func (handler IngredientPriceHandler) Put(w http.ResponseWriter, r *http.Request) {
+	var price service.IngredientPrice
+	if !decodeBody(w, r, &price) {
+		return
+	}
+	err := handler.Service.Update(price)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+	messageResponse(w, "Success", http.StatusOK)
+}
+
+func (handler IngredientPriceHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseId(w, r)
+	if !ok {
+		return
+	}
+	err := handler.Service.Delete(id)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+}
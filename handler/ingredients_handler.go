@@ -2,128 +2,121 @@ package handler
 
 import (
 	"encoding/json"
-	"errors"
 	"net/http"
-	"strconv"
 
 	"github.com/cookbook/service"
-	"github.com/gorilla/mux"
 )
 
 type IngredientHandler struct {
-	Service service.IngredientService
+	Service       service.IngredientService
+	Substitutions service.SubstitutionService
+}
+
+func (handler IngredientHandler) Resource() Resource {
+	return Resource{Name: "ingredient", Get: service.Ingredient{}, Create: service.Ingredient{}, Dependents: true, ListParameters: dietParameters}
 }
 
 func (handler IngredientHandler) Get(w http.ResponseWriter, r *http.Request) {
+	filter, ok := parseDietFilter(w, r)
+	if !ok {
+		return
+	}
 	w.Header().Set("Content-Type", "application/json")
 	ings, err := handler.Service.GetAll()
 	if err != nil {
-		handleError(w, err)
+		handleError(w, r, err)
 		return
 	}
+	if !filter.IsEmpty() {
+		matching := make([]service.Ingredient, 0, len(ings))
+		for _, ing := range ings {
+			if filter.Matches(ing.Allergens, ing.Diets) {
+				matching = append(matching, ing)
+			}
+		}
+		ings = matching
+	}
 	json.NewEncoder(w).Encode(ings)
 }
 
 func (handler IngredientHandler) GetById(w http.ResponseWriter, r *http.Request) {
-	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
-	if err != nil {
-		handleError(w, err)
+	id, ok := parseId(w, r)
+	if !ok {
 		return
 	}
 	w.Header().Set("Content-Type", "application/json")
 	ing, err := handler.Service.Get(id)
 	if err != nil {
-		handleError(w, err)
+		handleError(w, r, err)
 		return
 	}
 	json.NewEncoder(w).Encode(ing)
 }
 
 func (handler IngredientHandler) Post(w http.ResponseWriter, r *http.Request) {
-	headerContentTtype := r.Header.Get("Content-Type")
-	if headerContentTtype != "application/json" {
-		errorResponse(w, "Content Type is not application/json", http.StatusUnsupportedMediaType)
-		return
-	}
 	var i service.Ingredient
-	var unmarshalErr *json.UnmarshalTypeError
-
-	decoder := json.NewDecoder(r.Body)
-	decoder.DisallowUnknownFields()
-	err := decoder.Decode(&i)
-	if err != nil {
-		if errors.As(err, &unmarshalErr) {
-			errorResponse(w, "Bad Request. Wrong Type provided for field "+unmarshalErr.Field, http.StatusBadRequest)
-		} else {
-			errorResponse(w, "Bad Request "+err.Error(), http.StatusBadRequest)
-		}
+	if !decodeBody(w, r, &i) {
 		return
 	}
-	err = handler.Service.Create(i)
+	_, err := handler.Service.Create(i)
 	if err != nil {
-		handleError(w, err)
+		handleError(w, r, err)
 		return
 	}
-	errorResponse(w, "Success", http.StatusOK)
+	messageResponse(w, "Success", http.StatusOK)
 }
 
 func (handler IngredientHandler) Put(w http.ResponseWriter, r *http.Request) {
-	headerContentTtype := r.Header.Get("Content-Type")
-	if headerContentTtype != "application/json" {
-		errorResponse(w, "Content Type is not application/json", http.StatusUnsupportedMediaType)
-		return
-	}
 	var i service.Ingredient
-	var unmarshalErr *json.UnmarshalTypeError
-
-	decoder := json.NewDecoder(r.Body)
-	decoder.DisallowUnknownFields()
-	err := decoder.Decode(&i)
-	if err != nil {
-		if errors.As(err, &unmarshalErr) {
-			errorResponse(w, "Bad Request. Wrong Type provided for field "+unmarshalErr.Field, http.StatusBadRequest)
-		} else {
-			errorResponse(w, "Bad Request "+err.Error(), http.StatusBadRequest)
-		}
+	if !decodeBody(w, r, &i) {
 		return
 	}
-	err = handler.Service.Update(i)
+	err := handler.Service.Update(i)
 	if err != nil {
-		handleError(w, err)
+		handleError(w, r, err)
 		return
 	}
-	errorResponse(w, "Success", http.StatusOK)
+	messageResponse(w, "Success", http.StatusOK)
 }
 
 func (handler IngredientHandler) Delete(w http.ResponseWriter, r *http.Request) {
-	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
-	if err != nil {
-		handleError(w, err)
+	id, ok := parseId(w, r)
+	if !ok {
 		return
 	}
-	err = handler.Service.Delete(id)
+	opts, ok := parseDeleteOptions(w, r)
+	if !ok {
+		return
+	}
+	err := handler.Service.Delete(id, opts)
 	if err != nil {
-		handleError(w, err)
+		handleError(w, r, err)
 		return
 	}
 }
 
-func errorResponse(w http.ResponseWriter, message string, httpStatusCode int) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(httpStatusCode)
-	resp := make(map[string]string)
-	resp["message"] = message
-	jsonResp, _ := json.Marshal(resp)
-	w.Write(jsonResp)
+func (handler IngredientHandler) Routes(router SubRouter) {
+	router.Handle(http.MethodGet, "/{id}/substitutes", handler.Substitutes, Operation{
+		Summary:    "Suggest substitutes for an ingredient, the closest in nutritional value first",
+		Response:   []service.SubstituteSuggestion{},
+		Parameters: dietParameters,
+	})
 }
 
-func handleError(w http.ResponseWriter, err error) {
-	switch x := err.(type) {
-	case *service.NotFound:
-		errorResponse(w, x.Error(), http.StatusNotFound)
-	case *service.ValidationError:
-		errorResponse(w, x.Error(), http.StatusBadRequest)
-	default:
-		errorResponse(w, "Internal server error, if the error persists contact server admin", http.StatusInternalServerError)
+func (handler IngredientHandler) Substitutes(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseId(w, r)
+	if !ok {
+		return
+	}
+	filter, ok := parseDietFilter(w, r)
+	if !ok {
+		return
 	}
+	suggestions, err := handler.Substitutions.Suggest(id, filter)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(suggestions)
 }
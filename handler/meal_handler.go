@@ -2,108 +2,129 @@ package handler
 
 import (
 	"encoding/json"
-	"errors"
 	"net/http"
-	"strconv"
 
 	"github.com/cookbook/service"
-	"github.com/gorilla/mux"
 )
 
 type MealHandler struct {
 	Service service.MealService
 }
 
+func (handler MealHandler) Resource() Resource {
+	return Resource{Name: "meal", Get: service.MealGet{}, Create: service.MealCreate{}, Dependents: true, ListParameters: dietParameters}
+}
+
 func (handler MealHandler) Get(w http.ResponseWriter, r *http.Request) {
+	filter, ok := parseDietFilter(w, r)
+	if !ok {
+		return
+	}
 	w.Header().Set("Content-Type", "application/json")
 	meals, err := handler.Service.GetAll()
 	if err != nil {
-		handleError(w, err)
+		handleError(w, r, err)
 		return
 	}
+	if !filter.IsEmpty() {
+		matching := make([]service.MealGet, 0, len(meals))
+		for _, meal := range meals {
+			if filter.Matches(meal.Allergens, meal.Diets) {
+				matching = append(matching, meal)
+			}
+		}
+		meals = matching
+	}
 	json.NewEncoder(w).Encode(meals)
 }
 
 func (handler MealHandler) GetById(w http.ResponseWriter, r *http.Request) {
-	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
-	if err != nil {
-		handleError(w, err)
+	id, ok := parseId(w, r)
+	if !ok {
 		return
 	}
 	w.Header().Set("Content-Type", "application/json")
 	meal, err := handler.Service.Get(id)
 	if err != nil {
-		handleError(w, err)
+		handleError(w, r, err)
 		return
 	}
 	json.NewEncoder(w).Encode(meal)
 }
 
 func (handler MealHandler) Post(w http.ResponseWriter, r *http.Request) {
-	headerContentTtype := r.Header.Get("Content-Type")
-	if headerContentTtype != "application/json" {
-		errorResponse(w, "Content Type is not application/json", http.StatusUnsupportedMediaType)
-		return
-	}
 	var meal service.MealCreate
-	var unmarshalErr *json.UnmarshalTypeError
-
-	decoder := json.NewDecoder(r.Body)
-	decoder.DisallowUnknownFields()
-	err := decoder.Decode(&meal)
-	if err != nil {
-		if errors.As(err, &unmarshalErr) {
-			errorResponse(w, "Bad Request. Wrong Type provided for field "+unmarshalErr.Field, http.StatusBadRequest)
-		} else {
-			errorResponse(w, "Bad Request "+err.Error(), http.StatusBadRequest)
-		}
+	if !decodeBody(w, r, &meal) {
 		return
 	}
-	err = handler.Service.Create(meal)
+	_, err := handler.Service.Create(meal)
 	if err != nil {
-		handleError(w, err)
+		handleError(w, r, err)
 		return
 	}
-	errorResponse(w, "Success", http.StatusOK)
+	messageResponse(w, "Success", http.StatusOK)
 }
 
 func (handler MealHandler) Put(w http.ResponseWriter, r *http.Request) {
-	headerContentTtype := r.Header.Get("Content-Type")
-	if headerContentTtype != "application/json" {
-		errorResponse(w, "Content Type is not application/json", http.StatusUnsupportedMediaType)
+	var meal service.MealCreate
+	if !decodeBody(w, r, &meal) {
 		return
 	}
-	var meal service.MealCreate
-	var unmarshalErr *json.UnmarshalTypeError
-
-	decoder := json.NewDecoder(r.Body)
-	decoder.DisallowUnknownFields()
-	err := decoder.Decode(&meal)
+	err := handler.Service.Update(meal)
 	if err != nil {
-		if errors.As(err, &unmarshalErr) {
-			errorResponse(w, "Bad Request. Wrong Type provided for field "+unmarshalErr.Field, http.StatusBadRequest)
-		} else {
-			errorResponse(w, "Bad Request "+err.Error(), http.StatusBadRequest)
-		}
+		handleError(w, r, err)
 		return
 	}
-	err = handler.Service.Update(meal)
+	messageResponse(w, "Success", http.StatusOK)
+}
+
+func (handler MealHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseId(w, r)
+	if !ok {
+		return
+	}
+	opts, ok := parseDeleteOptions(w, r)
+	if !ok {
+		return
+	}
+	err := handler.Service.Delete(id, opts)
 	if err != nil {
-		handleError(w, err)
+		handleError(w, r, err)
 		return
 	}
-	errorResponse(w, "Success", http.StatusOK)
 }
 
-func (handler MealHandler) Delete(w http.ResponseWriter, r *http.Request) {
-	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+func (handler MealHandler) Routes(router SubRouter) {
+	router.Handle(http.MethodPost, "/{id}/clone", handler.Clone, Operation{Summary: "Copy a meal with its recipes", Request: service.Clone{}, Response: service.MealGet{}})
+	nutritionLabelRoutes(router, "meal", func(id int64, query labelQuery) (service.NutritionLabel, error) {
+		meal, err := handler.Service.Get(id)
+		if err != nil {
+			return service.NutritionLabel{}, err
+		}
+		return service.MealNutritionLabel(meal, query.style, query.servings, query.intakes), nil
+	})
+}
+
+// Clone responds with the copy of the meal.
+func (handler MealHandler) Clone(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseId(w, r)
+	if !ok {
+		return
+	}
+	var clone service.Clone
+	if !decodeBody(w, r, &clone) {
+		return
+	}
+	cloneId, err := handler.Service.Clone(id, clone.Name)
 	if err != nil {
-		handleError(w, err)
+		handleError(w, r, err)
 		return
 	}
-	err = handler.Service.Delete(id)
+	meal, err := handler.Service.Get(cloneId)
 	if err != nil {
-		handleError(w, err)
+		handleError(w, r, err)
 		return
 	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(meal)
 }
@@ -2,9 +2,10 @@ package handler
 
 import (
 	"encoding/json"
-	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/cookbook/service"
 	"github.com/gorilla/mux"
@@ -14,96 +15,382 @@ type MealPlanHandler struct {
 	Service service.MealPlanService
 }
 
+var prepScheduleParameters = []Parameter{
+	{Name: "serve_at", Format: "date-time", Required: true, Description: "When the dishes are to be ready, RFC 3339 or YYYY-MM-DDTHH:MM in server time"},
+	{Name: "from", Format: "date", Description: "First day of the entries to prep for, YYYY-MM-DD"},
+	{Name: "to", Format: "date", Description: "Last day of the entries to prep for, YYYY-MM-DD"},
+	{Name: "cooks", Type: "integer", Description: "Number of people cooking, 1 by default"},
+}
+
+func (handler MealPlanHandler) Resource() Resource {
+	return Resource{Name: "meal plan", Get: service.MealPlanGet{}, Create: service.MealPlanCreate{}, ListParameters: dietParameters}
+}
+
 func (handler MealPlanHandler) Get(w http.ResponseWriter, r *http.Request) {
+	filter, ok := parseDietFilter(w, r)
+	if !ok {
+		return
+	}
 	w.Header().Set("Content-Type", "application/json")
 	mealPlans, err := handler.Service.GetAll()
 	if err != nil {
-		handleError(w, err)
+		handleError(w, r, err)
 		return
 	}
+	if !filter.IsEmpty() {
+		matching := make([]service.MealPlanGet, 0, len(mealPlans))
+		for _, mealPlan := range mealPlans {
+			if filter.Matches(mealPlan.Allergens, mealPlan.Diets) {
+				matching = append(matching, mealPlan)
+			}
+		}
+		mealPlans = matching
+	}
 	json.NewEncoder(w).Encode(mealPlans)
 }
 
 func (handler MealPlanHandler) GetById(w http.ResponseWriter, r *http.Request) {
-	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
-	if err != nil {
-		handleError(w, err)
+	id, ok := parseId(w, r)
+	if !ok {
 		return
 	}
 	w.Header().Set("Content-Type", "application/json")
 	mealPlan, err := handler.Service.Get(id)
 	if err != nil {
-		handleError(w, err)
+		handleError(w, r, err)
 		return
 	}
 	json.NewEncoder(w).Encode(mealPlan)
 }
 
 func (handler MealPlanHandler) Post(w http.ResponseWriter, r *http.Request) {
-	headerContentTtype := r.Header.Get("Content-Type")
-	if headerContentTtype != "application/json" {
-		errorResponse(w, "Content Type is not application/json", http.StatusUnsupportedMediaType)
+	var mealPlan service.MealPlanCreate
+	if !decodeBody(w, r, &mealPlan) {
 		return
 	}
+	_, err := handler.Service.Create(mealPlan)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+	messageResponse(w, "Success", http.StatusOK)
+}
+
+func (handler MealPlanHandler) Put(w http.ResponseWriter, r *http.Request) {
 	var mealPlan service.MealPlanCreate
-	var unmarshalErr *json.UnmarshalTypeError
+	if !decodeBody(w, r, &mealPlan) {
+		return
+	}
+	err := handler.Service.Update(mealPlan)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+	messageResponse(w, "Success", http.StatusOK)
+}
 
-	decoder := json.NewDecoder(r.Body)
-	decoder.DisallowUnknownFields()
-	err := decoder.Decode(&mealPlan)
+func (handler MealPlanHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseId(w, r)
+	if !ok {
+		return
+	}
+	err := handler.Service.Delete(id)
 	if err != nil {
-		if errors.As(err, &unmarshalErr) {
-			errorResponse(w, "Bad Request. Wrong Type provided for field "+unmarshalErr.Field, http.StatusBadRequest)
-		} else {
-			errorResponse(w, "Bad Request "+err.Error(), http.StatusBadRequest)
-		}
+		handleError(w, r, err)
 		return
 	}
-	err = handler.Service.Create(mealPlan)
+}
+
+// FeedSubscription is the address calendar apps subscribe to for a meal plan.
+type FeedSubscription struct {
+	Url string `json:"url"`
+}
+
+func (handler MealPlanHandler) Routes(router SubRouter) {
+	router.Handle(http.MethodPost, "/generate", handler.Generate, Operation{Summary: "Generate meal plan meeting nutrition targets", Request: service.MealPlanGenerate{}, Response: service.GeneratedMealPlan{}})
+	router.Handle(http.MethodPost, "/{id}/clone", handler.Clone, Operation{Summary: "Copy a meal plan to start on another date", Request: service.MealPlanClone{}, Response: service.MealPlanGet{}})
+	router.Handle(http.MethodPost, "/{id}/repeat", handler.Repeat, Operation{Summary: "Append copies of the days of a meal plan", Request: service.MealPlanRepeat{}, Response: service.MealPlanGet{}})
+	router.Handle(http.MethodPost, "/{id}/shift", handler.Shift, Operation{Summary: "Move every day of a meal plan by a number of days", Request: service.MealPlanShift{}, Response: service.MealPlanGet{}})
+	router.Handle(http.MethodGet, "/{id}/shopping-list", handler.ShoppingList, Operation{Summary: "List ingredients to buy for the meal plan", Response: []service.ShoppingListItem{}})
+	router.Handle(http.MethodGet, "/{id}/intake", handler.Intake, Operation{Summary: "Report the daily intake of the members assigned to the meal plan against their targets", Response: []service.MemberIntake{}})
+	router.Handle(http.MethodGet, "/{id}/prep-schedule", handler.PrepSchedule, Operation{Summary: "Plan prepping the meal plan recipes at once, counting back from the serving time", Response: service.PrepSchedule{}, Parameters: prepScheduleParameters})
+	router.Handle(http.MethodPost, "/{id}/entries/{index}/cooked", handler.Cook, Operation{
+		Summary:    "Mark meal plan entry as cooked, taking its ingredients out of the pantry",
+		Response:   service.CookedEntry{},
+		Parameters: []Parameter{{Name: "index", Type: "integer", Format: "int32", Description: "Position of the entry in the meal plan, counting from 0"}},
+		Errors:     []int{http.StatusConflict},
+	})
+	router.Handle(http.MethodGet, "/{id}/calendar.ics", handler.Export, Operation{Summary: "Export meal plan as iCalendar", ContentType: "text/calendar"})
+	router.Handle(http.MethodGet, "/{id}/week.pdf", handler.WeekPdf, Operation{
+		Summary:     "Print a week of the meal plan with its shopping list as PDF",
+		ContentType: pdfContentType,
+		Parameters:  []Parameter{{Name: "from", Format: "date", Description: "First day of the week, YYYY-MM-DD, the start of the meal plan by default"}},
+	})
+	router.Handle(http.MethodPost, "/{id}/feed", handler.Subscribe, Operation{Summary: "Create meal plan calendar feed, replacing the previous one", Response: FeedSubscription{}})
+	router.Handle(http.MethodDelete, "/{id}/feed", handler.Unsubscribe, Operation{Summary: "Disable meal plan calendar feed"})
+}
+
+// Generate responds with a generated meal plan without saving it.
+func (handler MealPlanHandler) Generate(w http.ResponseWriter, r *http.Request) {
+	var req service.MealPlanGenerate
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	generated, err := handler.Service.Generate(req)
 	if err != nil {
-		handleError(w, err)
+		handleError(w, r, err)
 		return
 	}
-	errorResponse(w, "Success", http.StatusOK)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(generated)
 }
 
-func (handler MealPlanHandler) Put(w http.ResponseWriter, r *http.Request) {
-	headerContentTtype := r.Header.Get("Content-Type")
-	if headerContentTtype != "application/json" {
-		errorResponse(w, "Content Type is not application/json", http.StatusUnsupportedMediaType)
+// Clone responds with the copy of the meal plan.
+func (handler MealPlanHandler) Clone(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseId(w, r)
+	if !ok {
 		return
 	}
-	var mealPlan service.MealPlanCreate
-	var unmarshalErr *json.UnmarshalTypeError
+	var clone service.MealPlanClone
+	if !decodeBody(w, r, &clone) {
+		return
+	}
+	cloneId, err := handler.Service.Clone(id, clone)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+	handler.writeMealPlan(w, r, cloneId)
+}
+
+// Repeat responds with the meal plan including the repeated days.
+func (handler MealPlanHandler) Repeat(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseId(w, r)
+	if !ok {
+		return
+	}
+	var repeat service.MealPlanRepeat
+	if !decodeBody(w, r, &repeat) {
+		return
+	}
+	err := handler.Service.Repeat(id, repeat)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+	handler.writeMealPlan(w, r, id)
+}
+
+// Shift responds with the moved meal plan.
+func (handler MealPlanHandler) Shift(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseId(w, r)
+	if !ok {
+		return
+	}
+	var shift service.MealPlanShift
+	if !decodeBody(w, r, &shift) {
+		return
+	}
+	err := handler.Service.Shift(id, shift)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+	handler.writeMealPlan(w, r, id)
+}
+
+func (handler MealPlanHandler) writeMealPlan(w http.ResponseWriter, r *http.Request, id int64) {
+	mealPlan, err := handler.Service.Get(id)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(mealPlan)
+}
 
-	decoder := json.NewDecoder(r.Body)
-	decoder.DisallowUnknownFields()
-	err := decoder.Decode(&mealPlan)
+func (handler MealPlanHandler) ShoppingList(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseId(w, r)
+	if !ok {
+		return
+	}
+	list, err := handler.Service.GetShoppingList(id)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(list)
+}
+
+func (handler MealPlanHandler) Intake(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseId(w, r)
+	if !ok {
+		return
+	}
+	intake, err := handler.Service.GetIntake(id)
 	if err != nil {
-		if errors.As(err, &unmarshalErr) {
-			errorResponse(w, "Bad Request. Wrong Type provided for field "+unmarshalErr.Field, http.StatusBadRequest)
-		} else {
-			errorResponse(w, "Bad Request "+err.Error(), http.StatusBadRequest)
+		handleError(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(intake)
+}
+
+// PrepSchedule plans prepping the recipes of the entries not cooked yet, by
+// default of the whole meal plan.
+func (handler MealPlanHandler) PrepSchedule(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseId(w, r)
+	if !ok {
+		return
+	}
+	var query service.PrepQuery
+	var errors []service.FieldError
+	values := r.URL.Query()
+	if value := values.Get("serve_at"); value != "" {
+		var err error
+		query.ServeAt, err = time.Parse(time.RFC3339, value)
+		if err != nil {
+			query.ServeAt, err = time.ParseInLocation("2006-01-02T15:04", value, time.Local)
+		}
+		if err != nil {
+			errors = append(errors, service.FieldError{Field: "serve_at", Message: "must be a time formatted as RFC 3339 or YYYY-MM-DDTHH:MM"})
 		}
+	}
+	for _, param := range []struct {
+		name string
+		date *service.Date
+	}{{"from", &query.From}, {"to", &query.To}} {
+		if value := values.Get(param.name); value != "" {
+			var err error
+			*param.date, err = service.ParseDate(value)
+			if err != nil {
+				errors = append(errors, service.FieldError{Field: param.name, Message: "must be a date formatted as YYYY-MM-DD"})
+			}
+		}
+	}
+	if value := values.Get("cooks"); value != "" {
+		var err error
+		query.Cooks, err = strconv.Atoi(value)
+		if err != nil || query.Cooks < 1 {
+			errors = append(errors, service.FieldError{Field: "cooks", Message: "must be a positive integer"})
+		}
+	}
+	if len(errors) > 0 {
+		invalidParameters(w, r, errors)
 		return
 	}
-	err = handler.Service.Update(mealPlan)
+	schedule, err := handler.Service.GetPrepSchedule(id, query)
 	if err != nil {
-		handleError(w, err)
+		handleError(w, r, err)
 		return
 	}
-	errorResponse(w, "Success", http.StatusOK)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(schedule)
 }
 
-func (handler MealPlanHandler) Delete(w http.ResponseWriter, r *http.Request) {
-	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+// Cook marks the entry at the given position of the meal plan entries as
+// cooked.
+func (handler MealPlanHandler) Cook(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseId(w, r)
+	if !ok {
+		return
+	}
+	index, ok := parsePathInt(w, r, "index")
+	if !ok {
+		return
+	}
+	cooked, err := handler.Service.Cook(id, int(index))
 	if err != nil {
-		handleError(w, err)
+		handleError(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(cooked)
+}
+
+func (handler MealPlanHandler) Export(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseId(w, r)
+	if !ok {
 		return
 	}
-	err = handler.Service.Delete(id)
+	mealPlan, err := handler.Service.Get(id)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", icalContentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="meal-plan-%d.ics"`, id))
+	writeMealPlanCalendar(w, mealPlan, baseUrl(r))
+}
+
+// Feed serves the meal plan subscribed to with the token in the path. It
+// needs no other authorization, the token is the secret.
+func (handler MealPlanHandler) Feed(w http.ResponseWriter, r *http.Request) {
+	mealPlan, err := handler.Service.GetByFeedToken(mux.Vars(r)["token"])
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", icalContentType)
+	writeMealPlanCalendar(w, mealPlan, baseUrl(r))
+}
+
+func (handler MealPlanHandler) Subscribe(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseId(w, r)
+	if !ok {
+		return
+	}
+	token, err := handler.Service.CreateFeedToken(id)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(FeedSubscription{Url: baseUrl(r) + "/feeds/" + token + ".ics"})
+}
+
+func (handler MealPlanHandler) Unsubscribe(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseId(w, r)
+	if !ok {
+		return
+	}
+	err := handler.Service.DeleteFeedToken(id)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+	messageResponse(w, "Success", http.StatusOK)
+}
+
+func (handler MealPlanHandler) WeekPdf(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseId(w, r)
+	if !ok {
+		return
+	}
+	var from service.Date
+	if value := r.URL.Query().Get("from"); value != "" {
+		var err error
+		from, err = service.ParseDate(value)
+		if err != nil {
+			invalidParameters(w, r, []service.FieldError{{Field: "from", Message: "must be a date formatted as YYYY-MM-DD"}})
+			return
+		}
+	}
+	mealPlan, err := handler.Service.Get(id)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+	if from.IsZero() {
+		from = service.NewDate(mealPlan.DateStarted)
+	}
+	list, err := handler.Service.GetShoppingListBetween(id, from, from.AddDays(6))
 	if err != nil {
-		handleError(w, err)
+		handleError(w, r, err)
 		return
 	}
+	w.Header().Set("Content-Type", pdfContentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`inline; filename="meal-plan-%d-%s.pdf"`, id, from))
+	writeMealPlanPdf(w, mealPlan, from, list)
 }
@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/cookbook/service"
+)
+
+type MemberHandler struct {
+	Service service.MemberService
+}
+
+func (handler MemberHandler) Resource() Resource {
+	return Resource{Name: "member", Get: service.MemberGet{}, Create: service.MemberCreate{}}
+}
+
+func (handler MemberHandler) Get(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	members, err := handler.Service.GetAll()
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+	json.NewEncoder(w).Encode(members)
+}
+
+func (handler MemberHandler) GetById(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseId(w, r)
+	if !ok {
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	member, err := handler.Service.Get(id)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+	json.NewEncoder(w).Encode(member)
+}
+
+func (handler MemberHandler) Post(w http.ResponseWriter, r *http.Request) {
+	var member service.MemberCreate
+	if !decodeBody(w, r, &member) {
+		return
+	}
+	_, err := handler.Service.Create(member)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+	messageResponse(w, "Success", http.StatusOK)
+}
+
+func (handler MemberHandler) Put(w http.ResponseWriter, r *http.Request) {
+	var member service.MemberCreate
+	if !decodeBody(w, r, &member) {
+		return
+	}
+	err := handler.Service.Update(member)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+	messageResponse(w, "Success", http.StatusOK)
+}
+
+func (handler MemberHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseId(w, r)
+	if !ok {
+		return
+	}
+	err := handler.Service.Delete(id)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+}
@@ -0,0 +1,236 @@
+package handler
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"html"
+	"html/template"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/cookbook/pdf"
+	"github.com/cookbook/service"
+)
+
+//go:embed nutrition_label.html
+var nutritionLabelHtml string
+
+var nutritionLabelTemplate = template.Must(template.New("nutrition label").Funcs(template.FuncMap{
+	"kilojoules": service.Kilojoules,
+}).Parse(nutritionLabelHtml))
+
+var nutritionLabelParameters = []Parameter{
+	{Name: "style", Type: "string", Description: "Label style, " + strings.Join(service.LabelStyles, " or ") + ", us by default"},
+	{Name: "servings", Type: "number", Description: "Number of servings to split into, the recipe's servings or a single serving for meals by default"},
+	{Name: "reference_calories", Type: "number", Description: "Daily calorie reference, 2000 by default"},
+	{Name: "reference_fat", Type: "number", Description: "Daily fat reference in grams, 78 for US and 70 for EU labels by default"},
+	{Name: "reference_carbs", Type: "number", Description: "Daily carbohydrate reference in grams, 275 for US and 260 for EU labels by default"},
+	{Name: "reference_protein", Type: "number", Description: "Daily protein reference in grams, 50 by default"},
+}
+
+// labelQuery holds the parsed nutrition label query parameters.
+type labelQuery struct {
+	style    string
+	servings float32
+	intakes  service.ReferenceIntakes
+}
+
+func parseLabelQuery(w http.ResponseWriter, r *http.Request) (query labelQuery, ok bool) {
+	var fields []service.FieldError
+	values := r.URL.Query()
+	query.style = service.LabelStyleUS
+	if style := values.Get("style"); style != "" {
+		query.style = strings.ToLower(style)
+		if query.style != service.LabelStyleUS && query.style != service.LabelStyleEU {
+			fields = append(fields, service.FieldError{Field: "style", Message: "must be one of " + strings.Join(service.LabelStyles, ", ")})
+		}
+	}
+	query.intakes = service.DefaultReferenceIntakes(query.style)
+	numbers := []struct {
+		name  string
+		value *float32
+	}{
+		{"servings", &query.servings},
+		{"reference_calories", &query.intakes.Calories},
+		{"reference_fat", &query.intakes.Fat},
+		{"reference_carbs", &query.intakes.Carbs},
+		{"reference_protein", &query.intakes.Protein},
+	}
+	for _, number := range numbers {
+		value := values.Get(number.name)
+		if value == "" {
+			continue
+		}
+		parsed, err := strconv.ParseFloat(value, 32)
+		// ParseFloat accepts "NaN" and "Inf", neither is a usable amount.
+		if err != nil || math.IsNaN(parsed) || math.IsInf(parsed, 0) || parsed <= 0 {
+			fields = append(fields, service.FieldError{Field: number.name, Message: "must be a positive number"})
+			continue
+		}
+		*number.value = float32(parsed)
+	}
+	if len(fields) > 0 {
+		invalidParameters(w, r, fields)
+		return query, false
+	}
+	return query, true
+}
+
+// nutritionLabelRoutes registers the JSON, HTML and SVG routes of the label of
+// a resource, label returns it for the id path parameter.
+func nutritionLabelRoutes(router SubRouter, resource string, label func(id int64, query labelQuery) (service.NutritionLabel, error)) {
+	formats := []struct {
+		suffix      string
+		contentType string
+		write       func(io.Writer, service.NutritionLabel) error
+	}{
+		{"", "", func(w io.Writer, l service.NutritionLabel) error { return json.NewEncoder(w).Encode(l) }},
+		{".html", "text/html", func(w io.Writer, l service.NutritionLabel) error { return nutritionLabelTemplate.Execute(w, l) }},
+		{".svg", "image/svg+xml", writeNutritionLabelSvg},
+	}
+	for _, format := range formats {
+		format := format
+		serve := func(w http.ResponseWriter, r *http.Request) {
+			id, ok := parseId(w, r)
+			if !ok {
+				return
+			}
+			query, ok := parseLabelQuery(w, r)
+			if !ok {
+				return
+			}
+			nutritionLabel, err := label(id, query)
+			if err != nil {
+				handleError(w, r, err)
+				return
+			}
+			contentType := format.contentType
+			if contentType == "" {
+				contentType = "application/json"
+			}
+			w.Header().Set("Content-Type", contentType)
+			format.write(w, nutritionLabel)
+		}
+		op := Operation{Summary: "Nutrition label of a " + resource, ContentType: format.contentType, Parameters: nutritionLabelParameters}
+		if format.contentType == "" {
+			op.Response = service.NutritionLabel{}
+		} else {
+			op.Summary += " as " + strings.ToUpper(format.suffix[1:])
+		}
+		router.Handle(http.MethodGet, "/{id}/nutrition-label"+format.suffix, serve, op)
+	}
+}
+
+const (
+	svgLabelWidth = 280
+	svgPadding    = 8
+)
+
+// svgWriter draws a label top to bottom, y is the baseline of the last line.
+type svgWriter struct {
+	b strings.Builder
+	y float64
+}
+
+func (s *svgWriter) text(x float64, size float64, bold bool, anchor, text string) {
+	weight := "normal"
+	if bold {
+		weight = "bold"
+	}
+	fmt.Fprintf(&s.b, `<text x="%g" y="%g" font-size="%g" font-weight="%s" text-anchor="%s">%s</text>`+"\n", x, s.y, size, weight, anchor, html.EscapeString(text))
+}
+
+// line advances by the line height of the size and writes text at the left,
+// and right aligned if given.
+func (s *svgWriter) line(size float64, bold bool, left, right string) {
+	s.y += size * 1.25
+	if left != "" {
+		s.text(svgPadding, size, bold, "start", left)
+	}
+	if right != "" {
+		s.text(svgLabelWidth-svgPadding, size, bold, "end", right)
+	}
+}
+
+// bar draws a horizontal rule of the thickness below the last line.
+func (s *svgWriter) bar(thickness float64) {
+	s.y += 3
+	fmt.Fprintf(&s.b, `<rect x="%d" y="%g" width="%d" height="%g"/>`+"\n", svgPadding, s.y, svgLabelWidth-2*svgPadding, thickness)
+	s.y += thickness
+}
+
+// paragraph wraps the text to the width of the label.
+func (s *svgWriter) paragraph(size float64, text string) {
+	for _, line := range pdf.Wrap(pdf.Helvetica, size, text, svgLabelWidth-2*svgPadding) {
+		s.line(size, false, line, "")
+	}
+}
+
+// writeNutritionLabelSvg draws the label in its style, the text is set in
+// Helvetica so the wrapping matches the font metrics.
+func writeNutritionLabelSvg(w io.Writer, label service.NutritionLabel) error {
+	s := &svgWriter{}
+	servings := strconv.FormatFloat(float64(label.Servings), 'f', -1, 32)
+	if label.Style == service.LabelStyleEU {
+		s.line(16, true, "Nutrition declaration", "")
+		s.bar(2)
+		perServing := "Per serving"
+		if label.ServingSize > 0 {
+			perServing = fmt.Sprintf("Per serving (%g g)", label.ServingSize)
+		}
+		columns := []float64{120, 205, svgLabelWidth - svgPadding}
+		s.y += 12
+		if label.ServingSize > 0 {
+			s.text(columns[0], 9, true, "end", "Per 100 g")
+		}
+		s.text(columns[1], 9, true, "end", perServing)
+		s.text(columns[2], 9, true, "end", "%RI*")
+		for _, row := range label.Rows {
+			s.bar(0.5)
+			s.line(9, true, row.Nutrient, "")
+			if label.ServingSize > 0 {
+				s.text(columns[0], 9, false, "end", row.Per100g)
+			}
+			s.text(columns[1], 9, false, "end", row.Declared)
+			if row.DailyValue != nil {
+				s.text(columns[2], 9, false, "end", fmt.Sprintf("%d%%", *row.DailyValue))
+			}
+		}
+		s.bar(2)
+		s.paragraph(8, fmt.Sprintf("*Reference intake of an average adult (%g kJ / %g kcal). %s servings.",
+			service.Kilojoules(label.Intakes.Calories), label.Intakes.Calories, servings))
+	} else {
+		s.line(26, true, "Nutrition Facts", "")
+		s.bar(0.5)
+		s.paragraph(11, servings+" servings per "+label.Name)
+		servingSize := fmt.Sprintf("%gg", label.ServingSize)
+		if label.ServingSize == 0 {
+			servingSize = "1/" + servings + " of total"
+		}
+		s.line(12, true, "Serving size", servingSize)
+		s.bar(8)
+		s.line(9, true, "Amount per serving", "")
+		s.line(20, true, "Calories", label.Rows[0].Declared)
+		s.bar(4)
+		s.line(9, true, "", "% Daily Value*")
+		for _, row := range label.Rows[1:] {
+			s.bar(0.5)
+			percent := ""
+			if row.DailyValue != nil {
+				percent = fmt.Sprintf("%d%%", *row.DailyValue)
+			}
+			s.line(10, true, row.Nutrient+" "+row.Declared, percent)
+		}
+		s.bar(4)
+		s.paragraph(8, fmt.Sprintf("* The %% Daily Value (DV) tells you how much a nutrient in a serving of food contributes to a daily diet. %g calories a day is used for general nutrition advice.", label.Intakes.Calories))
+	}
+	height := s.y + svgPadding
+	_, err := fmt.Fprintf(w, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%g" viewBox="0 0 %d %g" font-family="Helvetica, Arial, sans-serif">`+"\n"+
+		`<rect x="0.5" y="0.5" width="%d" height="%g" fill="#fff" stroke="#000"/>`+"\n%s</svg>\n",
+		svgLabelWidth, height, svgLabelWidth, height, svgLabelWidth-1, height-1, s.b.String())
+	return err
+}
@@ -0,0 +1,35 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestParseLabelQuery(t *testing.T) {
+	tests := []struct {
+		query string
+		ok    bool
+	}{
+		{"", true},
+		{"servings=2.5", true},
+		{"servings=0", false},
+		{"servings=-1", false},
+		{"servings=NaN", false},
+		{"servings=Inf", false},
+		{"servings=-Inf", false},
+		{"servings=1e40", false},
+		{"reference_calories=nan", false},
+		{"style=eu&reference_fat=+Inf", false},
+	}
+	for _, test := range tests {
+		w := httptest.NewRecorder()
+		_, ok := parseLabelQuery(w, httptest.NewRequest(http.MethodGet, "/recipes/1/nutrition-label?"+test.query, nil))
+		if ok != test.ok {
+			t.Errorf("%q: parsed %t, want %t", test.query, ok, test.ok)
+		}
+		if !ok && w.Code != http.StatusBadRequest {
+			t.Errorf("%q: responded with %d", test.query, w.Code)
+		}
+	}
+}
@@ -0,0 +1,408 @@
+package handler
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"net/http"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cookbook/service"
+	"github.com/gorilla/mux"
+)
+
+// DefaultDocsAssetsUrl serves the Swagger UI assets of the docs page from a
+// CDN, deployments without internet access serve swagger-ui-dist themselves.
+const DefaultDocsAssetsUrl = "https://unpkg.com/swagger-ui-dist@4"
+
+//go:embed docs.html
+var docsPage string
+
+var docsTemplate = template.Must(template.New("docs").Parse(docsPage))
+
+// Operation documents a single route. Request and Response hold zero values of
+// the models read from and written to the body, nil if there is none. Request
+// bodies are JSON unless RequestContentType says otherwise.
+type Operation struct {
+	Summary            string
+	Request            interface{}
+	RequestContentType string
+	Response           interface{}
+	ContentType        string
+	Parameters         []Parameter
+	// Errors lists additional error statuses the route may respond with.
+	Errors []int
+}
+
+// Parameter documents a query parameter. Path parameters are derived from the
+// route template, as an integer if named id and as a string otherwise, a
+// Parameter with the same name documents them instead.
+type Parameter struct {
+	Name        string
+	Description string
+	Type        string
+	Format      string
+	Required    bool
+}
+
+type Document struct {
+	OpenAPI    string                          `json:"openapi"`
+	Info       Info                            `json:"info"`
+	Paths      map[string]map[string]*PathItem `json:"paths"`
+	Components Components                      `json:"components"`
+}
+
+type Info struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+type PathItem struct {
+	Summary     string                    `json:"summary"`
+	OperationId string                    `json:"operationId"`
+	Parameters  []ParameterObject         `json:"parameters,omitempty"`
+	RequestBody *RequestBody              `json:"requestBody,omitempty"`
+	Responses   map[string]ResponseObject `json:"responses"`
+}
+
+type ParameterObject struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required"`
+	Schema      *Schema `json:"schema"`
+}
+
+type RequestBody struct {
+	Required bool                 `json:"required"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+type ResponseObject struct {
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+type Components struct {
+	Schemas map[string]*Schema `json:"schemas"`
+}
+
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	ExclusiveMinimum     bool               `json:"exclusiveMinimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	ExclusiveMaximum     bool               `json:"exclusiveMaximum,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+}
+
+var pathParamRegex = regexp.MustCompile(`{([^}:]+)}`)
+
+// OpenAPI builds the specification of every route registered on the router.
+// Routes registered without documentation are still listed so they show up
+// as drift, see CheckDocs.
+func (router RestRouter) OpenAPI() (Document, error) {
+	doc := Document{
+		OpenAPI:    "3.0.3",
+		Info:       Info{Title: "Cookbook REST API", Version: "1.0.0"},
+		Paths:      make(map[string]map[string]*PathItem),
+		Components: Components{Schemas: make(map[string]*Schema)},
+	}
+	err := router.Walk(func(route *mux.Route, _ *mux.Router, _ []*mux.Route) error {
+		path, err := route.GetPathTemplate()
+		if err != nil {
+			return nil
+		}
+		methods, err := route.GetMethods()
+		if err != nil {
+			return nil
+		}
+		for _, method := range methods {
+			op, ok := router.docs[method+" "+path]
+			if !ok {
+				op = Operation{Summary: "Undocumented"}
+			}
+			if _, ok := doc.Paths[path]; !ok {
+				doc.Paths[path] = make(map[string]*PathItem)
+			}
+			doc.Paths[path][strings.ToLower(method)] = doc.operation(method, path, op)
+		}
+		return nil
+	})
+	return doc, err
+}
+
+// CheckDocs reports routes registered without documentation and documentation
+// left behind for routes that no longer exist.
+func (router RestRouter) CheckDocs() error {
+	routes := make(map[string]bool)
+	var messages []string
+	router.Walk(func(route *mux.Route, _ *mux.Router, _ []*mux.Route) error {
+		path, err := route.GetPathTemplate()
+		if err != nil {
+			return nil
+		}
+		methods, err := route.GetMethods()
+		if err != nil {
+			return nil
+		}
+		for _, method := range methods {
+			routes[method+" "+path] = true
+			if _, ok := router.docs[method+" "+path]; !ok {
+				messages = append(messages, "undocumented route "+method+" "+path)
+			}
+		}
+		return nil
+	})
+	for key := range router.docs {
+		if !routes[key] {
+			messages = append(messages, "documented route "+key+" is not registered")
+		}
+	}
+	if len(messages) > 0 {
+		sort.Strings(messages)
+		return fmt.Errorf("%s", strings.Join(messages, "\n"))
+	}
+	return nil
+}
+
+// ServeDocs exposes the specification at /openapi.json and a docs UI at /docs
+// loading the Swagger UI assets from assetsUrl.
+func (router RestRouter) ServeDocs(assetsUrl string) {
+	router.Handle(http.MethodGet, "/openapi.json", func(w http.ResponseWriter, r *http.Request) {
+		doc, err := router.OpenAPI()
+		if err != nil {
+			handleError(w, r, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(doc)
+	}, Operation{Summary: "OpenAPI specification", ContentType: "application/json"})
+	router.Handle(http.MethodGet, "/docs", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		docsTemplate.Execute(w, assetsUrl)
+	}, Operation{Summary: "API documentation", ContentType: "text/html"})
+}
+
+func (doc *Document) operation(method, path string, op Operation) *PathItem {
+	item := &PathItem{
+		Summary:     op.Summary,
+		OperationId: operationId(method, path),
+		Responses:   make(map[string]ResponseObject),
+	}
+	pathParams := make(map[string]bool)
+	for _, match := range pathParamRegex.FindAllStringSubmatch(path, -1) {
+		param := ParameterObject{
+			Name:     match[1],
+			In:       "path",
+			Required: true,
+			Schema:   &Schema{Type: "string"},
+		}
+		if param.Name == "id" {
+			param.Schema = &Schema{Type: "integer", Format: "int64"}
+		}
+		for _, p := range op.Parameters {
+			if p.Name != param.Name {
+				continue
+			}
+			param.Description = p.Description
+			if p.Type != "" {
+				param.Schema = &Schema{Type: p.Type, Format: p.Format}
+			}
+		}
+		pathParams[param.Name] = true
+		item.Parameters = append(item.Parameters, param)
+	}
+	for _, p := range op.Parameters {
+		if pathParams[p.Name] {
+			continue
+		}
+		paramType := p.Type
+		if paramType == "" {
+			paramType = "string"
+		}
+		item.Parameters = append(item.Parameters, ParameterObject{
+			Name:        p.Name,
+			In:          "query",
+			Description: p.Description,
+			Required:    p.Required,
+			Schema:      &Schema{Type: paramType, Format: p.Format},
+		})
+	}
+	errorContent := map[string]MediaType{problemContentType: {Schema: doc.schema(reflect.TypeOf(Problem{}))}}
+	hasPathParams := len(pathParamRegex.FindAllString(path, -1)) > 0
+	if op.Request != nil {
+		contentType := op.RequestContentType
+		if contentType == "" {
+			contentType = "application/json"
+		}
+		item.RequestBody = &RequestBody{
+			Required: true,
+			Content:  map[string]MediaType{contentType: {Schema: doc.schema(reflect.TypeOf(op.Request))}},
+		}
+		item.Responses["415"] = ResponseObject{Description: "Unsupported Media Type", Content: errorContent}
+	}
+	if op.Request != nil || hasPathParams || len(op.Parameters) > len(pathParams) {
+		item.Responses["400"] = ResponseObject{Description: "Bad Request", Content: errorContent}
+	}
+	success := ResponseObject{Description: "OK"}
+	if op.Response != nil {
+		success.Content = map[string]MediaType{"application/json": {Schema: doc.schema(reflect.TypeOf(op.Response))}}
+	} else if op.ContentType != "" {
+		success.Content = map[string]MediaType{op.ContentType: {Schema: &Schema{Type: "string"}}}
+	}
+	item.Responses["200"] = success
+	if hasPathParams {
+		item.Responses["404"] = ResponseObject{Description: "Not Found", Content: errorContent}
+	}
+	for _, status := range op.Errors {
+		item.Responses[strconv.Itoa(status)] = ResponseObject{Description: http.StatusText(status), Content: errorContent}
+	}
+	item.Responses["500"] = ResponseObject{Description: "Internal Server Error", Content: errorContent}
+	return item
+}
+
+func (doc *Document) schema(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t {
+	case reflect.TypeOf(time.Time{}):
+		return &Schema{Type: "string", Format: "date-time"}
+	case reflect.TypeOf(service.Date{}):
+		return &Schema{Type: "string", Format: "date"}
+	case reflect.TypeOf(File{}):
+		return &Schema{Type: "string", Format: "binary"}
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: doc.schema(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: doc.schema(t.Elem())}
+	case reflect.Struct:
+		name := t.Name()
+		if name == "" {
+			return doc.structSchema(t)
+		}
+		if _, ok := doc.Components.Schemas[name]; !ok {
+			// reserve the name first so recursive types terminate
+			doc.Components.Schemas[name] = &Schema{}
+			*doc.Components.Schemas[name] = *doc.structSchema(t)
+		}
+		return &Schema{Ref: "#/components/schemas/" + name}
+	}
+	return &Schema{}
+}
+
+func (doc *Document) structSchema(t reflect.Type) *Schema {
+	s := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" && !field.Anonymous {
+			continue
+		}
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+		if field.Anonymous && name == "" {
+			embedded := doc.structSchema(field.Type)
+			for k, v := range embedded.Properties {
+				s.Properties[k] = v
+			}
+			s.Required = append(s.Required, embedded.Required...)
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fieldSchema := doc.schema(field.Type)
+		if applyValidation(fieldSchema, field.Tag.Get("validate")) {
+			s.Required = append(s.Required, name)
+		}
+		s.Properties[name] = fieldSchema
+	}
+	return s
+}
+
+// applyValidation translates the validate struct tag into schema constraints
+// and reports whether the field is required.
+func applyValidation(s *Schema, tag string) (required bool) {
+	if tag == "" {
+		return false
+	}
+	for _, rule := range strings.Split(tag, ",") {
+		switch {
+		case rule == "required":
+			required = true
+			if s.Type == "string" {
+				minLength := 1
+				s.MinLength = &minLength
+			}
+		case service.Vocabularies[rule] != nil:
+			if s.Type == "array" {
+				s.Items.Enum = service.Vocabularies[rule]
+			} else {
+				s.Enum = service.Vocabularies[rule]
+			}
+		case strings.HasPrefix(rule, "gt="), strings.HasPrefix(rule, "gte="):
+			var min float64
+			fmt.Sscanf(rule[strings.Index(rule, "=")+1:], "%g", &min)
+			s.Minimum = &min
+			s.ExclusiveMinimum = strings.HasPrefix(rule, "gt=")
+		case strings.HasPrefix(rule, "lt="), strings.HasPrefix(rule, "lte="):
+			var max float64
+			fmt.Sscanf(rule[strings.Index(rule, "=")+1:], "%g", &max)
+			s.Maximum = &max
+			s.ExclusiveMaximum = strings.HasPrefix(rule, "lt=")
+		}
+	}
+	return required
+}
+
+func operationId(method, path string) string {
+	var b strings.Builder
+	b.WriteString(strings.ToLower(method))
+	for _, part := range strings.FieldsFunc(path, func(r rune) bool { return r == '/' || r == '-' || r == '.' }) {
+		if strings.HasPrefix(part, "{") {
+			part = strings.Trim(part, "{}")
+			part = "By" + strings.ToUpper(part[:1]) + part[1:]
+		}
+		b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	return b.String()
+}
+
+func listOf(model interface{}) interface{} {
+	return reflect.MakeSlice(reflect.SliceOf(reflect.TypeOf(model)), 0, 0).Interface()
+}
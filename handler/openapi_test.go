@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite testdata/openapi.json with the generated specification")
+
+var goldenSpec = filepath.Join("testdata", "openapi.json")
+
+func testRouter() RestRouter {
+	return NewAPI(Services{}, DefaultDocsAssetsUrl)
+}
+
+// TestOpenAPI compares the specification with testdata/openapi.json so any
+// change to the routes or models shows up in review. Run go test ./handler
+// -update to accept the change.
+func TestOpenAPI(t *testing.T) {
+	router := testRouter()
+	if err := router.CheckDocs(); err != nil {
+		t.Fatal(err)
+	}
+	doc, err := router.OpenAPI()
+	if err != nil {
+		t.Fatal(err)
+	}
+	spec, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	spec = append(spec, '\n')
+	if *update {
+		if err := ioutil.WriteFile(goldenSpec, spec, 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	golden, err := ioutil.ReadFile(goldenSpec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(spec, golden) {
+		t.Errorf("specification differs from %s, run go test ./handler -update if the change is intended\n%s", goldenSpec, firstDifference(golden, spec))
+	}
+}
+
+func firstDifference(want, got []byte) string {
+	wantLines := strings.Split(string(want), "\n")
+	gotLines := strings.Split(string(got), "\n")
+	for i := 0; i < len(wantLines) && i < len(gotLines); i++ {
+		if wantLines[i] != gotLines[i] {
+			return fmt.Sprintf("line %d:\n- %s\n+ %s", i+1, wantLines[i], gotLines[i])
+		}
+	}
+	return fmt.Sprintf("%d lines instead of %d", len(gotLines), len(wantLines))
+}
+
+func TestOpenAPIPathParameters(t *testing.T) {
+	doc, err := testRouter().OpenAPI()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for path, items := range doc.Paths {
+		for method, item := range items {
+			for _, param := range item.Parameters {
+				if param.In == "path" && param.Name != "id" && param.Description == "" {
+					t.Errorf("path parameter %s of %s %s is not documented", param.Name, strings.ToUpper(method), path)
+				}
+			}
+		}
+	}
+}
+
+func TestDocsPage(t *testing.T) {
+	router := NewAPI(Services{}, "/assets/swagger-ui")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/docs", nil))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("docs page responded with %d", recorder.Code)
+	}
+	if body := recorder.Body.String(); !strings.Contains(body, `src="/assets/swagger-ui/swagger-ui-bundle.js"`) {
+		t.Errorf("docs page doesn't load the assets from the configured URL:\n%s", body)
+	}
+}
@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/cookbook/service"
+)
+
+type PantryHandler struct {
+	Service service.PantryService
+}
+
+func (handler PantryHandler) Resource() Resource {
+	return Resource{Name: "pantry item", Get: service.PantryItemGet{}, Create: service.PantryItemCreate{}}
+}
+
+func (handler PantryHandler) Get(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	items, err := handler.Service.GetAll()
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+	json.NewEncoder(w).Encode(items)
+}
+
+func (handler PantryHandler) GetById(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseId(w, r)
+	if !ok {
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	item, err := handler.Service.Get(id)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+	json.NewEncoder(w).Encode(item)
+}
+
+func (handler PantryHandler) Post(w http.ResponseWriter, r *http.Request) {
+	var item service.PantryItemCreate
+	if !decodeBody(w, r, &item) {
+		return
+	}
+	_, err := handler.Service.Create(item)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+	messageResponse(w, "Success", http.StatusOK)
+}
+
+func (handler PantryHandler) Put(w http.ResponseWriter, r *http.Request) {
+	var item service.PantryItemCreate
+	if !decodeBody(w, r, &item) {
+		return
+	}
+	err := handler.Service.Update(item)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+	messageResponse(w, "Success", http.StatusOK)
+}
+
+func (handler PantryHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseId(w, r)
+	if !ok {
+		return
+	}
+	err := handler.Service.Delete(id)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+}
@@ -0,0 +1,309 @@
+package handler
+
+import (
+	_ "embed"
+	"fmt"
+	"html/template"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/cookbook/pdf"
+	"github.com/cookbook/service"
+)
+
+const pdfContentType = "application/pdf"
+
+//go:embed recipe_card.html
+var recipeCardHtml string
+
+var recipeCardTemplate = template.Must(template.New("recipe card").Funcs(template.FuncMap{
+	"amount": formatQuantity,
+	"join":   strings.Join,
+}).Parse(recipeCardHtml))
+
+// nutritionRow is a line of the nutrition panel.
+type nutritionRow struct {
+	Name       string
+	Total      string
+	PerServing string
+}
+
+func nutritionRows(recipe service.RecipeGet) []nutritionRow {
+	servings := float32(recipe.Servings)
+	if servings == 0 {
+		servings = 1
+	}
+	values := []struct {
+		name  string
+		value float32
+		unit  string
+	}{
+		{"Calories", recipe.Calories, "kcal"},
+		{"Protein", recipe.Protein, "g"},
+		{"Carbs", recipe.Carbs, "g"},
+		{"Fat", recipe.Fat, "g"},
+	}
+	rows := make([]nutritionRow, len(values))
+	for index, v := range values {
+		rows[index] = nutritionRow{
+			Name:       v.name,
+			Total:      formatAmount(v.value) + " " + v.unit,
+			PerServing: formatAmount(v.value/servings) + " " + v.unit,
+		}
+	}
+	return rows
+}
+
+// writeRecipeCard writes the recipe as an HTML page meant to be printed.
+func writeRecipeCard(w io.Writer, recipe service.RecipeGet) error {
+	card := struct {
+		Recipe    service.RecipeGet
+		Photo     string
+		Steps     []string
+		Nutrition []nutritionRow
+	}{
+		Recipe:    recipe,
+		Steps:     service.SplitSteps(recipe.Steps),
+		Nutrition: nutritionRows(recipe),
+	}
+	for _, image := range recipe.Images {
+		if image.Step == 0 && len(image.Thumbnails) > 1 {
+			card.Photo = image.Thumbnails[1].Url
+			break
+		}
+	}
+	return recipeCardTemplate.Execute(w, card)
+}
+
+// writeRecipePdf writes the recipe card as a PDF.
+func writeRecipePdf(w io.Writer, recipe service.RecipeGet) error {
+	doc := pdf.New(recipe.Name)
+	l := newPdfLayout(doc, "")
+	l.recipe(recipe)
+	_, err := doc.WriteTo(w)
+	return err
+}
+
+// writeMealPlanPdf writes a page per day of the week starting at from, with
+// the meals planned for it and the nutrition of the day, followed by the
+// shopping list of the week.
+func writeMealPlanPdf(w io.Writer, mealPlan service.MealPlanGet, from service.Date, shoppingList []service.ShoppingListItem) error {
+	doc := pdf.New(mealPlan.Name)
+	l := newPdfLayout(doc, mealPlan.Name)
+	for day := 0; day < 7; day++ {
+		date := from.AddDays(day)
+		l.newPage()
+		l.text(pdf.HelveticaBold, 18, date.Format("Monday, 2 January 2006"), 0)
+		l.space(6)
+		var calories, protein, carbs, fat float32
+		planned := false
+		for _, entry := range mealPlan.Entries {
+			if !entry.Date.Equal(date.Time) {
+				continue
+			}
+			planned = true
+			title := strings.Title(entry.Slot) + ": " + entry.Meal.Name
+			var details []string
+			switch {
+			case entry.Leftover:
+				details = append(details, fmt.Sprintf("leftovers, %s servings", formatAmount(entry.Portions)))
+			case entry.Batch > 0:
+				details = append(details, fmt.Sprintf("batch, %s servings", formatAmount(entry.Portions)))
+			case entry.Servings != 1:
+				details = append(details, fmt.Sprintf("%s servings", formatAmount(entry.Servings)))
+			}
+			if entry.People > 1 {
+				details = append(details, fmt.Sprintf("%d people", entry.People))
+			}
+			if len(details) > 0 {
+				title += " (" + strings.Join(details, ", ") + ")"
+			}
+			l.text(pdf.HelveticaBold, 13, title, 0)
+			for _, recipe := range entry.Meal.Recipes {
+				line := recipe.Name
+				if recipe.PrepMinutes > 0 || recipe.CookMinutes > 0 {
+					line += fmt.Sprintf(" - prep %d min, cook %d min", recipe.PrepMinutes, recipe.CookMinutes)
+				}
+				l.text(pdf.Helvetica, 11, line, 12)
+				share := entry.EatenShare(recipe)
+				calories += recipe.Calories * share
+				protein += recipe.Protein * share
+				carbs += recipe.Carbs * share
+				fat += recipe.Fat * share
+			}
+			l.space(6)
+		}
+		if !planned {
+			l.text(pdf.Helvetica, 11, "Nothing planned", 0)
+			continue
+		}
+		l.rule()
+		l.text(pdf.Helvetica, 11, fmt.Sprintf("Day total: %s kcal, protein %s g, carbs %s g, fat %s g",
+			formatAmount(calories), formatAmount(protein), formatAmount(carbs), formatAmount(fat)), 0)
+	}
+	l.newPage()
+	l.text(pdf.HelveticaBold, 18, "Shopping list", 0)
+	l.space(6)
+	columns := []float64{0, 230, 330, 430}
+	l.row(pdf.HelveticaBold, 11, columns, "Ingredient", "To buy", "In pantry", "Cost")
+	l.rule()
+	var total float32
+	for _, item := range shoppingList {
+		if item.ToBuy <= 0 {
+			continue
+		}
+		cost := ""
+		if item.Cost != nil {
+			cost = formatPrice(*item.Cost)
+			total += *item.Cost
+		}
+		l.row(pdf.Helvetica, 11, columns, item.Name,
+			formatQuantity(service.Quantity{Amount: item.ToBuy, Unit: item.Unit}),
+			formatQuantity(service.Quantity{Amount: item.InPantry, Unit: item.Unit}), cost)
+	}
+	l.rule()
+	l.row(pdf.HelveticaBold, 11, columns, "Total", "", "", formatPrice(total))
+	_, err := doc.WriteTo(w)
+	return err
+}
+
+const (
+	pdfMargin       = 50
+	pdfContentWidth = pdf.PageWidth - 2*pdfMargin
+	pdfLineSpacing  = 1.35
+)
+
+// pdfLayout places text top to bottom, wrapping lines and starting a new
+// page when the current one is full. Pages get the footer, if set, and their
+// number at the bottom.
+type pdfLayout struct {
+	doc    *pdf.Document
+	page   *pdf.Page
+	footer string
+	pages  int
+	y      float64
+}
+
+func newPdfLayout(doc *pdf.Document, footer string) *pdfLayout {
+	return &pdfLayout{doc: doc, footer: footer}
+}
+
+func (l *pdfLayout) newPage() {
+	l.page = l.doc.AddPage()
+	l.pages++
+	l.y = pdfMargin
+	footer := strconv.Itoa(l.pages)
+	if l.footer != "" {
+		footer = l.footer + " - " + footer
+	}
+	l.page.Text(pdfMargin, pdf.PageHeight-pdfMargin/2, pdf.Helvetica, 8, footer)
+}
+
+// ensure starts a new page unless height fits on the current one.
+func (l *pdfLayout) ensure(height float64) {
+	if l.page == nil || l.y+height > pdf.PageHeight-pdfMargin {
+		l.newPage()
+	}
+}
+
+func (l *pdfLayout) space(height float64) {
+	l.y += height
+}
+
+func (l *pdfLayout) text(font pdf.Font, size float64, s string, indent float64) {
+	for _, line := range pdf.Wrap(font, size, s, pdfContentWidth-indent) {
+		l.ensure(size * pdfLineSpacing)
+		l.y += size * pdfLineSpacing
+		l.page.Text(pdfMargin+indent, l.y, font, size, line)
+	}
+}
+
+// row writes values starting at the column offsets, values are cut to the
+// words fitting their column.
+func (l *pdfLayout) row(font pdf.Font, size float64, columns []float64, values ...string) {
+	l.ensure(size * pdfLineSpacing)
+	l.y += size * pdfLineSpacing
+	for index, value := range values {
+		if value == "" {
+			continue
+		}
+		width := pdfContentWidth - columns[index]
+		if index+1 < len(columns) {
+			width = columns[index+1] - columns[index] - 8
+		}
+		l.page.Text(pdfMargin+columns[index], l.y, font, size, pdf.Wrap(font, size, value, width)[0])
+	}
+}
+
+func (l *pdfLayout) rule() {
+	l.ensure(8)
+	l.y += 4
+	l.page.Line(pdfMargin, l.y, pdf.PageWidth-pdfMargin, l.y, 0.5)
+	l.y += 2
+}
+
+// recipe lays out a recipe card: its details, ingredients, steps and the
+// nutrition panel.
+func (l *pdfLayout) recipe(recipe service.RecipeGet) {
+	l.ensure(200)
+	l.text(pdf.HelveticaBold, 20, recipe.Name, 0)
+	details := []string{fmt.Sprintf("Serves %d", recipe.Servings)}
+	if recipe.PrepMinutes > 0 {
+		details = append(details, fmt.Sprintf("Prep %d min", recipe.PrepMinutes))
+	}
+	if recipe.CookMinutes > 0 {
+		details = append(details, fmt.Sprintf("Cook %d min", recipe.CookMinutes))
+	}
+	details = append(details, recipe.Tags...)
+	l.text(pdf.Helvetica, 10, strings.Join(details, " - "), 0)
+	l.space(10)
+
+	l.text(pdf.HelveticaBold, 14, "Ingredients", 0)
+	l.space(2)
+	for _, ing := range recipe.Ingredients {
+		l.row(pdf.Helvetica, 11, []float64{0, 90}, formatQuantity(ing.Quantity), ing.Name)
+	}
+	l.space(10)
+
+	if steps := service.SplitSteps(recipe.Steps); len(steps) > 0 {
+		l.text(pdf.HelveticaBold, 14, "Steps", 0)
+		l.space(2)
+		for index, step := range steps {
+			l.text(pdf.Helvetica, 11, fmt.Sprintf("%d. %s", index+1, step), 0)
+			l.space(3)
+		}
+		l.space(7)
+	}
+
+	rows := nutritionRows(recipe)
+	height := float64(len(rows)+1)*11*pdfLineSpacing + 30
+	l.ensure(height)
+	l.text(pdf.HelveticaBold, 14, "Nutrition", 0)
+	top := l.y + 4
+	columns := []float64{8, 140, 240}
+	l.row(pdf.HelveticaBold, 11, columns, "", "Total", "Per serving")
+	for _, row := range rows {
+		l.row(pdf.Helvetica, 11, columns, row.Name, row.Total, row.PerServing)
+	}
+	l.y += 6
+	l.page.Rect(pdfMargin, top, 340, l.y-top, 1)
+	if len(recipe.Allergens) > 0 {
+		l.space(6)
+		l.text(pdf.Helvetica, 10, "Contains: "+strings.Join(recipe.Allergens, ", "), 0)
+	}
+}
+
+// formatAmount rounds the amount to at most two decimals.
+func formatAmount(amount float32) string {
+	return strconv.FormatFloat(math.Round(float64(amount)*100)/100, 'f', -1, 64)
+}
+
+func formatQuantity(q service.Quantity) string {
+	return formatAmount(q.Amount) + " " + q.Unit
+}
+
+func formatPrice(price float32) string {
+	return strconv.FormatFloat(float64(price), 'f', 2, 32)
+}
@@ -2,108 +2,326 @@ package handler
 
 import (
 	"encoding/json"
-	"errors"
+	"fmt"
 	"net/http"
+	"net/url"
 	"strconv"
+	"strings"
 
 	"github.com/cookbook/service"
-	"github.com/gorilla/mux"
 )
 
 type RecipeHandler struct {
 	Service service.RecipeService
+	Images  ImageHandler
+}
+
+func (handler RecipeHandler) Resource() Resource {
+	return Resource{Name: "recipe", Get: service.RecipeGet{}, Create: service.RecipeCreate{}, Dependents: true, ListParameters: recipeListParameters}
 }
 
 func (handler RecipeHandler) Get(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter, fields := readRecipeFilter(query)
+	order := service.RecipeSort{Key: "id"}
+	if sort := query.Get("sort"); sort != "" {
+		var ok bool
+		if order, ok = service.ParseRecipeSort(sort); !ok {
+			fields = append(fields, service.FieldError{Field: "sort", Message: "must be one of " + strings.Join(service.RecipeSortKeys, ", ") + ", optionally prefixed with -"})
+		}
+	}
+	if len(fields) > 0 {
+		invalidParameters(w, r, fields)
+		return
+	}
 	w.Header().Set("Content-Type", "application/json")
 	recipes, err := handler.Service.GetAll()
 	if err != nil {
-		handleError(w, err)
+		handleError(w, r, err)
 		return
 	}
+	if !filter.IsEmpty() {
+		matching := make([]service.RecipeGet, 0, len(recipes))
+		for _, recipe := range recipes {
+			if filter.Matches(recipe) {
+				matching = append(matching, recipe)
+			}
+		}
+		recipes = matching
+	}
+	service.SortRecipes(recipes, order)
 	json.NewEncoder(w).Encode(recipes)
 }
 
 func (handler RecipeHandler) GetById(w http.ResponseWriter, r *http.Request) {
-	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
-	if err != nil {
-		handleError(w, err)
+	id, ok := parseId(w, r)
+	if !ok {
 		return
 	}
 	w.Header().Set("Content-Type", "application/json")
 	ing, err := handler.Service.Get(id)
 	if err != nil {
-		handleError(w, err)
+		handleError(w, r, err)
 		return
 	}
 	json.NewEncoder(w).Encode(ing)
 }
 
 func (handler RecipeHandler) Post(w http.ResponseWriter, r *http.Request) {
-	headerContentTtype := r.Header.Get("Content-Type")
-	if headerContentTtype != "application/json" {
-		errorResponse(w, "Content Type is not application/json", http.StatusUnsupportedMediaType)
+	var recipe service.RecipeCreate
+	if !decodeBody(w, r, &recipe) {
+		return
+	}
+	_, err := handler.Service.Create(recipe)
+	if err != nil {
+		handleError(w, r, err)
 		return
 	}
+	messageResponse(w, "Success", http.StatusOK)
+}
+
+func (handler RecipeHandler) Put(w http.ResponseWriter, r *http.Request) {
 	var recipe service.RecipeCreate
-	var unmarshalErr *json.UnmarshalTypeError
+	if !decodeBody(w, r, &recipe) {
+		return
+	}
+	err := handler.Service.Update(recipe)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+	messageResponse(w, "Success", http.StatusOK)
+}
 
-	decoder := json.NewDecoder(r.Body)
-	decoder.DisallowUnknownFields()
-	err := decoder.Decode(&recipe)
+func (handler RecipeHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseId(w, r)
+	if !ok {
+		return
+	}
+	opts, ok := parseDeleteOptions(w, r)
+	if !ok {
+		return
+	}
+	err := handler.Service.Delete(id, opts)
 	if err != nil {
-		if errors.As(err, &unmarshalErr) {
-			errorResponse(w, "Bad Request. Wrong Type provided for field "+unmarshalErr.Field, http.StatusBadRequest)
-		} else {
-			errorResponse(w, "Bad Request "+err.Error(), http.StatusBadRequest)
-		}
+		handleError(w, r, err)
 		return
 	}
-	err = handler.Service.Create(recipe)
+}
+
+const (
+	defaultExpiringWithin = 3
+	defaultSuggestions    = 10
+	maxSuggestions        = 100
+)
+
+var suggestionParameters = []Parameter{
+	{Name: "ingredients", Description: "Comma separated ids of ingredients to use up"},
+	{Name: "expiring_within", Type: "integer", Description: "Use up pantry items expiring within this many days, 3 by default if no ingredients are given"},
+	{Name: "limit", Type: "integer", Description: "Maximum number of suggestions, 10 by default"},
+}
+
+func (handler RecipeHandler) Routes(router SubRouter) {
+	router.Handle(http.MethodGet, "/facets", handler.Facets, Operation{Summary: "Count the recipes passing the filters per tag", Response: service.RecipeFacets{}, Parameters: recipeFilterParameters})
+	router.Handle(http.MethodGet, "/suggestions", handler.Suggest, Operation{Summary: "Suggest recipes using up ingredients", Response: []service.RecipeSuggestion{}, Parameters: suggestionParameters})
+	router.Handle(http.MethodGet, "/{id}/substituted", handler.Substituted, Operation{
+		Summary:    "Get a recipe with substitutions applied",
+		Response:   service.SubstitutedRecipe{},
+		Parameters: []Parameter{{Name: "substitutions", Type: "string", Required: true, Description: "Comma separated ids of the substitutions to apply"}},
+	})
+	router.Handle(http.MethodPost, "/{id}/clone", handler.Clone, Operation{Summary: "Copy a recipe with its ingredients and tags", Request: service.Clone{}, Response: service.RecipeGet{}})
+	router.Handle(http.MethodGet, "/{id}/card", handler.Card, Operation{Summary: "Print-friendly recipe card", ContentType: "text/html", Parameters: servingsParameters})
+	router.Handle(http.MethodGet, "/{id}/card.pdf", handler.CardPdf, Operation{Summary: "Recipe card as PDF", ContentType: pdfContentType, Parameters: servingsParameters})
+	nutritionLabelRoutes(router, "recipe", func(id int64, query labelQuery) (service.NutritionLabel, error) {
+		recipe, err := handler.Service.Get(id)
+		if err != nil {
+			return service.NutritionLabel{}, err
+		}
+		return service.RecipeNutritionLabel(recipe, query.style, query.servings, query.intakes), nil
+	})
+	handler.textRoutes(router)
+	handler.Images.recipeRoutes(router)
+}
+
+var servingsParameters = []Parameter{
+	{Name: "servings", Type: "integer", Description: "Scale the ingredients and nutrition to this many servings instead of the recipe's own"},
+}
+
+// getScaled reads the recipe of the id path parameter, scaled to the servings
+// query parameter if it's given.
+func (handler RecipeHandler) getScaled(w http.ResponseWriter, r *http.Request) (service.RecipeGet, bool) {
+	id, ok := parseId(w, r)
+	if !ok {
+		return service.RecipeGet{}, false
+	}
+	var servings int
+	if value := r.URL.Query().Get("servings"); value != "" {
+		var err error
+		servings, err = strconv.Atoi(value)
+		if err != nil || servings < 1 {
+			invalidParameters(w, r, []service.FieldError{{Field: "servings", Message: "must be a positive integer"}})
+			return service.RecipeGet{}, false
+		}
+	}
+	recipe, err := handler.Service.Get(id)
 	if err != nil {
-		handleError(w, err)
+		handleError(w, r, err)
+		return service.RecipeGet{}, false
+	}
+	if servings > 0 {
+		recipe = service.ScaleRecipe(recipe, int32(servings))
+	}
+	return recipe, true
+}
+
+func (handler RecipeHandler) Card(w http.ResponseWriter, r *http.Request) {
+	recipe, ok := handler.getScaled(w, r)
+	if !ok {
 		return
 	}
-	errorResponse(w, "Success", http.StatusOK)
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	writeRecipeCard(w, recipe)
 }
 
-func (handler RecipeHandler) Put(w http.ResponseWriter, r *http.Request) {
-	headerContentTtype := r.Header.Get("Content-Type")
-	if headerContentTtype != "application/json" {
-		errorResponse(w, "Content Type is not application/json", http.StatusUnsupportedMediaType)
+func (handler RecipeHandler) CardPdf(w http.ResponseWriter, r *http.Request) {
+	recipe, ok := handler.getScaled(w, r)
+	if !ok {
 		return
 	}
-	var recipe service.RecipeCreate
-	var unmarshalErr *json.UnmarshalTypeError
+	w.Header().Set("Content-Type", pdfContentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`inline; filename="recipe-%d.pdf"`, recipe.Id))
+	writeRecipePdf(w, recipe)
+}
 
-	decoder := json.NewDecoder(r.Body)
-	decoder.DisallowUnknownFields()
-	err := decoder.Decode(&recipe)
+// Clone responds with the copy of the recipe.
+func (handler RecipeHandler) Clone(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseId(w, r)
+	if !ok {
+		return
+	}
+	var clone service.Clone
+	if !decodeBody(w, r, &clone) {
+		return
+	}
+	cloneId, err := handler.Service.Clone(id, clone.Name)
 	if err != nil {
-		if errors.As(err, &unmarshalErr) {
-			errorResponse(w, "Bad Request. Wrong Type provided for field "+unmarshalErr.Field, http.StatusBadRequest)
-		} else {
-			errorResponse(w, "Bad Request "+err.Error(), http.StatusBadRequest)
-		}
+		handleError(w, r, err)
+		return
+	}
+	handler.writeRecipe(w, r, cloneId)
+}
+
+func (handler RecipeHandler) Facets(w http.ResponseWriter, r *http.Request) {
+	filter, ok := parseRecipeFilter(w, r)
+	if !ok {
 		return
 	}
-	err = handler.Service.Update(recipe)
+	facets, err := handler.Service.Facets(filter)
 	if err != nil {
-		handleError(w, err)
+		handleError(w, r, err)
 		return
 	}
-	errorResponse(w, "Success", http.StatusOK)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(facets)
 }
 
-func (handler RecipeHandler) Delete(w http.ResponseWriter, r *http.Request) {
-	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+var recipeFilterParameters = append([]Parameter{
+	{Name: "tags", Type: "string", Description: "Comma separated tags that must all be present"},
+	{Name: "not_cooked_within", Type: "integer", Description: "Leave out recipes cooked within this many days"},
+}, dietParameters...)
+
+var recipeListParameters = append([]Parameter{
+	{Name: "sort", Type: "string", Description: "Sort by " + strings.Join(service.RecipeSortKeys, ", ") + ", prefixed with - for descending order"},
+}, recipeFilterParameters...)
+
+// parseRecipeFilter reads the tags and not_cooked_within query parameters
+// besides the diet filter.
+func parseRecipeFilter(w http.ResponseWriter, r *http.Request) (service.RecipeFilter, bool) {
+	filter, fields := readRecipeFilter(r.URL.Query())
+	if len(fields) > 0 {
+		invalidParameters(w, r, fields)
+		return filter, false
+	}
+	return filter, true
+}
+
+func readRecipeFilter(query url.Values) (filter service.RecipeFilter, fields []service.FieldError) {
+	filter.Diet, fields = readDietFilter(query)
+	filter.Tags = splitList(query.Get("tags"))
+	if days := query.Get("not_cooked_within"); days != "" {
+		var err error
+		filter.NotCookedWithin, err = strconv.Atoi(days)
+		if err != nil || filter.NotCookedWithin < 0 {
+			fields = append(fields, service.FieldError{Field: "not_cooked_within", Message: "must be a positive integer"})
+		}
+	}
+	return filter, fields
+}
+
+func (handler RecipeHandler) Substituted(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseId(w, r)
+	if !ok {
+		return
+	}
+	ids, ok := splitIds(r.URL.Query().Get("substitutions"))
+	if !ok || len(ids) == 0 {
+		invalidParameters(w, r, []service.FieldError{{Field: "substitutions", Message: "must be a non-empty comma separated list of integers"}})
+		return
+	}
+	recipe, err := handler.Service.Substitute(id, ids)
 	if err != nil {
-		handleError(w, err)
+		handleError(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(recipe)
+}
+
+func (handler RecipeHandler) Suggest(w http.ResponseWriter, r *http.Request) {
+	query, ok := parseSuggestionQuery(w, r)
+	if !ok {
 		return
 	}
-	err = handler.Service.Delete(id)
+	suggestions, err := handler.Service.Suggest(query)
 	if err != nil {
-		handleError(w, err)
+		handleError(w, r, err)
 		return
 	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(suggestions)
+}
+
+// parseSuggestionQuery reads the ingredients, expiring_within and limit query
+// parameters.
+func parseSuggestionQuery(w http.ResponseWriter, r *http.Request) (query service.SuggestionQuery, ok bool) {
+	var fields []service.FieldError
+	values := r.URL.Query()
+	ids, valid := splitIds(values.Get("ingredients"))
+	if !valid {
+		fields = append(fields, service.FieldError{Field: "ingredients", Message: "must be a comma separated list of integers"})
+	}
+	query.IngredientIds = ids
+	if len(query.IngredientIds) == 0 {
+		query.ExpiringWithin = defaultExpiringWithin
+	}
+	if expiringWithin := values.Get("expiring_within"); expiringWithin != "" {
+		var err error
+		query.ExpiringWithin, err = strconv.Atoi(expiringWithin)
+		if err != nil || query.ExpiringWithin < 0 {
+			fields = append(fields, service.FieldError{Field: "expiring_within", Message: "must be a positive integer"})
+		}
+	}
+	query.Limit = defaultSuggestions
+	if limit := values.Get("limit"); limit != "" {
+		var err error
+		query.Limit, err = strconv.Atoi(limit)
+		if err != nil || query.Limit < 1 || query.Limit > maxSuggestions {
+			fields = append(fields, service.FieldError{Field: "limit", Message: fmt.Sprintf("must be an integer between 1 and %d", maxSuggestions)})
+		}
+	}
+	if len(fields) > 0 {
+		invalidParameters(w, r, fields)
+		return query, false
+	}
+	return query, true
 }
@@ -0,0 +1,123 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/cookbook/service"
+)
+
+// maxRecipeTextSize limits the size of recipes imported from text.
+const maxRecipeTextSize = 1 << 20
+
+var recipeTextFormats = []struct {
+	format      string
+	title       string
+	extension   string
+	contentType string
+	write       func(service.RecipeGet) string
+}{
+	{service.RecipeFormatMarkdown, "Markdown", ".md", "text/markdown", service.RecipeMarkdown},
+	{service.RecipeFormatCooklang, "Cooklang", ".cook", "text/plain", service.RecipeCooklang},
+}
+
+// textRoutes registers the routes exporting recipes to and importing them
+// from the text formats. Imported ingredients are matched to the catalogue
+// by name.
+func (handler RecipeHandler) textRoutes(router SubRouter) {
+	for _, format := range recipeTextFormats {
+		format := format
+		router.Handle(http.MethodGet, "/{id}/"+format.format, func(w http.ResponseWriter, r *http.Request) {
+			id, ok := parseId(w, r)
+			if !ok {
+				return
+			}
+			recipe, err := handler.Service.Get(id)
+			if err != nil {
+				handleError(w, r, err)
+				return
+			}
+			w.Header().Set("Content-Type", format.contentType+"; charset=utf-8")
+			w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="recipe-%d%s"`, recipe.Id, format.extension))
+			io.WriteString(w, format.write(recipe))
+		}, Operation{Summary: "Export a recipe as " + format.title, ContentType: format.contentType})
+		router.Handle(http.MethodPost, "/import/"+format.format, func(w http.ResponseWriter, r *http.Request) {
+			recipe, ok := handler.readText(w, r, format.format)
+			if !ok {
+				return
+			}
+			id, err := handler.Service.Create(recipe)
+			if err != nil {
+				handleError(w, r, err)
+				return
+			}
+			handler.writeRecipe(w, r, id)
+		}, Operation{
+			Summary:            "Create a recipe from " + format.title,
+			Request:            "",
+			RequestContentType: format.contentType,
+			Response:           service.RecipeGet{},
+			Errors:             []int{http.StatusRequestEntityTooLarge},
+		})
+		router.Handle(http.MethodPut, "/{id}/"+format.format, func(w http.ResponseWriter, r *http.Request) {
+			id, ok := parseId(w, r)
+			if !ok {
+				return
+			}
+			recipe, ok := handler.readText(w, r, format.format)
+			if !ok {
+				return
+			}
+			recipe.Id = id
+			err := handler.Service.Update(recipe)
+			if err != nil {
+				handleError(w, r, err)
+				return
+			}
+			handler.writeRecipe(w, r, id)
+		}, Operation{
+			Summary:            "Replace a recipe with one in " + format.title,
+			Request:            "",
+			RequestContentType: format.contentType,
+			Response:           service.RecipeGet{},
+			Errors:             []int{http.StatusRequestEntityTooLarge},
+		})
+	}
+}
+
+// readText parses the recipe in the request body, which may be sent with any
+// text content type.
+func (handler RecipeHandler) readText(w http.ResponseWriter, r *http.Request, format string) (service.RecipeCreate, bool) {
+	if !strings.HasPrefix(r.Header.Get("Content-Type"), "text/") {
+		problemResponse(w, r, newProblem(http.StatusUnsupportedMediaType, "unsupported_media_type", "Content Type is not text"))
+		return service.RecipeCreate{}, false
+	}
+	text, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxRecipeTextSize))
+	if err != nil {
+		if strings.Contains(err.Error(), "request body too large") {
+			problemResponse(w, r, newProblem(http.StatusRequestEntityTooLarge, "payload_too_large", fmt.Sprintf("Recipes must not be larger than %d MB", maxRecipeTextSize>>20)))
+		} else {
+			problemResponse(w, r, newProblem(http.StatusBadRequest, "malformed_body", "Bad Request "+err.Error()))
+		}
+		return service.RecipeCreate{}, false
+	}
+	recipe, err := handler.Service.ParseText(format, string(text))
+	if err != nil {
+		handleError(w, r, err)
+		return service.RecipeCreate{}, false
+	}
+	return recipe, true
+}
+
+func (handler RecipeHandler) writeRecipe(w http.ResponseWriter, r *http.Request, id int64) {
+	recipe, err := handler.Service.Get(id)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(recipe)
+}
@@ -0,0 +1,101 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/cookbook/service"
+)
+
+type ReviewHandler struct {
+	Service service.ReviewService
+}
+
+func (handler ReviewHandler) Resource() Resource {
+	return Resource{Name: "review", Get: service.Review{}, Create: service.Review{}, ListParameters: recipeIdParameters}
+}
+
+func (handler ReviewHandler) Get(w http.ResponseWriter, r *http.Request) {
+	recipeId, ok := parseRecipeQuery(w, r)
+	if !ok {
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	reviews, err := handler.Service.GetAll(recipeId)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+	json.NewEncoder(w).Encode(reviews)
+}
+
+func (handler ReviewHandler) GetById(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseId(w, r)
+	if !ok {
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	review, err := handler.Service.Get(id)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+	json.NewEncoder(w).Encode(review)
+}
+
+func (handler ReviewHandler) Post(w http.ResponseWriter, r *http.Request) {
+	var review service.Review
+	if !decodeBody(w, r, &review) {
+		return
+	}
+	_, err := handler.Service.Create(review)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+	messageResponse(w, "Success", http.StatusOK)
+}
+
+func (handler ReviewHandler) Put(w http.ResponseWriter, r *http.Request) {
+	var review service.Review
+	if !decodeBody(w, r, &review) {
+		return
+	}
+	err := handler.Service.Update(review)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+	messageResponse(w, "Success", http.StatusOK)
+}
+
+func (handler ReviewHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseId(w, r)
+	if !ok {
+		return
+	}
+	err := handler.Service.Delete(id)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+}
+
+var recipeIdParameters = []Parameter{
+	{Name: "recipe", Type: "integer", Description: "Only list the ones of this recipe"},
+}
+
+// parseRecipeQuery reads the recipe query parameter, 0 if it's missing.
+func parseRecipeQuery(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	value := r.URL.Query().Get("recipe")
+	if value == "" {
+		return 0, true
+	}
+	id, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || id <= 0 {
+		invalidParameters(w, r, []service.FieldError{{Field: "recipe", Message: "must be a positive integer"}})
+		return 0, false
+	}
+	return id, true
+}
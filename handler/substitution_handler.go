@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/cookbook/service"
+)
+
+type SubstitutionHandler struct {
+	Service service.SubstitutionService
+}
+
+func (handler SubstitutionHandler) Resource() Resource {
+	return Resource{Name: "substitution", Get: service.Substitution{}, Create: service.Substitution{}}
+}
+
+func (handler SubstitutionHandler) Get(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	subs, err := handler.Service.GetAll()
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+	json.NewEncoder(w).Encode(subs)
+}
+
+func (handler SubstitutionHandler) GetById(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseId(w, r)
+	if !ok {
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	sub, err := handler.Service.Get(id)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+	json.NewEncoder(w).Encode(sub)
+}
+
+func (handler SubstitutionHandler) Post(w http.ResponseWriter, r *http.Request) {
+	var sub service.Substitution
+	if !decodeBody(w, r, &sub) {
+		return
+	}
+	_, err := handler.Service.Create(sub)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+	messageResponse(w, "Success", http.StatusOK)
+}
+
+func (handler SubstitutionHandler) Put(w http.ResponseWriter, r *http.Request) {
+	var sub service.Substitution
+	if !decodeBody(w, r, &sub) {
+		return
+	}
+	err := handler.Service.Update(sub)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+	messageResponse(w, "Success", http.StatusOK)
+}
+
+func (handler SubstitutionHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseId(w, r)
+	if !ok {
+		return
+	}
+	err := handler.Service.Delete(id)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+}
@@ -3,12 +3,15 @@ package main
 import (
 	"context"
 	"fmt"
+	"net"
 	"net/http"
 	"os"
 
 	"github.com/cookbook/handler"
 	"github.com/cookbook/repository"
+	"github.com/cookbook/rpc"
 	"github.com/cookbook/service"
+	"github.com/cookbook/storage"
 	"github.com/jackc/pgx/v4/pgxpool"
 )
 
@@ -16,6 +19,15 @@ func main() {
 	databaseUrl := os.Getenv("DATABASE_URL")
 	serverHost := os.Getenv("SERVER_HOST")
 	serverPort := os.Getenv("PORT")
+	grpcPort := os.Getenv("GRPC_PORT")
+	blobDir := os.Getenv("BLOB_DIR")
+	if blobDir == "" {
+		blobDir = "data/blobs"
+	}
+	docsAssetsUrl := os.Getenv("DOCS_ASSETS_URL")
+	if docsAssetsUrl == "" {
+		docsAssetsUrl = handler.DefaultDocsAssetsUrl
+	}
 	
 	dbConn, err := pgxpool.Connect(context.Background(), databaseUrl)
 	if err != nil {
@@ -25,26 +37,63 @@ func main() {
 
 	defer dbConn.Close()
 
+	blobs, err := storage.NewLocalBlobStore(blobDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Unable to open blob store: %v\n", err)
+		os.Exit(1)
+	}
+
 	repo := repository.NewIngredientRepository(dbConn)
 	recipeRepo := repository.NewRecipeRepository(dbConn)
 	mealRepo := repository.NewMealRepository(dbConn)
 	mealPlanRepo := repository.NewMealPlanRepository(dbConn)
+	pantryRepo := repository.NewPantryRepository(dbConn)
+	priceRepo := repository.NewIngredientPriceRepository(dbConn)
+	subRepo := repository.NewSubstitutionRepository(dbConn)
+	collectionRepo := repository.NewCollectionRepository(dbConn)
+	reviewRepo := repository.NewReviewRepository(dbConn)
+	cookLogRepo := repository.NewCookLogRepository(dbConn)
+	imageRepo := repository.NewRecipeImageRepository(dbConn)
+	memberRepo := repository.NewMemberRepository(dbConn)
 
 	serv := service.NewIngredientService(repo)
-	recipeServ := service.NewRecipeService(recipeRepo, serv)
+	pantryServ := service.NewPantryService(pantryRepo, serv)
+	priceServ := service.NewIngredientPriceService(priceRepo, serv)
+	subServ := service.NewSubstitutionService(subRepo, serv)
+	recipeServ := service.NewRecipeService(recipeRepo, serv, pantryServ, priceServ, subServ, blobs)
 	mealServ := service.NewMealService(mealRepo, recipeServ)
-	mealPlanServ := service.NewMealPlanService(mealPlanRepo, mealServ)
-
-	router := handler.NewRestRouter()
-	ingredientHandler := handler.IngredientHandler{Service: serv}
-	recipeHandler := handler.RecipeHandler{Service: recipeServ}
-	mealHandler := handler.MealHandler{Service: mealServ}
-	mealPlanHandler := handler.MealPlanHandler{Service: mealPlanServ}
-
-	router.Register("ingredients", ingredientHandler)
-	router.Register("recipes", recipeHandler)
-	router.Register("meals", mealHandler)
-	router.Register("meal-plans", mealPlanHandler)
+	collectionServ := service.NewCollectionService(collectionRepo, recipeServ)
+	reviewServ := service.NewReviewService(reviewRepo, recipeServ)
+	cookLogServ := service.NewCookLogService(cookLogRepo, recipeServ)
+	imageServ := service.NewRecipeImageService(imageRepo, recipeServ, blobs)
+	memberServ := service.NewMemberService(memberRepo)
+	mealPlanServ := service.NewMealPlanService(mealPlanRepo, mealServ, pantryServ, priceServ, memberServ)
+	archiveServ := service.NewArchiveService(serv, recipeServ, mealServ, mealPlanServ, imageServ, memberServ)
+
+	router := handler.NewAPI(handler.Services{
+		Ingredients:   serv,
+		Recipes:       recipeServ,
+		Meals:         mealServ,
+		MealPlans:     mealPlanServ,
+		Pantry:        pantryServ,
+		Prices:        priceServ,
+		Substitutions: subServ,
+		Collections:   collectionServ,
+		Reviews:       reviewServ,
+		CookLog:       cookLogServ,
+		Images:        imageServ,
+		Archive:       archiveServ,
+		Members:       memberServ,
+	}, docsAssetsUrl)
+
+	if grpcPort != "" {
+		listener, err := net.Listen("tcp", serverHost+":"+grpcPort)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Unable to listen on gRPC port: %v\n", err)
+			os.Exit(1)
+		}
+		go rpc.NewServer(serv, recipeServ, mealServ, mealPlanServ).Serve(listener)
+	}
 
 	http.ListenAndServe(serverHost + ":" + serverPort, router)
 }
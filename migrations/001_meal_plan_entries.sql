@@ -0,0 +1,38 @@
+-- Meal plans used to store their meals per day, ordered by an index counting
+-- from 0 within each day. Entries now carry their slot, servings and the
+-- state of cooking them, and are ordered by an index counting from 0 within
+-- the whole meal plan, which is also how the cooked route addresses them.
+BEGIN;
+
+ALTER TABLE meal_plans
+	ADD COLUMN followed boolean NOT NULL DEFAULT false,
+	ADD COLUMN feed_token text UNIQUE;
+
+ALTER TABLE meal_plan_meals
+	ADD COLUMN slot text,
+	ADD COLUMN servings real NOT NULL DEFAULT 1,
+	ADD COLUMN cooked boolean NOT NULL DEFAULT false,
+	ADD COLUMN batch integer NOT NULL DEFAULT 0,
+	ADD COLUMN portions real NOT NULL DEFAULT 0,
+	ADD COLUMN people integer NOT NULL DEFAULT 1,
+	ADD COLUMN members bigint[];
+
+-- The first three meals of a day become its breakfast, lunch and dinner, any
+-- further ones snacks.
+UPDATE meal_plan_meals SET slot = CASE index
+	WHEN 0 THEN 'breakfast'
+	WHEN 1 THEN 'lunch'
+	WHEN 2 THEN 'dinner'
+	ELSE 'snack'
+END;
+
+ALTER TABLE meal_plan_meals ALTER COLUMN slot SET NOT NULL;
+
+UPDATE meal_plan_meals SET index = numbered.position
+FROM (
+	SELECT ctid, row_number() OVER (PARTITION BY meal_plan_id ORDER BY day, index) - 1 AS position
+	FROM meal_plan_meals
+) AS numbered
+WHERE meal_plan_meals.ctid = numbered.ctid;
+
+COMMIT;
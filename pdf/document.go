@@ -0,0 +1,126 @@
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// A4 page size in points.
+const (
+	PageWidth  = 595.28
+	PageHeight = 841.89
+)
+
+// Document is a PDF of text and lines set in the standard fonts, enough for
+// recipe cards and lists without depending on a PDF library.
+type Document struct {
+	Title string
+	pages []*Page
+}
+
+// Page collects the drawing operations of a page. Coordinates are in points
+// from the top left corner, text is placed by its baseline.
+type Page struct {
+	content bytes.Buffer
+}
+
+func New(title string) *Document {
+	return &Document{Title: title}
+}
+
+func (d *Document) AddPage() *Page {
+	page := &Page{}
+	d.pages = append(d.pages, page)
+	return page
+}
+
+func (p *Page) Text(x, y float64, font Font, size float64, s string) {
+	fmt.Fprintf(&p.content, "BT /F%d %s Tf %s %s Td ", font+1, number(size), number(x), number(PageHeight-y))
+	writeString(&p.content, encode(s))
+	p.content.WriteString(" Tj ET\n")
+}
+
+func (p *Page) Line(x1, y1, x2, y2, width float64) {
+	fmt.Fprintf(&p.content, "%s w %s %s m %s %s l S\n", number(width), number(x1), number(PageHeight-y1), number(x2), number(PageHeight-y2))
+}
+
+// Rect strokes a rectangle whose top left corner is at x and y.
+func (p *Page) Rect(x, y, width, height, lineWidth float64) {
+	fmt.Fprintf(&p.content, "%s w %s %s %s %s re S\n", number(lineWidth), number(x), number(PageHeight-y-height), number(width), number(height))
+}
+
+// WriteTo writes the document, compressing the page contents.
+func (d *Document) WriteTo(w io.Writer) (int64, error) {
+	pages := d.pages
+	if len(pages) == 0 {
+		pages = []*Page{{}}
+	}
+	out := &pdfWriter{}
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	// Objects 1 to 4 are the catalog, the page tree, the info dictionary and
+	// the font resources, each page takes two more for itself and its content.
+	out.object(1, "<< /Type /Catalog /Pages 2 0 R >>")
+	kids := &bytes.Buffer{}
+	for index := range pages {
+		fmt.Fprintf(kids, "%d 0 R ", 5+2*index)
+	}
+	out.object(2, fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", kids.String(), len(pages)))
+	title := &bytes.Buffer{}
+	writeString(title, encode(d.Title))
+	out.object(3, fmt.Sprintf("<< /Title %s /Producer (Cookbook) >>", title.String()))
+	fonts := &bytes.Buffer{}
+	for index, name := range fontNames {
+		fmt.Fprintf(fonts, "/F%d << /Type /Font /Subtype /Type1 /BaseFont /%s /Encoding /WinAnsiEncoding >> ", index+1, name)
+	}
+	out.object(4, "<< /Font << "+fonts.String()+">> >>")
+	for index, page := range pages {
+		id := 5 + 2*index
+		out.object(id, fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] /Resources 4 0 R /Contents %d 0 R >>", number(PageWidth), number(PageHeight), id+1))
+		var compressed bytes.Buffer
+		zw := zlib.NewWriter(&compressed)
+		zw.Write(page.content.Bytes())
+		zw.Close()
+		out.offsets = append(out.offsets, out.Len())
+		fmt.Fprintf(out, "%d 0 obj\n<< /Length %d /Filter /FlateDecode >>\nstream\n", id+1, compressed.Len())
+		out.Write(compressed.Bytes())
+		out.WriteString("\nendstream\nendobj\n")
+	}
+	xref := out.Len()
+	fmt.Fprintf(out, "xref\n0 %d\n0000000000 65535 f \n", len(out.offsets)+1)
+	for _, offset := range out.offsets {
+		fmt.Fprintf(out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(out, "trailer\n<< /Size %d /Root 1 0 R /Info 3 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(out.offsets)+1, xref)
+	return out.WriteTo(w)
+}
+
+// pdfWriter buffers the file to track the offset of every object for the
+// cross-reference table. Objects must be written in the order of their ids.
+type pdfWriter struct {
+	bytes.Buffer
+	offsets []int
+}
+
+func (out *pdfWriter) object(id int, dict string) {
+	out.offsets = append(out.offsets, out.Len())
+	fmt.Fprintf(out, "%d 0 obj\n%s\nendobj\n", id, dict)
+}
+
+// writeString writes a literal string, escaping the delimiters.
+func writeString(b *bytes.Buffer, s []byte) {
+	b.WriteByte('(')
+	for _, c := range s {
+		if c == '(' || c == ')' || c == '\\' {
+			b.WriteByte('\\')
+		}
+		b.WriteByte(c)
+	}
+	b.WriteByte(')')
+}
+
+func number(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 32)
+}
@@ -0,0 +1,9 @@
+package repository
+
+// Collection is a named, ordered list of recipes.
+type Collection struct {
+	Id          int64
+	Name        string
+	Description string
+	Recipes     []int64
+}
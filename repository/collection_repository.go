@@ -0,0 +1,179 @@
+package repository
+
+import (
+	"context"
+	"log"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type CollectionRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewCollectionRepository(dbConn *pgxpool.Pool) *CollectionRepository {
+	r := new(CollectionRepository)
+	r.db = dbConn
+	return r
+}
+
+const collectionColumns = "id, name, description"
+
+func (r CollectionRepository) Get(id int64) (collection Collection, e error) {
+	err := r.db.QueryRow(context.Background(), "SELECT "+collectionColumns+" FROM collections WHERE id = $1", id).Scan(&collection.Id, &collection.Name, &collection.Description)
+	if err != nil {
+		log.Println(err.Error())
+		switch err {
+		case pgx.ErrNoRows:
+			return Collection{}, &NotFound{"collections", id}
+		default:
+			return Collection{}, &InternalError{err.Error()}
+		}
+	}
+	recipes, err := r.getCollectionRecipes("SELECT collection_id, recipe_id FROM collection_recipes WHERE collection_id = $1 ORDER BY position", id)
+	if err != nil {
+		return Collection{}, err
+	}
+	collection.Recipes = recipes[id]
+	return collection, nil
+}
+
+func (r CollectionRepository) GetAll() ([]Collection, error) {
+	results, err := r.db.Query(context.Background(), "SELECT "+collectionColumns+" FROM collections ORDER BY name, id")
+	if err != nil {
+		return []Collection{}, &InternalError{err.Error()}
+	}
+	defer results.Close()
+	recipes, err := r.getCollectionRecipes("SELECT collection_id, recipe_id FROM collection_recipes ORDER BY collection_id, position")
+	if err != nil {
+		return []Collection{}, err
+	}
+	var collections []Collection
+	for results.Next() {
+		var collection Collection
+		err := results.Scan(&collection.Id, &collection.Name, &collection.Description)
+		if err != nil {
+			log.Println(err.Error())
+		}
+		collection.Recipes = recipes[collection.Id]
+		collections = append(collections, collection)
+	}
+	return collections, nil
+}
+
+func (r CollectionRepository) getCollectionRecipes(query string, args ...interface{}) (map[int64][]int64, error) {
+	recipes := make(map[int64][]int64)
+	results, err := r.db.Query(context.Background(), query, args...)
+	if err != nil {
+		log.Println(err.Error())
+		return nil, &InternalError{err.Error()}
+	}
+	defer results.Close()
+	for results.Next() {
+		var collectionId, recipeId int64
+		err = results.Scan(&collectionId, &recipeId)
+		if err != nil {
+			log.Println(err.Error())
+		}
+		recipes[collectionId] = append(recipes[collectionId], recipeId)
+	}
+	return recipes, nil
+}
+
+func (r CollectionRepository) Create(collection Collection) (int64, error) {
+	ctx := context.Background()
+	tx, err := r.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return 0, &InternalError{err.Error()}
+	}
+	err = tx.QueryRow(ctx, "INSERT INTO collections (name, description) VALUES ($1, $2) RETURNING id", collection.Name, collection.Description).Scan(&collection.Id)
+	if err != nil {
+		log.Println(err.Error())
+		tx.Rollback(ctx)
+		return 0, &InternalError{err.Error()}
+	}
+	err = r.createCollectionRecipes(tx, ctx, collection)
+	if err != nil {
+		tx.Rollback(ctx)
+		return 0, err
+	}
+	err = tx.Commit(ctx)
+	if err != nil {
+		return 0, &InternalError{err.Error()}
+	}
+	return collection.Id, nil
+}
+
+func (r CollectionRepository) Update(collection Collection) error {
+	ctx := context.Background()
+	tx, err := r.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return &InternalError{err.Error()}
+	}
+	result, err := tx.Exec(ctx, "UPDATE collections SET name = $1, description = $2 WHERE id = $3", collection.Name, collection.Description, collection.Id)
+	if err != nil {
+		log.Println(err.Error())
+		tx.Rollback(ctx)
+		return &InternalError{err.Error()}
+	}
+	if result.RowsAffected() != 1 {
+		tx.Rollback(ctx)
+		return &NotFound{"collections", collection.Id}
+	}
+	_, err = tx.Exec(ctx, "DELETE FROM collection_recipes WHERE collection_id = $1", collection.Id)
+	if err != nil {
+		tx.Rollback(ctx)
+		return &InternalError{err.Error()}
+	}
+	err = r.createCollectionRecipes(tx, ctx, collection)
+	if err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
+	err = tx.Commit(ctx)
+	if err != nil {
+		return &InternalError{err.Error()}
+	}
+	return nil
+}
+
+// createCollectionRecipes stores the recipes of the collection, their
+// position being the order they are listed in.
+func (r CollectionRepository) createCollectionRecipes(tx pgx.Tx, ctx context.Context, collection Collection) error {
+	for position, recipeId := range collection.Recipes {
+		_, err := tx.Exec(ctx, "INSERT INTO collection_recipes (collection_id, recipe_id, position) VALUES ($1, $2, $3)", collection.Id, recipeId, position)
+		if err != nil {
+			log.Println(err.Error())
+			return &InternalError{err.Error()}
+		}
+	}
+	return nil
+}
+
+func (r CollectionRepository) Delete(id int64) error {
+	ctx := context.Background()
+	tx, err := r.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return &InternalError{err.Error()}
+	}
+	_, err = tx.Exec(ctx, "DELETE FROM collection_recipes WHERE collection_id = $1", id)
+	if err != nil {
+		tx.Rollback(ctx)
+		return &InternalError{err.Error()}
+	}
+	result, err := tx.Exec(ctx, "DELETE FROM collections WHERE id = $1", id)
+	if err != nil {
+		tx.Rollback(ctx)
+		return &InternalError{err.Error()}
+	}
+	if result.RowsAffected() != 1 {
+		tx.Rollback(ctx)
+		return &NotFound{"collections", id}
+	}
+	err = tx.Commit(ctx)
+	if err != nil {
+		return &InternalError{err.Error()}
+	}
+	return nil
+}
@@ -0,0 +1,13 @@
+package repository
+
+import "time"
+
+// CookLogEntry records a recipe being cooked.
+type CookLogEntry struct {
+	Id            int64
+	RecipeId      int64
+	Date          time.Time
+	Servings      float32
+	Notes         string
+	Modifications string
+}
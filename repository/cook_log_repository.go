@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"context"
+	"log"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type CookLogRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewCookLogRepository(dbConn *pgxpool.Pool) *CookLogRepository {
+	r := new(CookLogRepository)
+	r.db = dbConn
+	return r
+}
+
+const cookLogColumns = "id, recipe_id, date, servings, notes, modifications"
+
+func (r CookLogRepository) Get(id int64) (entry CookLogEntry, e error) {
+	err := r.db.QueryRow(context.Background(), "SELECT "+cookLogColumns+" FROM cook_log WHERE id = $1", id).Scan(&entry.Id, &entry.RecipeId, &entry.Date, &entry.Servings, &entry.Notes, &entry.Modifications)
+	if err != nil {
+		log.Println(err.Error())
+		switch err {
+		case pgx.ErrNoRows:
+			e = &NotFound{"cook_log", id}
+		default:
+			e = &InternalError{err.Error()}
+		}
+	}
+	return
+}
+
+// GetAll returns the cook log of the recipe, or of all recipes if recipeId is
+// 0, the most recent first.
+func (r CookLogRepository) GetAll(recipeId int64) ([]CookLogEntry, error) {
+	query := "SELECT " + cookLogColumns + " FROM cook_log WHERE $1 = 0 OR recipe_id = $1 ORDER BY date DESC, id DESC"
+	results, err := r.db.Query(context.Background(), query, recipeId)
+	if err != nil {
+		log.Println(err.Error())
+		return []CookLogEntry{}, &InternalError{err.Error()}
+	}
+	defer results.Close()
+	var entries []CookLogEntry
+	for results.Next() {
+		var entry CookLogEntry
+		err = results.Scan(&entry.Id, &entry.RecipeId, &entry.Date, &entry.Servings, &entry.Notes, &entry.Modifications)
+		if err != nil {
+			log.Println(err.Error())
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+func (r CookLogRepository) Create(entry CookLogEntry) (int64, error) {
+	err := r.db.QueryRow(context.Background(), "INSERT INTO cook_log (recipe_id, date, servings, notes, modifications) VALUES ($1, $2, $3, $4, $5) RETURNING id", entry.RecipeId, entry.Date, entry.Servings, entry.Notes, entry.Modifications).Scan(&entry.Id)
+	if err != nil {
+		log.Println(err.Error())
+		return 0, &InternalError{err.Error()}
+	}
+	return entry.Id, nil
+}
+
+func (r CookLogRepository) Update(entry CookLogEntry) error {
+	result, err := r.db.Exec(context.Background(), "UPDATE cook_log SET recipe_id = $1, date = $2, servings = $3, notes = $4, modifications = $5 WHERE id = $6", entry.RecipeId, entry.Date, entry.Servings, entry.Notes, entry.Modifications, entry.Id)
+	if err != nil {
+		log.Println(err.Error())
+		return &InternalError{err.Error()}
+	}
+	rowCnt := result.RowsAffected()
+	if rowCnt != 1 {
+		return &NotFound{"cook_log", entry.Id}
+	}
+	return nil
+}
+
+func (r CookLogRepository) Delete(id int64) error {
+	result, err := r.db.Exec(context.Background(), "DELETE FROM cook_log WHERE id = $1", id)
+	if err != nil {
+		return &InternalError{err.Error()}
+	}
+	rowCnt := result.RowsAffected()
+	if rowCnt != 1 {
+		return &NotFound{"cook_log", id}
+	}
+	return nil
+}
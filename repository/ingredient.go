@@ -9,4 +9,7 @@ type Ingredient struct {
 	Fat      float32
 	Amount   float32
 	Unit     string
+	// Allergens and Diets are stored as text arrays.
+	Allergens []string
+	Diets     []string
 }
@@ -0,0 +1,14 @@
+package repository
+
+import "time"
+
+// IngredientPrice is the price paid for a package of an ingredient.
+type IngredientPrice struct {
+	Id           int64
+	IngredientId int64
+	Price        float32
+	Amount       float32
+	Unit         string
+	Store        string
+	Date         time.Time
+}
@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"log"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type IngredientPriceRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewIngredientPriceRepository(dbConn *pgxpool.Pool) *IngredientPriceRepository {
+	r := new(IngredientPriceRepository)
+	r.db = dbConn
+	return r
+}
+
+const ingredientPriceColumns = "id, ingredient_id, price, amount, unit, store, date"
+
+func (r IngredientPriceRepository) Get(id int64) (price IngredientPrice, e error) {
+	err := r.db.QueryRow(context.Background(), "SELECT "+ingredientPriceColumns+" FROM ingredient_prices WHERE id = $1", id).Scan(&price.Id, &price.IngredientId, &price.Price, &price.Amount, &price.Unit, &price.Store, &price.Date)
+	if err != nil {
+		log.Println(err.Error())
+		switch err {
+		case pgx.ErrNoRows:
+			e = &NotFound{"ingredient_prices", id}
+		default:
+			e = &InternalError{err.Error()}
+		}
+	}
+	return
+}
+
+// GetAll returns the price records, the most recent first.
+func (r IngredientPriceRepository) GetAll() ([]IngredientPrice, error) {
+	return r.getPrices("SELECT " + ingredientPriceColumns + " FROM ingredient_prices ORDER BY date DESC, id DESC")
+}
+
+// GetLatest returns the most recent price record of each of the given
+// ingredients that has any.
+func (r IngredientPriceRepository) GetLatest(ingredientIds []int64) ([]IngredientPrice, error) {
+	if len(ingredientIds) == 0 {
+		return []IngredientPrice{}, nil
+	}
+	return r.getPrices("SELECT DISTINCT ON (ingredient_id) " + ingredientPriceColumns + " FROM ingredient_prices WHERE ingredient_id IN (" + JoinIds(ingredientIds) + ") ORDER BY ingredient_id, date DESC, id DESC")
+}
+
+func (r IngredientPriceRepository) getPrices(query string) ([]IngredientPrice, error) {
+	results, err := r.db.Query(context.Background(), query)
+	if err != nil {
+		log.Println(err.Error())
+		return []IngredientPrice{}, &InternalError{err.Error()}
+	}
+	defer results.Close()
+	var prices []IngredientPrice
+	for results.Next() {
+		var price IngredientPrice
+		err = results.Scan(&price.Id, &price.IngredientId, &price.Price, &price.Amount, &price.Unit, &price.Store, &price.Date)
+		if err != nil {
+			log.Println(err.Error())
+		}
+		prices = append(prices, price)
+	}
+	return prices, nil
+}
+
+func (r IngredientPriceRepository) Create(price IngredientPrice) (int64, error) {
+	err := r.db.QueryRow(context.Background(), "INSERT INTO ingredient_prices (ingredient_id, price, amount, unit, store, date) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id", price.IngredientId, price.Price, price.Amount, price.Unit, price.Store, price.Date).Scan(&price.Id)
+	if err != nil {
+		log.Println(err.Error())
+		return 0, &InternalError{err.Error()}
+	}
+	return price.Id, nil
+}
+
+func (r IngredientPriceRepository) Update(price IngredientPrice) error {
+	result, err := r.db.Exec(context.Background(), "UPDATE ingredient_prices SET ingredient_id = $1, price = $2, amount = $3, unit = $4, store = $5, date = $6 WHERE id = $7", price.IngredientId, price.Price, price.Amount, price.Unit, price.Store, price.Date, price.Id)
+	if err != nil {
+		log.Println(err.Error())
+		return &InternalError{err.Error()}
+	}
+	rowCnt := result.RowsAffected()
+	if rowCnt != 1 {
+		return &NotFound{"ingredient_prices", price.Id}
+	}
+	return nil
+}
+
+func (r IngredientPriceRepository) Delete(id int64) error {
+	result, err := r.db.Exec(context.Background(), "DELETE FROM ingredient_prices WHERE id = $1", id)
+	if err != nil {
+		return &InternalError{err.Error()}
+	}
+	rowCnt := result.RowsAffected()
+	if rowCnt != 1 {
+		return &NotFound{"ingredient_prices", id}
+	}
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"log"
 	"strconv"
+	"strings"
 
 	"github.com/jackc/pgx/v4"
 	"github.com/jackc/pgx/v4/pgxpool"
@@ -32,14 +33,56 @@ func (e *InternalError) Error() string {
 	return e.message
 }
 
+// Conflict is returned when deleting a row that other rows still reference,
+// or when a change doesn't apply to the row as it is now.
+type Conflict struct {
+	Table      string
+	Id         int64
+	Dependents []Dependents
+}
+
+// Dependents are the rows of a table referencing a deleted row.
+type Dependents struct {
+	Table string
+	Ids   []int64
+}
+
+func (e *Conflict) Error() string {
+	if len(e.Dependents) == 0 {
+		return fmt.Sprintf("%s with id %d can't be changed in its current state", e.Table, e.Id)
+	}
+	used := make([]string, len(e.Dependents))
+	for i, dependents := range e.Dependents {
+		used[i] = dependents.Table + " " + JoinIds(dependents.Ids)
+	}
+	return fmt.Sprintf("%s with id %d is used by %s", e.Table, e.Id, strings.Join(used, " and "))
+}
+
+// DeleteOptions controls what happens to the rows referencing a deleted row.
+// By default the delete fails with a Conflict if there are any. Rows owned by
+// the deleted row are always removed with it.
+type DeleteOptions struct {
+	// Cascade removes the references from the referencing rows.
+	Cascade bool
+	// ReplaceWith points the references to another row instead, 0 if unused.
+	// References of a row already referencing the replacement are merged
+	// with it.
+	ReplaceWith int64
+	// ConvertUnit returns the factor converting amounts between units, used
+	// to add up the amounts of merged references.
+	ConvertUnit func(src, dst string) (float32, error)
+}
+
 func NewIngredientRepository(dbConn *pgxpool.Pool) *IngredientRepository {
 	r := new(IngredientRepository)
 	r.db = dbConn
 	return r
 }
 
+const ingredientColumns = "id, name, calories, protein, carbs, fat, amount, unit, allergens, diets"
+
 func (r IngredientRepository) Get(id int64) (i Ingredient, e error) {
-	err := r.db.QueryRow(context.Background(), "SELECT * FROM ingredients WHERE id = $1", id).Scan(&i.Id, &i.Name, &i.Calories, &i.Protein, &i.Carbs, &i.Fat, &i.Amount, &i.Unit)
+	err := r.db.QueryRow(context.Background(), "SELECT "+ingredientColumns+" FROM ingredients WHERE id = $1", id).Scan(&i.Id, &i.Name, &i.Calories, &i.Protein, &i.Carbs, &i.Fat, &i.Amount, &i.Unit, &i.Allergens, &i.Diets)
 	if err != nil {
 		log.Println(err.Error())
 		switch err {
@@ -52,21 +95,17 @@ func (r IngredientRepository) Get(id int64) (i Ingredient, e error) {
 	return
 }
 
-func (r IngredientRepository) Create(i Ingredient) error {
-	result, err := r.db.Exec(context.Background(), "INSERT INTO ingredients (name, calories, protein, carbs, fat, amount, unit) VALUES ($1, $2, $3, $4, $5, $6, $7)", i.Name, i.Calories, i.Protein, i.Carbs, i.Fat, i.Amount, i.Unit)
+func (r IngredientRepository) Create(i Ingredient) (int64, error) {
+	err := r.db.QueryRow(context.Background(), "INSERT INTO ingredients (name, calories, protein, carbs, fat, amount, unit, allergens, diets) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING id", i.Name, i.Calories, i.Protein, i.Carbs, i.Fat, i.Amount, i.Unit, i.Allergens, i.Diets).Scan(&i.Id)
 	if err != nil {
 		log.Println(err.Error())
-		return &InternalError{err.Error()}
+		return 0, &InternalError{err.Error()}
 	}
-	rowCnt := result.RowsAffected()
-	if rowCnt != 1 {
-		return &InternalError{"ingredient not created"}
-	}
-	return nil
+	return i.Id, nil
 }
 
 func (r IngredientRepository) Update(i Ingredient) error {
-	result, err := r.db.Exec(context.Background(), "UPDATE ingredients SET name = $1, calories = $2, protein = $3, carbs = $4, fat = $5, amount = $6, unit = $7 WHERE id = $8", i.Name, i.Calories, i.Protein, i.Carbs, i.Fat, i.Amount, i.Unit, i.Id)
+	result, err := r.db.Exec(context.Background(), "UPDATE ingredients SET name = $1, calories = $2, protein = $3, carbs = $4, fat = $5, amount = $6, unit = $7, allergens = $8, diets = $9 WHERE id = $10", i.Name, i.Calories, i.Protein, i.Carbs, i.Fat, i.Amount, i.Unit, i.Allergens, i.Diets, i.Id)
 	if err != nil {
 		log.Println(err.Error())
 		switch err {
@@ -83,26 +122,27 @@ func (r IngredientRepository) Update(i Ingredient) error {
 	return nil
 }
 
-func (r IngredientRepository) Delete(id int64) error {
-	result, err := r.db.Exec(context.Background(), "DELETE FROM ingredients WHERE id = $1", id)
-	if err != nil {
-		return &InternalError{err.Error()}
-	}
-	rowCnt := result.RowsAffected()
-	if rowCnt != 1 {
-		return &NotFound{"ingredients", id}
-	}
-	return nil
+func (r IngredientRepository) Delete(id int64, opts DeleteOptions) error {
+	return deleteWithDependents(r.db, "ingredients", id, opts,
+		dependency{"recipes", "recipe_ingredients", "recipe_id", "ingredient_id", false, addAmounts},
+		dependency{"pantry_items", "pantry_items", "id", "ingredient_id", true, keepBoth},
+		dependency{"ingredient_prices", "ingredient_prices", "id", "ingredient_id", true, keepBoth},
+		dependency{"substitutions", "substitutions", "id", "ingredient_id", true, keepBoth},
+		dependency{"substitutions", "substitutions", "id", "substitute_id", true, keepBoth})
+}
+
+func (r IngredientRepository) GetExistingIds(ids []int64) (map[int64]bool, error) {
+	return getExistingIds(r.db, "ingredients", ids)
 }
 
 func (r IngredientRepository) GetAll() (ingredients []Ingredient, err error) {
-	results, err := r.db.Query(context.Background(), "SELECT * FROM ingredients")
+	results, err := r.db.Query(context.Background(), "SELECT "+ingredientColumns+" FROM ingredients")
 	if err != nil {
 		return []Ingredient{}, &InternalError{err.Error()}
 	}
 	for results.Next() {
 		var i Ingredient
-		err = results.Scan(&i.Id, &i.Name, &i.Calories, &i.Protein, &i.Carbs, &i.Fat, &i.Amount, &i.Unit)
+		err = results.Scan(&i.Id, &i.Name, &i.Calories, &i.Protein, &i.Carbs, &i.Fat, &i.Amount, &i.Unit, &i.Allergens, &i.Diets)
 		if err != nil {
 			log.Println(err.Error())
 		}
@@ -112,13 +152,13 @@ func (r IngredientRepository) GetAll() (ingredients []Ingredient, err error) {
 }
 
 func (r IngredientRepository) GetList(ids []int64) (ingredients []Ingredient, err error) {
-	results, err := r.db.Query(context.Background(), "SELECT * FROM ingredients WHERE id IN ("+JoinIds(ids)+")")
+	results, err := r.db.Query(context.Background(), "SELECT "+ingredientColumns+" FROM ingredients WHERE id IN ("+JoinIds(ids)+")")
 	if err != nil {
 		return []Ingredient{}, &InternalError{err.Error()}
 	}
 	for results.Next() {
 		var i Ingredient
-		err = results.Scan(&i.Id, &i.Name, &i.Calories, &i.Protein, &i.Carbs, &i.Fat, &i.Amount, &i.Unit)
+		err = results.Scan(&i.Id, &i.Name, &i.Calories, &i.Protein, &i.Carbs, &i.Fat, &i.Amount, &i.Unit, &i.Allergens, &i.Diets)
 		if err != nil {
 			log.Println(err.Error())
 		}
@@ -127,6 +167,247 @@ func (r IngredientRepository) GetList(ids []int64) (ingredients []Ingredient, er
 	return ingredients, nil
 }
 
+// dependency describes a link table referencing the deleted table. Tables
+// referencing it directly are their own link table, with id as owner column.
+// Owned rows belong to the referenced row, like the prices of an ingredient,
+// and are deleted with it.
+type dependency struct {
+	table       string
+	linkTable   string
+	ownerColumn string
+	refColumn   string
+	owned       bool
+	merge       mergeRule
+}
+
+// mergeRule tells how replacing a row merges the link rows of an owner that
+// references both the deleted row and its replacement.
+type mergeRule int
+
+const (
+	// keepBoth points both link rows to the replacement, for lists that may
+	// repeat it like the entries of a meal plan.
+	keepBoth mergeRule = iota
+	// dropDuplicate deletes the link row of the deleted row, for sets like
+	// the recipes of a collection.
+	dropDuplicate
+	// addAmounts adds the amount of the link row of the deleted row to the
+	// first link row of the replacement, converting it to the unit of that
+	// row, and keeps both if the units can't be converted. The link table has
+	// amount, unit and index columns.
+	addAmounts
+)
+
+// deleteWithDependents deletes a row in a single transaction with its owned
+// rows and the rewrite of the link table rows referencing it, as requested
+// by opts. A Conflict lists every dependency with referencing rows.
+func deleteWithDependents(db *pgxpool.Pool, table string, id int64, opts DeleteOptions, deps ...dependency) error {
+	ctx := context.Background()
+	tx, err := db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return &InternalError{err.Error()}
+	}
+	err = rewriteDependents(tx, ctx, table, id, opts, deps)
+	if err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
+	result, err := tx.Exec(ctx, "DELETE FROM "+table+" WHERE id = $1", id)
+	if err != nil {
+		tx.Rollback(ctx)
+		return &InternalError{err.Error()}
+	}
+	rowCnt := result.RowsAffected()
+	if rowCnt != 1 {
+		tx.Rollback(ctx)
+		return &NotFound{table, id}
+	}
+	err = tx.Commit(ctx)
+	if err != nil {
+		return &InternalError{err.Error()}
+	}
+	return nil
+}
+
+func rewriteDependents(tx pgx.Tx, ctx context.Context, table string, id int64, opts DeleteOptions, deps []dependency) error {
+	conflict := &Conflict{Table: table, Id: id}
+	for _, dep := range deps {
+		var err error
+		switch {
+		case opts.ReplaceWith != 0 && !dep.owned:
+			err = replaceReferences(tx, ctx, dep, id, opts)
+		case opts.Cascade || dep.owned:
+			_, err = tx.Exec(ctx, "DELETE FROM "+dep.linkTable+" WHERE "+dep.refColumn+" = $1", id)
+		default:
+			var dependents []int64
+			dependents, err = queryIds(tx, ctx, "SELECT DISTINCT "+dep.ownerColumn+" FROM "+dep.linkTable+" WHERE "+dep.refColumn+" = $1 ORDER BY "+dep.ownerColumn, id)
+			if len(dependents) > 0 {
+				conflict.Dependents = append(conflict.Dependents, Dependents{dep.table, dependents})
+			}
+		}
+		if err != nil {
+			log.Println(err.Error())
+			return &InternalError{err.Error()}
+		}
+	}
+	if len(conflict.Dependents) > 0 {
+		return conflict
+	}
+	return nil
+}
+
+// replaceReferences points the link rows referencing id to opts.ReplaceWith,
+// merging them as dep.merge tells.
+func replaceReferences(tx pgx.Tx, ctx context.Context, dep dependency, id int64, opts DeleteOptions) error {
+	var err error
+	switch dep.merge {
+	case dropDuplicate:
+		_, err = tx.Exec(ctx, "DELETE FROM "+dep.linkTable+" WHERE "+dep.refColumn+" = $1 AND "+dep.ownerColumn+" IN (SELECT "+dep.ownerColumn+" FROM "+dep.linkTable+" WHERE "+dep.refColumn+" = $2)", id, opts.ReplaceWith)
+	case addAmounts:
+		err = addReplacedAmounts(tx, ctx, dep, id, opts)
+	}
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, "UPDATE "+dep.linkTable+" SET "+dep.refColumn+" = $1 WHERE "+dep.refColumn+" = $2", opts.ReplaceWith, id)
+	return err
+}
+
+// addReplacedAmounts adds the amounts of the link rows referencing id to the
+// link rows of the same owners referencing the replacement and deletes them.
+func addReplacedAmounts(tx pgx.Tx, ctx context.Context, dep dependency, id int64, opts DeleteOptions) error {
+	type linkRow struct {
+		owner  int64
+		amount float32
+		unit   string
+		index  int
+	}
+	query := func(ref int64, owners string) ([]linkRow, error) {
+		results, err := tx.Query(ctx, "SELECT "+dep.ownerColumn+", amount, unit, index FROM "+dep.linkTable+" WHERE "+dep.refColumn+" = $1"+owners+" ORDER BY "+dep.ownerColumn+", index", ref)
+		if err != nil {
+			return nil, err
+		}
+		defer results.Close()
+		var rows []linkRow
+		for results.Next() {
+			var row linkRow
+			err = results.Scan(&row.owner, &row.amount, &row.unit, &row.index)
+			if err != nil {
+				return nil, err
+			}
+			rows = append(rows, row)
+		}
+		return rows, results.Err()
+	}
+	replaced, err := query(id, "")
+	if err != nil || len(replaced) == 0 {
+		return err
+	}
+	owners := make([]int64, len(replaced))
+	for i, row := range replaced {
+		owners[i] = row.owner
+	}
+	replacements, err := query(opts.ReplaceWith, " AND "+dep.ownerColumn+" IN ("+JoinIds(owners)+")")
+	if err != nil {
+		return err
+	}
+	first := make(map[int64]linkRow)
+	for _, row := range replacements {
+		if _, ok := first[row.owner]; !ok {
+			first[row.owner] = row
+		}
+	}
+	for _, row := range replaced {
+		replacement, ok := first[row.owner]
+		if !ok {
+			continue
+		}
+		factor := float32(1)
+		if row.unit != replacement.unit {
+			if opts.ConvertUnit == nil {
+				continue
+			}
+			factor, err = opts.ConvertUnit(row.unit, replacement.unit)
+			if err != nil {
+				continue
+			}
+		}
+		_, err = tx.Exec(ctx, "UPDATE "+dep.linkTable+" SET amount = amount + $1 WHERE "+dep.ownerColumn+" = $2 AND index = $3", row.amount*factor, row.owner, replacement.index)
+		if err != nil {
+			return err
+		}
+		_, err = tx.Exec(ctx, "DELETE FROM "+dep.linkTable+" WHERE "+dep.ownerColumn+" = $1 AND index = $2", row.owner, row.index)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// queryIds runs a query selecting a single id column.
+func queryIds(tx pgx.Tx, ctx context.Context, query string, args ...interface{}) ([]int64, error) {
+	results, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer results.Close()
+	var ids []int64
+	for results.Next() {
+		var id int64
+		err = results.Scan(&id)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, results.Err()
+}
+
+// getExistingIds reports which of the given ids exist in the table.
+func getExistingIds(db *pgxpool.Pool, table string, ids []int64) (map[int64]bool, error) {
+	existing := make(map[int64]bool)
+	if len(ids) == 0 {
+		return existing, nil
+	}
+	results, err := db.Query(context.Background(), "SELECT id FROM "+table+" WHERE id IN ("+JoinIds(ids)+")")
+	if err != nil {
+		log.Println(err.Error())
+		return nil, &InternalError{err.Error()}
+	}
+	defer results.Close()
+	for results.Next() {
+		var id int64
+		err = results.Scan(&id)
+		if err != nil {
+			log.Println(err.Error())
+			return nil, &InternalError{err.Error()}
+		}
+		existing[id] = true
+	}
+	return existing, nil
+}
+
+// queryIdMap runs a query selecting (key, id) pairs and groups the ids by key.
+func queryIdMap(db *pgxpool.Pool, query string) (map[int64][]int64, error) {
+	ids := make(map[int64][]int64)
+	results, err := db.Query(context.Background(), query)
+	if err != nil {
+		log.Println(err.Error())
+		return nil, &InternalError{err.Error()}
+	}
+	defer results.Close()
+	for results.Next() {
+		var key, id int64
+		err = results.Scan(&key, &id)
+		if err != nil {
+			log.Println(err.Error())
+			return nil, &InternalError{err.Error()}
+		}
+		ids[key] = append(ids[key], id)
+	}
+	return ids, nil
+}
+
 func JoinIds(ids []int64) string {
 	if len(ids) == 0 {
 		return ""
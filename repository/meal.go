@@ -1,7 +1,10 @@
 package repository
 
+// Meal lists its recipes with the servings of each in a portion of the meal,
+// 0 for the whole recipe.
 type Meal struct {
-	Id      int64
-	Name    string
-	Recipes []int64
+	Id       int64
+	Name     string
+	Recipes  []int64
+	Servings []float32
 }
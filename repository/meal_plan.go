@@ -6,5 +6,41 @@ type MealPlan struct {
 	Id        int64
 	Name      string
 	StartDate time.Time
-	Meals     [][]int64
+	Followed  bool
+	Entries   []MealPlanEntry
+}
+
+// Days returns the number of days covered by the meal plan entries.
+func (mealPlan MealPlan) Days() int64 {
+	var days int64
+	for _, entry := range mealPlan.Entries {
+		if entry.Day+1 > days {
+			days = entry.Day + 1
+		}
+	}
+	return days
+}
+
+// MealPlanEntry is a meal planned for a slot of a day, Day counts the days
+// since the start date of the meal plan. Entries with the same Batch above 0
+// eat Portions of one cooked batch. People is the number of people eating,
+// Members the household members among them.
+type MealPlanEntry struct {
+	MealId   int64
+	Day      int64
+	Slot     string
+	Servings float32
+	Cooked   bool
+	Batch    int
+	Portions float32
+	People   int
+	Members  []int64
+}
+
+// CalendarEntry is a meal plan entry placed on its calendar date.
+type CalendarEntry struct {
+	MealPlanId   int64
+	MealPlanName string
+	Date         time.Time
+	MealPlanEntry
 }
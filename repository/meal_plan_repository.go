@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"log"
+	"time"
 
 	"github.com/jackc/pgx/v4"
 	"github.com/jackc/pgx/v4/pgxpool"
@@ -18,111 +19,149 @@ func NewMealPlanRepository(dbConn *pgxpool.Pool) *MealPlanRepository {
 	return r
 }
 
-func (r MealPlanRepository) Get(id int64) (mealPlan MealPlan, e error) {
-	err := r.db.QueryRow(context.Background(), "SELECT * FROM meal_plans WHERE id = $1", id).Scan(&mealPlan.Id, &mealPlan.Name, &mealPlan.StartDate)
+const mealPlanColumns = "id, name, start_date, followed"
+
+func (r MealPlanRepository) Get(id int64) (MealPlan, error) {
+	var mealPlan MealPlan
+	err := r.db.QueryRow(context.Background(), "SELECT "+mealPlanColumns+" FROM meal_plans WHERE id = $1", id).Scan(&mealPlan.Id, &mealPlan.Name, &mealPlan.StartDate, &mealPlan.Followed)
 	if err != nil {
 		log.Println(err.Error())
 		switch err {
 		case pgx.ErrNoRows:
-			e = &NotFound{"meal_plans", id}
+			return MealPlan{}, &NotFound{"meal_plans", id}
 		default:
-			e = &InternalError{err.Error()}
+			return MealPlan{}, &InternalError{err.Error()}
 		}
 	}
-	meals, e := r.getMealPlanMeals([]int64{id})
-	var ok bool
-	if mealPlan.Meals, ok = meals[id]; !ok {
-		mealPlan.Meals = [][]int64{}
+	entries, err := r.getMealPlanEntriesByIds([]int64{id})
+	if err != nil {
+		return MealPlan{}, err
 	}
-	return
+	mealPlan.Entries = entries[id]
+	return mealPlan, nil
 }
 
 func (r MealPlanRepository) GetAll() (mealPlans []MealPlan, e error) {
-	results, err := r.db.Query(context.Background(), "SELECT * FROM meal_plans")
+	results, err := r.db.Query(context.Background(), "SELECT "+mealPlanColumns+" FROM meal_plans")
 	if err != nil {
 		return []MealPlan{}, &InternalError{err.Error()}
 	}
-	meals, err := r.getAllMealPlanMeals()
+	entries, err := r.getAllMealPlanEntries()
+	if err != nil {
+		return []MealPlan{}, err
+	}
+	return r.parseMealPlanRows(results, entries), nil
+}
+
+func (r MealPlanRepository) GetList(ids []int64) (mealPlans []MealPlan, e error) {
+	results, err := r.db.Query(context.Background(), "SELECT "+mealPlanColumns+" FROM meal_plans WHERE id IN ("+JoinIds(ids)+")")
 	if err != nil {
 		return []MealPlan{}, &InternalError{err.Error()}
 	}
-	for results.Next() {
-		var mealPlan MealPlan
-		err = results.Scan(&mealPlan.Id, &mealPlan.Name, &mealPlan.StartDate)
-		if err != nil {
-			log.Println(err.Error())
-		}
-		var ok bool
-		if mealPlan.Meals, ok = meals[mealPlan.Id]; !ok {
-			mealPlan.Meals = [][]int64{}
-		}
-		mealPlans = append(mealPlans, mealPlan)
+	entries, err := r.getMealPlanEntriesByIds(ids)
+	if err != nil {
+		return []MealPlan{}, err
+	}
+	return r.parseMealPlanRows(results, entries), nil
+}
+
+// GetIdsByMeals returns the ids of the meal plans containing each of the given meals.
+func (r MealPlanRepository) GetIdsByMeals(mealIds []int64) (map[int64][]int64, error) {
+	if len(mealIds) == 0 {
+		return map[int64][]int64{}, nil
 	}
-	return mealPlans, nil
+	return queryIdMap(r.db, "SELECT DISTINCT meal_id, meal_plan_id FROM meal_plan_meals WHERE meal_id IN ("+JoinIds(mealIds)+") ORDER BY meal_plan_id")
 }
 
-func (r MealPlanRepository) getMealPlanMeals(ids []int64) (meals map[int64][][]int64, err error) {
-	meals = make(map[int64][][]int64)
-	results, err := r.db.Query(context.Background(), "SELECT meal_plan_id, meal_id, day FROM meal_plan_meals WHERE meal_plan_id IN ("+JoinIds(ids)+") ORDER BY meal_plan_meals.day DESC, meal_plan_meals.index")
+// GetCalendar returns the entries of all followed meal plans falling between
+// from and to, both inclusive, ordered by date.
+func (r MealPlanRepository) GetCalendar(from, to time.Time) ([]CalendarEntry, error) {
+	results, err := r.db.Query(context.Background(), `SELECT meal_plans.id, meal_plans.name, meal_plans.start_date::date + meal_plan_meals.day::int AS date, meal_plan_meals.meal_id, meal_plan_meals.day, meal_plan_meals.slot, meal_plan_meals.servings, meal_plan_meals.cooked
+		FROM meal_plan_meals JOIN meal_plans ON meal_plans.id = meal_plan_meals.meal_plan_id
+		WHERE meal_plans.followed AND meal_plans.start_date::date + meal_plan_meals.day::int BETWEEN $1::date AND $2::date
+		ORDER BY date, meal_plans.id, meal_plan_meals.index`, from, to)
 	if err != nil {
+		log.Println(err.Error())
 		return nil, &InternalError{err.Error()}
 	}
+	defer results.Close()
+	var entries []CalendarEntry
 	for results.Next() {
-		var mealPlanId, mealId, day int64
-		err = results.Scan(&mealPlanId, &mealId, &day)
+		var entry CalendarEntry
+		err = results.Scan(&entry.MealPlanId, &entry.MealPlanName, &entry.Date, &entry.MealId, &entry.Day, &entry.Slot, &entry.Servings, &entry.Cooked)
 		if err != nil {
 			log.Println(err.Error())
+			return nil, &InternalError{err.Error()}
 		}
-		if _, ok := meals[mealPlanId]; !ok {
-			meals[mealPlanId] = make([][]int64, day+1)
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+func (r MealPlanRepository) parseMealPlanRows(rows pgx.Rows, entries map[int64][]MealPlanEntry) (mealPlans []MealPlan) {
+	for rows.Next() {
+		var mealPlan MealPlan
+		err := rows.Scan(&mealPlan.Id, &mealPlan.Name, &mealPlan.StartDate, &mealPlan.Followed)
+		if err != nil {
+			log.Println(err.Error())
 		}
-		meals[mealPlanId][day] = append(meals[mealPlanId][day], mealId)
+		mealPlan.Entries = entries[mealPlan.Id]
+		mealPlans = append(mealPlans, mealPlan)
 	}
-	return meals, nil
+	return mealPlans
 }
 
-func (r MealPlanRepository) getAllMealPlanMeals() (meals map[int64][][]int64, err error) {
-	meals = make(map[int64][][]int64)
-	results, err := r.db.Query(context.Background(), "SELECT meal_plan_id, meal_id, day FROM meal_plan_meals ORDER BY meal_plan_meals.day DESC, meal_plan_meals.index")
+func (r MealPlanRepository) getMealPlanEntriesByIds(ids []int64) (map[int64][]MealPlanEntry, error) {
+	return r.getMealPlanEntries("SELECT meal_plan_id, meal_id, day, slot, servings, cooked, batch, portions, people, members FROM meal_plan_meals WHERE meal_plan_id IN (" + JoinIds(ids) + ") ORDER BY meal_plan_id, meal_plan_meals.day, meal_plan_meals.index")
+}
+
+func (r MealPlanRepository) getAllMealPlanEntries() (map[int64][]MealPlanEntry, error) {
+	return r.getMealPlanEntries("SELECT meal_plan_id, meal_id, day, slot, servings, cooked, batch, portions, people, members FROM meal_plan_meals ORDER BY meal_plan_id, meal_plan_meals.day, meal_plan_meals.index")
+}
+
+func (r MealPlanRepository) getMealPlanEntries(query string) (map[int64][]MealPlanEntry, error) {
+	entries := make(map[int64][]MealPlanEntry)
+	results, err := r.db.Query(context.Background(), query)
 	if err != nil {
+		log.Println(err.Error())
 		return nil, &InternalError{err.Error()}
 	}
+	defer results.Close()
 	for results.Next() {
-		var mealPlanId, mealId, day int64
-		err = results.Scan(&mealPlanId, &mealId, &day)
+		var mealPlanId int64
+		var entry MealPlanEntry
+		err = results.Scan(&mealPlanId, &entry.MealId, &entry.Day, &entry.Slot, &entry.Servings, &entry.Cooked, &entry.Batch, &entry.Portions, &entry.People, &entry.Members)
 		if err != nil {
 			log.Println(err.Error())
+			return nil, &InternalError{err.Error()}
 		}
-		if _, ok := meals[mealPlanId]; !ok {
-			meals[mealPlanId] = make([][]int64, day+1)
-		}
-		meals[mealPlanId][day] = append(meals[mealPlanId][day], mealId)
+		entries[mealPlanId] = append(entries[mealPlanId], entry)
 	}
-	return meals, nil
+	return entries, nil
 }
 
-func (r MealPlanRepository) Create(mealPlan MealPlan) error {
+func (r MealPlanRepository) Create(mealPlan MealPlan) (int64, error) {
 	ctx := context.Background()
 	tx, err := r.db.BeginTx(ctx, pgx.TxOptions{})
 	if err != nil {
-		return err
+		return 0, err
 	}
-	err = tx.QueryRow(ctx, "INSERT INTO meal_plans (name, start_date, days) VALUES ($1, $2, $3) RETURNING id", mealPlan.Name, mealPlan.StartDate, len(mealPlan.Meals)).Scan(&mealPlan.Id)
+	err = tx.QueryRow(ctx, "INSERT INTO meal_plans (name, start_date, days, followed) VALUES ($1, $2, $3, $4) RETURNING id", mealPlan.Name, mealPlan.StartDate, mealPlan.Days(), mealPlan.Followed).Scan(&mealPlan.Id)
 	if err != nil {
 		log.Println(err.Error())
 		tx.Rollback(ctx)
-		return &InternalError{err.Error()}
+		return 0, &InternalError{err.Error()}
 	}
 	err = r.createMealPlanMeals(tx, ctx, mealPlan)
 	if err != nil {
 		tx.Rollback(ctx)
-		return &InternalError{err.Error()}
+		return 0, &InternalError{err.Error()}
 	}
 	err = tx.Commit(ctx)
 	if err != nil {
-		return &InternalError{err.Error()}
+		return 0, &InternalError{err.Error()}
 	}
-	return nil
+	return mealPlan.Id, nil
 }
 
 func (r MealPlanRepository) Update(mealPlan MealPlan) error {
@@ -136,7 +175,7 @@ func (r MealPlanRepository) Update(mealPlan MealPlan) error {
 		tx.Rollback(ctx)
 		return err
 	}
-	result, err := r.db.Exec(ctx, "UPDATE meal_plans SET name = $1, start_date = $2, days = $3 WHERE id = $4", mealPlan.Name, mealPlan.StartDate, len(mealPlan.Meals), mealPlan.Id)
+	result, err := tx.Exec(ctx, "UPDATE meal_plans SET name = $1, start_date = $2, days = $3, followed = $4 WHERE id = $5", mealPlan.Name, mealPlan.StartDate, mealPlan.Days(), mealPlan.Followed, mealPlan.Id)
 	if err != nil {
 		log.Println(err.Error())
 		tx.Rollback(ctx)
@@ -165,18 +204,15 @@ func (r MealPlanRepository) Update(mealPlan MealPlan) error {
 }
 
 func (r MealPlanRepository) createMealPlanMeals(tx pgx.Tx, ctx context.Context, mealPlan MealPlan) error {
-	for day, dayMeals := range mealPlan.Meals {
-		for index, mealId := range dayMeals {
-			result, err := tx.Exec(ctx, "INSERT INTO meal_plan_meals (meal_plan_id, meal_id, day, index) VALUES ($1, $2, $3, $4)", mealPlan.Id, mealId, day, index)
-			if err != nil {
-				log.Println(err.Error())
-				return &InternalError{err.Error()}
-			}
-			rowCnt := result.RowsAffected()
-			if rowCnt != 1 {
-				log.Println(err.Error())
-				return &InternalError{"meal plan meal not created"}
-			}
+	for index, entry := range mealPlan.Entries {
+		result, err := tx.Exec(ctx, "INSERT INTO meal_plan_meals (meal_plan_id, meal_id, day, slot, servings, cooked, batch, portions, people, members, index) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)", mealPlan.Id, entry.MealId, entry.Day, entry.Slot, entry.Servings, entry.Cooked, entry.Batch, entry.Portions, entry.People, entry.Members, index)
+		if err != nil {
+			log.Println(err.Error())
+			return &InternalError{err.Error()}
+		}
+		rowCnt := result.RowsAffected()
+		if rowCnt != 1 {
+			return &InternalError{"meal plan meal not created"}
 		}
 	}
 	return nil
@@ -194,6 +230,91 @@ func (r MealPlanRepository) Delete(id int64) error {
 	return nil
 }
 
+// MarkCooked marks the entry at index of the meal plan as cooked and takes the
+// deducted amounts, keyed by pantry item id, out of the pantry in a single
+// transaction. Pantry items left empty are removed. It returns NotFound if the
+// meal plan has no entry at index and Conflict if the entry was cooked already.
+func (r MealPlanRepository) MarkCooked(mealPlanId int64, index int, deductions map[int64]float32) error {
+	ctx := context.Background()
+	tx, err := r.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return &InternalError{err.Error()}
+	}
+	result, err := tx.Exec(ctx, "UPDATE meal_plan_meals SET cooked = true WHERE meal_plan_id = $1 AND index = $2 AND NOT cooked", mealPlanId, index)
+	if err != nil {
+		log.Println(err.Error())
+		tx.Rollback(ctx)
+		return &InternalError{err.Error()}
+	}
+	if result.RowsAffected() != 1 {
+		var cooked bool
+		err = tx.QueryRow(ctx, "SELECT cooked FROM meal_plan_meals WHERE meal_plan_id = $1 AND index = $2", mealPlanId, index).Scan(&cooked)
+		tx.Rollback(ctx)
+		switch {
+		case err == pgx.ErrNoRows:
+			return &NotFound{"meal_plan_meals", mealPlanId}
+		case err != nil:
+			log.Println(err.Error())
+			return &InternalError{err.Error()}
+		default:
+			return &Conflict{Table: "meal_plan_meals", Id: mealPlanId}
+		}
+	}
+	var ids []int64
+	for id, amount := range deductions {
+		_, err = tx.Exec(ctx, "UPDATE pantry_items SET amount = amount - $1 WHERE id = $2", amount, id)
+		if err != nil {
+			log.Println(err.Error())
+			tx.Rollback(ctx)
+			return &InternalError{err.Error()}
+		}
+		ids = append(ids, id)
+	}
+	if len(ids) > 0 {
+		_, err = tx.Exec(ctx, "DELETE FROM pantry_items WHERE amount <= 0 AND id IN ("+JoinIds(ids)+")")
+		if err != nil {
+			log.Println(err.Error())
+			tx.Rollback(ctx)
+			return &InternalError{err.Error()}
+		}
+	}
+	err = tx.Commit(ctx)
+	if err != nil {
+		return &InternalError{err.Error()}
+	}
+	return nil
+}
+
+// SetFeedToken sets the token of the meal plan's calendar feed, an empty
+// token disables the feed.
+func (r MealPlanRepository) SetFeedToken(id int64, token string) error {
+	result, err := r.db.Exec(context.Background(), "UPDATE meal_plans SET feed_token = NULLIF($1, '') WHERE id = $2", token, id)
+	if err != nil {
+		log.Println(err.Error())
+		return &InternalError{err.Error()}
+	}
+	rowCnt := result.RowsAffected()
+	if rowCnt != 1 {
+		return &NotFound{"meal_plans", id}
+	}
+	return nil
+}
+
+// GetIdByFeedToken returns the id of the meal plan whose calendar feed uses the token.
+func (r MealPlanRepository) GetIdByFeedToken(token string) (id int64, e error) {
+	err := r.db.QueryRow(context.Background(), "SELECT id FROM meal_plans WHERE feed_token = $1", token).Scan(&id)
+	if err != nil {
+		switch err {
+		case pgx.ErrNoRows:
+			return 0, &NotFound{"meal_plans", 0}
+		default:
+			log.Println(err.Error())
+			return 0, &InternalError{err.Error()}
+		}
+	}
+	return id, nil
+}
+
 func (r MealPlanRepository) deleteMealPlanMeals(tx pgx.Tx, ctx context.Context, mealPlanId int64) error {
 	_, err := tx.Exec(ctx, "DELETE FROM meal_plan_meals WHERE meal_plan_id = $1", mealPlanId)
 	if err != nil {
@@ -201,3 +322,109 @@ func (r MealPlanRepository) deleteMealPlanMeals(tx pgx.Tx, ctx context.Context,
 	}
 	return nil
 }
+
+// Clone copies the meal plan and its entries to a new meal plan with the
+// name and start date, in a single transaction. The copy isn't followed and
+// none of its entries are cooked.
+func (r MealPlanRepository) Clone(id int64, name string, startDate time.Time) (int64, error) {
+	ctx := context.Background()
+	tx, err := r.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return 0, &InternalError{err.Error()}
+	}
+	var cloneId int64
+	err = tx.QueryRow(ctx, "INSERT INTO meal_plans (name, start_date, days, followed) SELECT $2, $3, days, false FROM meal_plans WHERE id = $1 RETURNING id", id, name, startDate).Scan(&cloneId)
+	if err != nil {
+		tx.Rollback(ctx)
+		switch err {
+		case pgx.ErrNoRows:
+			return 0, &NotFound{"meal_plans", id}
+		default:
+			log.Println(err.Error())
+			return 0, &InternalError{err.Error()}
+		}
+	}
+	_, err = tx.Exec(ctx, `INSERT INTO meal_plan_meals (meal_plan_id, meal_id, day, slot, servings, cooked, batch, portions, people, members, index)
+		SELECT $2, meal_id, day, slot, servings, false, batch, portions, people, members, index FROM meal_plan_meals WHERE meal_plan_id = $1`, id, cloneId)
+	if err != nil {
+		log.Println(err.Error())
+		tx.Rollback(ctx)
+		return 0, &InternalError{err.Error()}
+	}
+	err = tx.Commit(ctx)
+	if err != nil {
+		return 0, &InternalError{err.Error()}
+	}
+	return cloneId, nil
+}
+
+// Repeat appends times copies of the entries of the meal plan, each copy
+// starting period days after the previous one, in a single transaction. The
+// period is at least the number of days of the meal plan so the copies follow
+// the entries in order. The copied entries aren't cooked.
+func (r MealPlanRepository) Repeat(id int64, times, period int) error {
+	ctx := context.Background()
+	tx, err := r.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return &InternalError{err.Error()}
+	}
+	// Locking the meal plan keeps its entries from changing until the copies
+	// are in.
+	var days int
+	err = tx.QueryRow(ctx, "SELECT days FROM meal_plans WHERE id = $1 FOR UPDATE", id).Scan(&days)
+	if err != nil {
+		tx.Rollback(ctx)
+		switch err {
+		case pgx.ErrNoRows:
+			return &NotFound{"meal_plans", id}
+		default:
+			log.Println(err.Error())
+			return &InternalError{err.Error()}
+		}
+	}
+	if period < days {
+		period = days
+	}
+	// Each copy of a batch is a batch of its own, numbered after the
+	// batches of the previous copy.
+	var entries, batches int
+	err = tx.QueryRow(ctx, "SELECT count(*), coalesce(max(batch), 0) FROM meal_plan_meals WHERE meal_plan_id = $1", id).Scan(&entries, &batches)
+	if err != nil {
+		log.Println(err.Error())
+		tx.Rollback(ctx)
+		return &InternalError{err.Error()}
+	}
+	_, err = tx.Exec(ctx, `INSERT INTO meal_plan_meals (meal_plan_id, meal_id, day, slot, servings, cooked, batch, portions, people, members, index)
+		SELECT meal_plan_id, meal_id, day + $2 * copy, slot, servings, false, CASE WHEN batch > 0 THEN batch + $5 * copy ELSE 0 END, portions, people, members, index + $3 * copy
+		FROM meal_plan_meals, generate_series(1, $4::int) AS copy WHERE meal_plan_id = $1`, id, period, entries, times, batches)
+	if err != nil {
+		log.Println(err.Error())
+		tx.Rollback(ctx)
+		return &InternalError{err.Error()}
+	}
+	_, err = tx.Exec(ctx, "UPDATE meal_plans SET days = (SELECT coalesce(max(day) + 1, 0) FROM meal_plan_meals WHERE meal_plan_id = $1) WHERE id = $1", id)
+	if err != nil {
+		log.Println(err.Error())
+		tx.Rollback(ctx)
+		return &InternalError{err.Error()}
+	}
+	err = tx.Commit(ctx)
+	if err != nil {
+		return &InternalError{err.Error()}
+	}
+	return nil
+}
+
+// Shift moves every day of the meal plan by the number of days, earlier if
+// negative, by moving its start date.
+func (r MealPlanRepository) Shift(id int64, days int) error {
+	result, err := r.db.Exec(context.Background(), "UPDATE meal_plans SET start_date = start_date + make_interval(days => $1) WHERE id = $2", days, id)
+	if err != nil {
+		log.Println(err.Error())
+		return &InternalError{err.Error()}
+	}
+	if result.RowsAffected() != 1 {
+		return &NotFound{"meal_plans", id}
+	}
+	return nil
+}
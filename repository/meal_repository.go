@@ -33,7 +33,8 @@ func (r MealRepository) Get(id int64) (meal Meal, e error) {
 	if err != nil {
 		return Meal{}, err
 	}
-	meal.Recipes = recipes[id]
+	meal.Recipes = recipes[id].Recipes
+	meal.Servings = recipes[id].Servings
 	return meal, nil
 }
 
@@ -61,14 +62,23 @@ func (r MealRepository) GetList(ids []int64) (meals []Meal, e error) {
 	return r.parseMealRows(results, mealRecipes), nil
 }
 
-func (r MealRepository) parseMealRows(rows pgx.Rows, mealRecipes map[int64][]int64) (meals []Meal) {
+// GetIdsByRecipes returns the ids of the meals containing each of the given recipes.
+func (r MealRepository) GetIdsByRecipes(recipeIds []int64) (map[int64][]int64, error) {
+	if len(recipeIds) == 0 {
+		return map[int64][]int64{}, nil
+	}
+	return queryIdMap(r.db, "SELECT DISTINCT recipe_id, meal_id FROM meal_recipes WHERE recipe_id IN ("+JoinIds(recipeIds)+") ORDER BY meal_id")
+}
+
+func (r MealRepository) parseMealRows(rows pgx.Rows, mealRecipes map[int64]Meal) (meals []Meal) {
 	for rows.Next() {
 		var meal Meal
 		err := rows.Scan(&meal.Id, &meal.Name)
 		if err != nil {
 			log.Println(err.Error())
 		}
-		meal.Recipes = mealRecipes[meal.Id]
+		meal.Recipes = mealRecipes[meal.Id].Recipes
+		meal.Servings = mealRecipes[meal.Id].Servings
 		if err != nil {
 			log.Println(err.Error())
 		}
@@ -77,56 +87,58 @@ func (r MealRepository) parseMealRows(rows pgx.Rows, mealRecipes map[int64][]int
 	return meals
 }
 
-func (r MealRepository) getMealRecipesByIds(ids []int64) (recipes map[int64][]int64, err error) {
-	return r.getMealRecipes("SELECT meal_id, recipe_id FROM meal_recipes WHERE meal_id IN (" + JoinIds(ids) + ") ORDER BY recipe_id, meal_recipes.index")
+func (r MealRepository) getMealRecipesByIds(ids []int64) (recipes map[int64]Meal, err error) {
+	return r.getMealRecipes("SELECT meal_id, recipe_id, servings FROM meal_recipes WHERE meal_id IN (" + JoinIds(ids) + ") ORDER BY recipe_id, meal_recipes.index")
 }
 
-func (r MealRepository) getAllMealRecipes() (recipes map[int64][]int64, err error) {
-	return r.getMealRecipes("SELECT meal_id, recipe_id FROM meal_recipes ORDER BY recipe_id, meal_recipes.index")
+func (r MealRepository) getAllMealRecipes() (recipes map[int64]Meal, err error) {
+	return r.getMealRecipes("SELECT meal_id, recipe_id, servings FROM meal_recipes ORDER BY recipe_id, meal_recipes.index")
 }
 
-func (r MealRepository) getMealRecipes(query string) (map[int64][]int64, error) {
-	recipes := make(map[int64][]int64)
+// getMealRecipes returns the recipes and their servings by meal id.
+func (r MealRepository) getMealRecipes(query string) (map[int64]Meal, error) {
+	recipes := make(map[int64]Meal)
 	results, err := r.db.Query(context.Background(), query)
 	if err != nil {
 		return nil, &InternalError{err.Error()}
 	}
 	for results.Next() {
 		var mealId, recipeId int64
-		err = results.Scan(&mealId, &recipeId)
+		var servings float32
+		err = results.Scan(&mealId, &recipeId, &servings)
 		if err != nil {
 			log.Println(err.Error())
 		}
-		if _, ok := recipes[mealId]; !ok {
-			recipes[mealId] = make([]int64, 0)
-		}
-		recipes[mealId] = append(recipes[mealId], recipeId)
+		meal := recipes[mealId]
+		meal.Recipes = append(meal.Recipes, recipeId)
+		meal.Servings = append(meal.Servings, servings)
+		recipes[mealId] = meal
 	}
 	return recipes, nil
 }
 
-func (r MealRepository) Create(meal Meal) error {
+func (r MealRepository) Create(meal Meal) (int64, error) {
 	ctx := context.Background()
 	tx, err := r.db.BeginTx(ctx, pgx.TxOptions{})
 	if err != nil {
-		return err
+		return 0, err
 	}
 	err = tx.QueryRow(ctx, "INSERT INTO meals (name) VALUES ($1) RETURNING id", meal.Name).Scan(&meal.Id)
 	if err != nil {
 		log.Println(err.Error())
 		tx.Rollback(ctx)
-		return &InternalError{err.Error()}
+		return 0, &InternalError{err.Error()}
 	}
 	err = r.createMealRecipes(tx, ctx, meal)
 	if err != nil {
 		tx.Rollback(ctx)
-		return &InternalError{err.Error()}
+		return 0, &InternalError{err.Error()}
 	}
 	err = tx.Commit(ctx)
 	if err != nil {
-		return &InternalError{err.Error()}
+		return 0, &InternalError{err.Error()}
 	}
-	return nil
+	return meal.Id, nil
 }
 
 func (r MealRepository) Update(meal Meal) error {
@@ -170,7 +182,11 @@ func (r MealRepository) Update(meal Meal) error {
 
 func (r MealRepository) createMealRecipes(tx pgx.Tx, ctx context.Context, meal Meal) error {
 	for index, recipeId := range meal.Recipes {
-		result, err := tx.Exec(ctx, "INSERT INTO meal_recipes (meal_id, recipe_id, index) VALUES ($1, $2, $3)", meal.Id, recipeId, index)
+		var servings float32
+		if index < len(meal.Servings) {
+			servings = meal.Servings[index]
+		}
+		result, err := tx.Exec(ctx, "INSERT INTO meal_recipes (meal_id, recipe_id, servings, index) VALUES ($1, $2, $3, $4)", meal.Id, recipeId, servings, index)
 		if err != nil {
 			log.Println(err.Error())
 			return &InternalError{err.Error()}
@@ -192,14 +208,43 @@ func (r MealRepository) deleteMealRecipes(tx pgx.Tx, ctx context.Context, mealId
 	return nil
 }
 
-func (r MealRepository) Delete(id int64) error {
-	result, err := r.db.Exec(context.Background(), "DELETE FROM meals WHERE id = $1", id)
+func (r MealRepository) Delete(id int64, opts DeleteOptions) error {
+	return deleteWithDependents(r.db, "meals", id, opts, dependency{"meal_plans", "meal_plan_meals", "meal_plan_id", "meal_id", false, keepBoth})
+}
+
+func (r MealRepository) GetExistingIds(ids []int64) (map[int64]bool, error) {
+	return getExistingIds(r.db, "meals", ids)
+}
+
+// Clone copies the meal with its recipes to a new meal with the name, in a
+// single transaction.
+func (r MealRepository) Clone(id int64, name string) (int64, error) {
+	ctx := context.Background()
+	tx, err := r.db.BeginTx(ctx, pgx.TxOptions{})
 	if err != nil {
-		return &InternalError{err.Error()}
+		return 0, &InternalError{err.Error()}
 	}
-	rowCnt := result.RowsAffected()
-	if rowCnt != 1 {
-		return &NotFound{"meals", id}
+	var cloneId int64
+	err = tx.QueryRow(ctx, "INSERT INTO meals (name) SELECT $2 FROM meals WHERE id = $1 RETURNING id", id, name).Scan(&cloneId)
+	if err != nil {
+		tx.Rollback(ctx)
+		switch err {
+		case pgx.ErrNoRows:
+			return 0, &NotFound{"meals", id}
+		default:
+			log.Println(err.Error())
+			return 0, &InternalError{err.Error()}
+		}
 	}
-	return nil
+	_, err = tx.Exec(ctx, "INSERT INTO meal_recipes (meal_id, recipe_id, servings, index) SELECT $2, recipe_id, servings, index FROM meal_recipes WHERE meal_id = $1", id, cloneId)
+	if err != nil {
+		log.Println(err.Error())
+		tx.Rollback(ctx)
+		return 0, &InternalError{err.Error()}
+	}
+	err = tx.Commit(ctx)
+	if err != nil {
+		return 0, &InternalError{err.Error()}
+	}
+	return cloneId, nil
 }
@@ -0,0 +1,21 @@
+package repository
+
+import "time"
+
+// Member is a person of the household. Weight is in kilograms, height in
+// centimetres. Targets of 0 are computed from the profile.
+type Member struct {
+	Id             int64
+	Name           string
+	BirthDate      time.Time
+	Sex            string
+	Weight         float32
+	Height         float32
+	ActivityLevel  string
+	Goal           string
+	TargetCalories float32
+	TargetProtein  float32
+	TargetCarbs    float32
+	TargetFat      float32
+	Allergies      []string
+}
@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"context"
+	"log"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type MemberRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewMemberRepository(dbConn *pgxpool.Pool) *MemberRepository {
+	r := new(MemberRepository)
+	r.db = dbConn
+	return r
+}
+
+const memberColumns = "id, name, birth_date, sex, weight, height, activity_level, goal, target_calories, target_protein, target_carbs, target_fat, allergies"
+
+func (r MemberRepository) Get(id int64) (member Member, e error) {
+	row := r.db.QueryRow(context.Background(), "SELECT "+memberColumns+" FROM members WHERE id = $1", id)
+	err := scanMember(row, &member)
+	if err != nil {
+		log.Println(err.Error())
+		switch err {
+		case pgx.ErrNoRows:
+			e = &NotFound{"members", id}
+		default:
+			e = &InternalError{err.Error()}
+		}
+	}
+	return
+}
+
+func (r MemberRepository) GetAll() ([]Member, error) {
+	return r.getMembers("SELECT " + memberColumns + " FROM members ORDER BY name, id")
+}
+
+func (r MemberRepository) GetList(ids []int64) ([]Member, error) {
+	if len(ids) == 0 {
+		return []Member{}, nil
+	}
+	return r.getMembers("SELECT " + memberColumns + " FROM members WHERE id IN (" + JoinIds(ids) + ") ORDER BY name, id")
+}
+
+func (r MemberRepository) getMembers(query string) ([]Member, error) {
+	results, err := r.db.Query(context.Background(), query)
+	if err != nil {
+		log.Println(err.Error())
+		return []Member{}, &InternalError{err.Error()}
+	}
+	defer results.Close()
+	var members []Member
+	for results.Next() {
+		var member Member
+		err = scanMember(results, &member)
+		if err != nil {
+			log.Println(err.Error())
+		}
+		members = append(members, member)
+	}
+	return members, nil
+}
+
+func scanMember(row pgx.Row, member *Member) error {
+	return row.Scan(&member.Id, &member.Name, &member.BirthDate, &member.Sex, &member.Weight, &member.Height, &member.ActivityLevel, &member.Goal,
+		&member.TargetCalories, &member.TargetProtein, &member.TargetCarbs, &member.TargetFat, &member.Allergies)
+}
+
+func (r MemberRepository) Create(member Member) (int64, error) {
+	err := r.db.QueryRow(context.Background(), `INSERT INTO members (name, birth_date, sex, weight, height, activity_level, goal, target_calories, target_protein, target_carbs, target_fat, allergies)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12) RETURNING id`,
+		member.Name, member.BirthDate, member.Sex, member.Weight, member.Height, member.ActivityLevel, member.Goal,
+		member.TargetCalories, member.TargetProtein, member.TargetCarbs, member.TargetFat, member.Allergies).Scan(&member.Id)
+	if err != nil {
+		log.Println(err.Error())
+		return 0, &InternalError{err.Error()}
+	}
+	return member.Id, nil
+}
+
+func (r MemberRepository) Update(member Member) error {
+	result, err := r.db.Exec(context.Background(), `UPDATE members SET name = $1, birth_date = $2, sex = $3, weight = $4, height = $5, activity_level = $6, goal = $7,
+		target_calories = $8, target_protein = $9, target_carbs = $10, target_fat = $11, allergies = $12 WHERE id = $13`,
+		member.Name, member.BirthDate, member.Sex, member.Weight, member.Height, member.ActivityLevel, member.Goal,
+		member.TargetCalories, member.TargetProtein, member.TargetCarbs, member.TargetFat, member.Allergies, member.Id)
+	if err != nil {
+		log.Println(err.Error())
+		return &InternalError{err.Error()}
+	}
+	rowCnt := result.RowsAffected()
+	if rowCnt != 1 {
+		return &NotFound{"members", member.Id}
+	}
+	return nil
+}
+
+// Delete removes the member and takes it off the meal plan entries it was
+// assigned to, in a single transaction.
+func (r MemberRepository) Delete(id int64) error {
+	ctx := context.Background()
+	tx, err := r.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return &InternalError{err.Error()}
+	}
+	_, err = tx.Exec(ctx, "UPDATE meal_plan_meals SET members = array_remove(members, $1) WHERE $1 = ANY(members)", id)
+	if err != nil {
+		log.Println(err.Error())
+		tx.Rollback(ctx)
+		return &InternalError{err.Error()}
+	}
+	result, err := tx.Exec(ctx, "DELETE FROM members WHERE id = $1", id)
+	if err != nil {
+		tx.Rollback(ctx)
+		return &InternalError{err.Error()}
+	}
+	if result.RowsAffected() != 1 {
+		tx.Rollback(ctx)
+		return &NotFound{"members", id}
+	}
+	err = tx.Commit(ctx)
+	if err != nil {
+		return &InternalError{err.Error()}
+	}
+	return nil
+}
+
+func (r MemberRepository) GetExistingIds(ids []int64) (map[int64]bool, error) {
+	return getExistingIds(r.db, "members", ids)
+}
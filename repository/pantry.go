@@ -0,0 +1,12 @@
+package repository
+
+import "time"
+
+type PantryItem struct {
+	Id           int64
+	IngredientId int64
+	Amount       float32
+	Unit         string
+	ExpiresOn    *time.Time
+	Location     string
+}
@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"context"
+	"log"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type PantryRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewPantryRepository(dbConn *pgxpool.Pool) *PantryRepository {
+	r := new(PantryRepository)
+	r.db = dbConn
+	return r
+}
+
+const pantryItemColumns = "id, ingredient_id, amount, unit, expires_on, location"
+
+func (r PantryRepository) Get(id int64) (item PantryItem, e error) {
+	err := r.db.QueryRow(context.Background(), "SELECT "+pantryItemColumns+" FROM pantry_items WHERE id = $1", id).Scan(&item.Id, &item.IngredientId, &item.Amount, &item.Unit, &item.ExpiresOn, &item.Location)
+	if err != nil {
+		log.Println(err.Error())
+		switch err {
+		case pgx.ErrNoRows:
+			e = &NotFound{"pantry_items", id}
+		default:
+			e = &InternalError{err.Error()}
+		}
+	}
+	return
+}
+
+// GetAll returns the pantry items ordered by expiry date, items that don't
+// expire last.
+func (r PantryRepository) GetAll() ([]PantryItem, error) {
+	return r.getPantryItems("SELECT " + pantryItemColumns + " FROM pantry_items ORDER BY expires_on NULLS LAST, id")
+}
+
+// GetByIngredients returns the pantry items of the given ingredients ordered
+// by expiry date, items that don't expire last.
+func (r PantryRepository) GetByIngredients(ingredientIds []int64) ([]PantryItem, error) {
+	if len(ingredientIds) == 0 {
+		return []PantryItem{}, nil
+	}
+	return r.getPantryItems("SELECT " + pantryItemColumns + " FROM pantry_items WHERE ingredient_id IN (" + JoinIds(ingredientIds) + ") ORDER BY expires_on NULLS LAST, id")
+}
+
+func (r PantryRepository) getPantryItems(query string) ([]PantryItem, error) {
+	results, err := r.db.Query(context.Background(), query)
+	if err != nil {
+		log.Println(err.Error())
+		return []PantryItem{}, &InternalError{err.Error()}
+	}
+	defer results.Close()
+	var items []PantryItem
+	for results.Next() {
+		var item PantryItem
+		err = results.Scan(&item.Id, &item.IngredientId, &item.Amount, &item.Unit, &item.ExpiresOn, &item.Location)
+		if err != nil {
+			log.Println(err.Error())
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+func (r PantryRepository) Create(item PantryItem) (int64, error) {
+	err := r.db.QueryRow(context.Background(), "INSERT INTO pantry_items (ingredient_id, amount, unit, expires_on, location) VALUES ($1, $2, $3, $4, $5) RETURNING id", item.IngredientId, item.Amount, item.Unit, item.ExpiresOn, item.Location).Scan(&item.Id)
+	if err != nil {
+		log.Println(err.Error())
+		return 0, &InternalError{err.Error()}
+	}
+	return item.Id, nil
+}
+
+func (r PantryRepository) Update(item PantryItem) error {
+	result, err := r.db.Exec(context.Background(), "UPDATE pantry_items SET ingredient_id = $1, amount = $2, unit = $3, expires_on = $4, location = $5 WHERE id = $6", item.IngredientId, item.Amount, item.Unit, item.ExpiresOn, item.Location, item.Id)
+	if err != nil {
+		log.Println(err.Error())
+		return &InternalError{err.Error()}
+	}
+	rowCnt := result.RowsAffected()
+	if rowCnt != 1 {
+		return &NotFound{"pantry_items", item.Id}
+	}
+	return nil
+}
+
+func (r PantryRepository) Delete(id int64) error {
+	result, err := r.db.Exec(context.Background(), "DELETE FROM pantry_items WHERE id = $1", id)
+	if err != nil {
+		return &InternalError{err.Error()}
+	}
+	rowCnt := result.RowsAffected()
+	if rowCnt != 1 {
+		return &NotFound{"pantry_items", id}
+	}
+	return nil
+}
@@ -1,10 +1,23 @@
 package repository
 
+import "time"
+
 type Recipe struct {
 	Id          int64
 	Name        string
 	Steps       string
+	PrepMinutes int32
+	CookMinutes int32
+	Servings    int32
 	Ingredients []IngredientShort
+	Tags        []string
+	Images      []RecipeImage
+	// Rating is the average rating, nil if the recipe has no reviews.
+	Rating      *float32
+	RatingCount int64
+	// LastCooked is nil if the recipe was never cooked.
+	LastCooked  *time.Time
+	TimesCooked int64
 }
 
 type IngredientShort struct {
@@ -0,0 +1,17 @@
+package repository
+
+import "time"
+
+// RecipeImage describes an uploaded photo of a recipe, Step is 0 for a photo
+// of the dish and the number of the step otherwise. The image data is kept
+// in a blob store.
+type RecipeImage struct {
+	Id          int64
+	RecipeId    int64
+	Step        int32
+	ContentType string
+	Width       int32
+	Height      int32
+	Size        int64
+	Created     time.Time
+}
@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"log"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type RecipeImageRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewRecipeImageRepository(dbConn *pgxpool.Pool) *RecipeImageRepository {
+	r := new(RecipeImageRepository)
+	r.db = dbConn
+	return r
+}
+
+const recipeImageColumns = "id, recipe_id, step, content_type, width, height, size, created"
+
+func (r RecipeImageRepository) Get(id int64) (image RecipeImage, e error) {
+	err := r.db.QueryRow(context.Background(), "SELECT "+recipeImageColumns+" FROM recipe_images WHERE id = $1", id).Scan(&image.Id, &image.RecipeId, &image.Step, &image.ContentType, &image.Width, &image.Height, &image.Size, &image.Created)
+	if err != nil {
+		log.Println(err.Error())
+		switch err {
+		case pgx.ErrNoRows:
+			e = &NotFound{"recipe_images", id}
+		default:
+			e = &InternalError{err.Error()}
+		}
+	}
+	return
+}
+
+// GetAll returns the images of the recipe, the dish first, then by step in
+// upload order.
+func (r RecipeImageRepository) GetAll(recipeId int64) ([]RecipeImage, error) {
+	images, err := getRecipeImages(r.db, "SELECT "+recipeImageColumns+" FROM recipe_images WHERE recipe_id = $1 ORDER BY step, id", recipeId)
+	if err != nil {
+		return []RecipeImage{}, err
+	}
+	return images[recipeId], nil
+}
+
+func (r RecipeImageRepository) Create(image RecipeImage) (int64, error) {
+	err := r.db.QueryRow(context.Background(), "INSERT INTO recipe_images (recipe_id, step, content_type, width, height, size, created) VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id", image.RecipeId, image.Step, image.ContentType, image.Width, image.Height, image.Size, image.Created).Scan(&image.Id)
+	if err != nil {
+		log.Println(err.Error())
+		return 0, &InternalError{err.Error()}
+	}
+	return image.Id, nil
+}
+
+func (r RecipeImageRepository) Delete(id int64) error {
+	result, err := r.db.Exec(context.Background(), "DELETE FROM recipe_images WHERE id = $1", id)
+	if err != nil {
+		return &InternalError{err.Error()}
+	}
+	rowCnt := result.RowsAffected()
+	if rowCnt != 1 {
+		return &NotFound{"recipe_images", id}
+	}
+	return nil
+}
+
+// getRecipeImages returns the images selected by the query, keyed by recipe
+// id. The query must select recipeImageColumns.
+func getRecipeImages(db *pgxpool.Pool, query string, args ...interface{}) (map[int64][]RecipeImage, error) {
+	images := make(map[int64][]RecipeImage)
+	results, err := db.Query(context.Background(), query, args...)
+	if err != nil {
+		log.Println(err.Error())
+		return nil, &InternalError{err.Error()}
+	}
+	defer results.Close()
+	for results.Next() {
+		var image RecipeImage
+		err := results.Scan(&image.Id, &image.RecipeId, &image.Step, &image.ContentType, &image.Width, &image.Height, &image.Size, &image.Created)
+		if err != nil {
+			log.Println(err.Error())
+			return nil, &InternalError{err.Error()}
+		}
+		images[image.RecipeId] = append(images[image.RecipeId], image)
+	}
+	return images, nil
+}
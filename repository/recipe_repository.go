@@ -18,8 +18,15 @@ func NewRecipeRepository(dbConn *pgxpool.Pool) *RecipeRepository {
 	return r
 }
 
+// recipeColumns include the review and cook log aggregates of the recipes.
+const recipeColumns = "id, name, steps, prep_minutes, cook_minutes, servings, " +
+	"(SELECT avg(rating)::real FROM recipe_reviews WHERE recipe_id = recipes.id), " +
+	"(SELECT count(*) FROM recipe_reviews WHERE recipe_id = recipes.id), " +
+	"(SELECT max(date) FROM cook_log WHERE recipe_id = recipes.id), " +
+	"(SELECT count(*) FROM cook_log WHERE recipe_id = recipes.id)"
+
 func (r RecipeRepository) Get(id int64) (recipe Recipe, e error) {
-	err := r.db.QueryRow(context.Background(), "SELECT * FROM recipes WHERE id = $1", id).Scan(&recipe.Id, &recipe.Name, &recipe.Steps)
+	err := r.db.QueryRow(context.Background(), "SELECT "+recipeColumns+" FROM recipes WHERE id = $1", id).Scan(&recipe.Id, &recipe.Name, &recipe.Steps, &recipe.PrepMinutes, &recipe.CookMinutes, &recipe.Servings, &recipe.Rating, &recipe.RatingCount, &recipe.LastCooked, &recipe.TimesCooked)
 	if err != nil {
 		log.Println(err.Error())
 		switch err {
@@ -34,11 +41,21 @@ func (r RecipeRepository) Get(id int64) (recipe Recipe, e error) {
 		return Recipe{}, err
 	}
 	recipe.Ingredients = ingredients[id]
+	tags, err := r.getRecipeTags("SELECT recipe_tags.recipe_id, tags.name FROM recipe_tags JOIN tags ON tags.id = recipe_tags.tag_id WHERE recipe_tags.recipe_id = $1 ORDER BY tags.name", id)
+	if err != nil {
+		return Recipe{}, err
+	}
+	recipe.Tags = tags[id]
+	images, err := getRecipeImages(r.db, "SELECT "+recipeImageColumns+" FROM recipe_images WHERE recipe_id = $1 ORDER BY step, id", id)
+	if err != nil {
+		return Recipe{}, err
+	}
+	recipe.Images = images[id]
 	return recipe, nil
 }
 
 func (r RecipeRepository) GetAll() (recipes []Recipe, err error) {
-	results, err := r.db.Query(context.Background(), "SELECT * FROM recipes")
+	results, err := r.db.Query(context.Background(), "SELECT "+recipeColumns+" FROM recipes")
 	if err != nil {
 		return []Recipe{}, &InternalError{err.Error()}
 	}
@@ -46,11 +63,19 @@ func (r RecipeRepository) GetAll() (recipes []Recipe, err error) {
 	if err != nil {
 		return []Recipe{}, err
 	}
-	return r.parseRecipeRows(results, recipeIngredients), nil
+	recipeTags, err := r.getRecipeTags("SELECT recipe_tags.recipe_id, tags.name FROM recipe_tags JOIN tags ON tags.id = recipe_tags.tag_id ORDER BY recipe_tags.recipe_id, tags.name")
+	if err != nil {
+		return []Recipe{}, err
+	}
+	recipeImages, err := getRecipeImages(r.db, "SELECT "+recipeImageColumns+" FROM recipe_images ORDER BY recipe_id, step, id")
+	if err != nil {
+		return []Recipe{}, err
+	}
+	return r.parseRecipeRows(results, recipeIngredients, recipeTags, recipeImages), nil
 }
 
 func (r RecipeRepository) GetList(ids []int64) (recipes []Recipe, err error) {
-	results, err := r.db.Query(context.Background(), "SELECT * FROM recipes WHERE id IN ("+JoinIds(ids)+")")
+	results, err := r.db.Query(context.Background(), "SELECT "+recipeColumns+" FROM recipes WHERE id IN ("+JoinIds(ids)+")")
 	if err != nil {
 		return []Recipe{}, &InternalError{err.Error()}
 	}
@@ -58,17 +83,35 @@ func (r RecipeRepository) GetList(ids []int64) (recipes []Recipe, err error) {
 	if err != nil {
 		return []Recipe{}, err
 	}
-	return r.parseRecipeRows(results, recipeIngredients), nil
+	recipeTags, err := r.getRecipeTags("SELECT recipe_tags.recipe_id, tags.name FROM recipe_tags JOIN tags ON tags.id = recipe_tags.tag_id WHERE recipe_tags.recipe_id IN (" + JoinIds(ids) + ") ORDER BY recipe_tags.recipe_id, tags.name")
+	if err != nil {
+		return []Recipe{}, err
+	}
+	recipeImages, err := getRecipeImages(r.db, "SELECT "+recipeImageColumns+" FROM recipe_images WHERE recipe_id IN ("+JoinIds(ids)+") ORDER BY recipe_id, step, id")
+	if err != nil {
+		return []Recipe{}, err
+	}
+	return r.parseRecipeRows(results, recipeIngredients, recipeTags, recipeImages), nil
+}
+
+// GetIdsByIngredients returns the ids of the recipes using each of the given ingredients.
+func (r RecipeRepository) GetIdsByIngredients(ingredientIds []int64) (map[int64][]int64, error) {
+	if len(ingredientIds) == 0 {
+		return map[int64][]int64{}, nil
+	}
+	return queryIdMap(r.db, "SELECT DISTINCT ingredient_id, recipe_id FROM recipe_ingredients WHERE ingredient_id IN ("+JoinIds(ingredientIds)+") ORDER BY recipe_id")
 }
 
-func (r RecipeRepository) parseRecipeRows(rows pgx.Rows, recipeIngredients map[int64][]IngredientShort) (recipes []Recipe) {
+func (r RecipeRepository) parseRecipeRows(rows pgx.Rows, recipeIngredients map[int64][]IngredientShort, recipeTags map[int64][]string, recipeImages map[int64][]RecipeImage) (recipes []Recipe) {
 	for rows.Next() {
 		var recipe Recipe
-		err := rows.Scan(&recipe.Id, &recipe.Name, &recipe.Steps)
+		err := rows.Scan(&recipe.Id, &recipe.Name, &recipe.Steps, &recipe.PrepMinutes, &recipe.CookMinutes, &recipe.Servings, &recipe.Rating, &recipe.RatingCount, &recipe.LastCooked, &recipe.TimesCooked)
 		if err != nil {
 			log.Println(err.Error())
 		}
 		recipe.Ingredients = recipeIngredients[recipe.Id]
+		recipe.Tags = recipeTags[recipe.Id]
+		recipe.Images = recipeImages[recipe.Id]
 		if err != nil {
 			log.Println(err.Error())
 		}
@@ -108,28 +151,55 @@ func (r RecipeRepository) getRecipeIngredients(query string) (map[int64][]Ingred
 	return ingredients, nil
 }
 
-func (r RecipeRepository) Create(recipe Recipe) error {
+// getRecipeTags returns the tag names of the recipes, keyed by recipe id.
+func (r RecipeRepository) getRecipeTags(query string, args ...interface{}) (map[int64][]string, error) {
+	tags := make(map[int64][]string)
+	results, err := r.db.Query(context.Background(), query, args...)
+	if err != nil {
+		log.Println(err.Error())
+		return nil, &InternalError{err.Error()}
+	}
+	defer results.Close()
+	for results.Next() {
+		var recipeId int64
+		var tag string
+		err := results.Scan(&recipeId, &tag)
+		if err != nil {
+			log.Println(err.Error())
+			return nil, &InternalError{err.Error()}
+		}
+		tags[recipeId] = append(tags[recipeId], tag)
+	}
+	return tags, nil
+}
+
+func (r RecipeRepository) Create(recipe Recipe) (int64, error) {
 	ctx := context.Background()
 	tx, err := r.db.BeginTx(ctx, pgx.TxOptions{})
 	if err != nil {
-		return err
+		return 0, err
 	}
-	err = tx.QueryRow(ctx, "INSERT INTO recipes (name, steps) VALUES ($1, $2) RETURNING id", recipe.Name, recipe.Steps).Scan(&recipe.Id)
+	err = tx.QueryRow(ctx, "INSERT INTO recipes (name, steps, prep_minutes, cook_minutes, servings) VALUES ($1, $2, $3, $4, $5) RETURNING id", recipe.Name, recipe.Steps, recipe.PrepMinutes, recipe.CookMinutes, recipe.Servings).Scan(&recipe.Id)
 	if err != nil {
 		log.Println(err.Error())
 		tx.Rollback(ctx)
-		return &InternalError{err.Error()}
+		return 0, &InternalError{err.Error()}
 	}
 	err = r.createRecipeIngredients(tx, ctx, recipe)
 	if err != nil {
 		tx.Rollback(ctx)
-		return &InternalError{err.Error()}
+		return 0, &InternalError{err.Error()}
+	}
+	err = r.setRecipeTags(tx, ctx, recipe)
+	if err != nil {
+		tx.Rollback(ctx)
+		return 0, err
 	}
 	err = tx.Commit(ctx)
 	if err != nil {
-		return &InternalError{err.Error()}
+		return 0, &InternalError{err.Error()}
 	}
-	return nil
+	return recipe.Id, nil
 }
 
 func (r RecipeRepository) Update(recipe Recipe) error {
@@ -143,7 +213,7 @@ func (r RecipeRepository) Update(recipe Recipe) error {
 		tx.Rollback(ctx)
 		return err
 	}
-	result, err := tx.Exec(ctx, "UPDATE recipes SET name = $1, steps = $2 WHERE id = $3", recipe.Name, recipe.Steps, recipe.Id)
+	result, err := tx.Exec(ctx, "UPDATE recipes SET name = $1, steps = $2, prep_minutes = $3, cook_minutes = $4, servings = $5 WHERE id = $6", recipe.Name, recipe.Steps, recipe.PrepMinutes, recipe.CookMinutes, recipe.Servings, recipe.Id)
 	if err != nil {
 		log.Println(err.Error())
 		tx.Rollback(ctx)
@@ -164,6 +234,11 @@ func (r RecipeRepository) Update(recipe Recipe) error {
 		tx.Rollback(ctx)
 		return &InternalError{err.Error()}
 	}
+	err = r.setRecipeTags(tx, ctx, recipe)
+	if err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
 	err = tx.Commit(ctx)
 	if err != nil {
 		return &InternalError{err.Error()}
@@ -187,18 +262,42 @@ func (r RecipeRepository) createRecipeIngredients(tx pgx.Tx, ctx context.Context
 	return nil
 }
 
-func (r RecipeRepository) Delete(id int64) error {
-	result, err := r.db.Exec(context.Background(), "DELETE FROM recipes WHERE id = $1", id)
+// setRecipeTags replaces the tags of the recipe, creating the tags that
+// don't exist yet.
+func (r RecipeRepository) setRecipeTags(tx pgx.Tx, ctx context.Context, recipe Recipe) error {
+	_, err := tx.Exec(ctx, "DELETE FROM recipe_tags WHERE recipe_id = $1", recipe.Id)
 	if err != nil {
+		log.Println(err.Error())
 		return &InternalError{err.Error()}
 	}
-	rowCnt := result.RowsAffected()
-	if rowCnt != 1 {
-		return &NotFound{"recipes", id}
+	for _, tag := range recipe.Tags {
+		_, err = tx.Exec(ctx, "INSERT INTO tags (name) VALUES ($1) ON CONFLICT (name) DO NOTHING", tag)
+		if err != nil {
+			log.Println(err.Error())
+			return &InternalError{err.Error()}
+		}
+		_, err = tx.Exec(ctx, "INSERT INTO recipe_tags (recipe_id, tag_id) SELECT $1, id FROM tags WHERE name = $2", recipe.Id, tag)
+		if err != nil {
+			log.Println(err.Error())
+			return &InternalError{err.Error()}
+		}
 	}
 	return nil
 }
 
+func (r RecipeRepository) Delete(id int64, opts DeleteOptions) error {
+	return deleteWithDependents(r.db, "recipes", id, opts,
+		dependency{"meals", "meal_recipes", "meal_id", "recipe_id", false, dropDuplicate},
+		dependency{"collections", "collection_recipes", "collection_id", "recipe_id", false, dropDuplicate},
+		dependency{"recipe_reviews", "recipe_reviews", "id", "recipe_id", true, keepBoth},
+		dependency{"cook_log", "cook_log", "id", "recipe_id", true, keepBoth},
+		dependency{"recipe_images", "recipe_images", "id", "recipe_id", true, keepBoth})
+}
+
+func (r RecipeRepository) GetExistingIds(ids []int64) (map[int64]bool, error) {
+	return getExistingIds(r.db, "recipes", ids)
+}
+
 func (r RecipeRepository) deleteRecipeIngredients(tx pgx.Tx, ctx context.Context, recipeId int64) error {
 	_, err := tx.Exec(ctx, "DELETE FROM recipe_ingredients WHERE recipe_id = $1", recipeId)
 	if err != nil {
@@ -206,3 +305,43 @@ func (r RecipeRepository) deleteRecipeIngredients(tx pgx.Tx, ctx context.Context
 	}
 	return nil
 }
+
+// Clone copies the recipe with its ingredients and tags to a new recipe with
+// the name, in a single transaction. Images, reviews and the cook log stay
+// with the original.
+func (r RecipeRepository) Clone(id int64, name string) (int64, error) {
+	ctx := context.Background()
+	tx, err := r.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return 0, &InternalError{err.Error()}
+	}
+	var cloneId int64
+	err = tx.QueryRow(ctx, "INSERT INTO recipes (name, steps, prep_minutes, cook_minutes, servings) SELECT $2, steps, prep_minutes, cook_minutes, servings FROM recipes WHERE id = $1 RETURNING id", id, name).Scan(&cloneId)
+	if err != nil {
+		tx.Rollback(ctx)
+		switch err {
+		case pgx.ErrNoRows:
+			return 0, &NotFound{"recipes", id}
+		default:
+			log.Println(err.Error())
+			return 0, &InternalError{err.Error()}
+		}
+	}
+	queries := []string{
+		"INSERT INTO recipe_ingredients (recipe_id, ingredient_id, amount, unit, index) SELECT $2, ingredient_id, amount, unit, index FROM recipe_ingredients WHERE recipe_id = $1",
+		"INSERT INTO recipe_tags (recipe_id, tag_id) SELECT $2, tag_id FROM recipe_tags WHERE recipe_id = $1",
+	}
+	for _, query := range queries {
+		_, err = tx.Exec(ctx, query, id, cloneId)
+		if err != nil {
+			log.Println(err.Error())
+			tx.Rollback(ctx)
+			return 0, &InternalError{err.Error()}
+		}
+	}
+	err = tx.Commit(ctx)
+	if err != nil {
+		return 0, &InternalError{err.Error()}
+	}
+	return cloneId, nil
+}
@@ -0,0 +1,14 @@
+package repository
+
+import "time"
+
+// Review is a user's rating and review of a recipe, each user reviews a
+// recipe at most once.
+type Review struct {
+	Id       int64
+	RecipeId int64
+	User     string
+	Rating   int32
+	Text     string
+	Date     time.Time
+}
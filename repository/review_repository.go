@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"context"
+	"log"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type ReviewRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewReviewRepository(dbConn *pgxpool.Pool) *ReviewRepository {
+	r := new(ReviewRepository)
+	r.db = dbConn
+	return r
+}
+
+const reviewColumns = "id, recipe_id, user_name, rating, review, date"
+
+func (r ReviewRepository) Get(id int64) (review Review, e error) {
+	err := r.db.QueryRow(context.Background(), "SELECT "+reviewColumns+" FROM recipe_reviews WHERE id = $1", id).Scan(&review.Id, &review.RecipeId, &review.User, &review.Rating, &review.Text, &review.Date)
+	if err != nil {
+		log.Println(err.Error())
+		switch err {
+		case pgx.ErrNoRows:
+			e = &NotFound{"recipe_reviews", id}
+		default:
+			e = &InternalError{err.Error()}
+		}
+	}
+	return
+}
+
+// GetAll returns the reviews of the recipe, or of all recipes if recipeId is
+// 0, the most recent first.
+func (r ReviewRepository) GetAll(recipeId int64) ([]Review, error) {
+	query := "SELECT " + reviewColumns + " FROM recipe_reviews WHERE $1 = 0 OR recipe_id = $1 ORDER BY date DESC, id DESC"
+	results, err := r.db.Query(context.Background(), query, recipeId)
+	if err != nil {
+		log.Println(err.Error())
+		return []Review{}, &InternalError{err.Error()}
+	}
+	defer results.Close()
+	var reviews []Review
+	for results.Next() {
+		var review Review
+		err = results.Scan(&review.Id, &review.RecipeId, &review.User, &review.Rating, &review.Text, &review.Date)
+		if err != nil {
+			log.Println(err.Error())
+		}
+		reviews = append(reviews, review)
+	}
+	return reviews, nil
+}
+
+// GetIdByUser returns the id of the user's review of the recipe, 0 if there
+// is none.
+func (r ReviewRepository) GetIdByUser(recipeId int64, user string) (int64, error) {
+	var id int64
+	err := r.db.QueryRow(context.Background(), "SELECT id FROM recipe_reviews WHERE recipe_id = $1 AND user_name = $2", recipeId, user).Scan(&id)
+	if err != nil && err != pgx.ErrNoRows {
+		log.Println(err.Error())
+		return 0, &InternalError{err.Error()}
+	}
+	return id, nil
+}
+
+func (r ReviewRepository) Create(review Review) (int64, error) {
+	err := r.db.QueryRow(context.Background(), "INSERT INTO recipe_reviews (recipe_id, user_name, rating, review, date) VALUES ($1, $2, $3, $4, $5) RETURNING id", review.RecipeId, review.User, review.Rating, review.Text, review.Date).Scan(&review.Id)
+	if err != nil {
+		log.Println(err.Error())
+		return 0, &InternalError{err.Error()}
+	}
+	return review.Id, nil
+}
+
+func (r ReviewRepository) Update(review Review) error {
+	result, err := r.db.Exec(context.Background(), "UPDATE recipe_reviews SET recipe_id = $1, user_name = $2, rating = $3, review = $4, date = $5 WHERE id = $6", review.RecipeId, review.User, review.Rating, review.Text, review.Date, review.Id)
+	if err != nil {
+		log.Println(err.Error())
+		return &InternalError{err.Error()}
+	}
+	rowCnt := result.RowsAffected()
+	if rowCnt != 1 {
+		return &NotFound{"recipe_reviews", review.Id}
+	}
+	return nil
+}
+
+func (r ReviewRepository) Delete(id int64) error {
+	result, err := r.db.Exec(context.Background(), "DELETE FROM recipe_reviews WHERE id = $1", id)
+	if err != nil {
+		return &InternalError{err.Error()}
+	}
+	rowCnt := result.RowsAffected()
+	if rowCnt != 1 {
+		return &NotFound{"recipe_reviews", id}
+	}
+	return nil
+}
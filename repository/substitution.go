@@ -0,0 +1,13 @@
+package repository
+
+// Substitution records that SubstituteId can replace IngredientId, Ratio
+// being the amount of the substitute per amount of the original.
+type Substitution struct {
+	Id           int64
+	IngredientId int64
+	SubstituteId int64
+	Ratio        float32
+	Notes        string
+	// Diets are stored as a text array.
+	Diets []string
+}
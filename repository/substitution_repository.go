@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"context"
+	"log"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type SubstitutionRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewSubstitutionRepository(dbConn *pgxpool.Pool) *SubstitutionRepository {
+	r := new(SubstitutionRepository)
+	r.db = dbConn
+	return r
+}
+
+const substitutionColumns = "id, ingredient_id, substitute_id, ratio, notes, diets"
+
+func (r SubstitutionRepository) Get(id int64) (sub Substitution, e error) {
+	err := r.db.QueryRow(context.Background(), "SELECT "+substitutionColumns+" FROM substitutions WHERE id = $1", id).Scan(&sub.Id, &sub.IngredientId, &sub.SubstituteId, &sub.Ratio, &sub.Notes, &sub.Diets)
+	if err != nil {
+		log.Println(err.Error())
+		switch err {
+		case pgx.ErrNoRows:
+			e = &NotFound{"substitutions", id}
+		default:
+			e = &InternalError{err.Error()}
+		}
+	}
+	return
+}
+
+func (r SubstitutionRepository) GetAll() ([]Substitution, error) {
+	return r.getSubstitutions("SELECT " + substitutionColumns + " FROM substitutions ORDER BY id")
+}
+
+func (r SubstitutionRepository) GetList(ids []int64) ([]Substitution, error) {
+	if len(ids) == 0 {
+		return []Substitution{}, nil
+	}
+	return r.getSubstitutions("SELECT " + substitutionColumns + " FROM substitutions WHERE id IN (" + JoinIds(ids) + ") ORDER BY id")
+}
+
+// GetByIngredient returns the substitutions replacing the ingredient.
+func (r SubstitutionRepository) GetByIngredient(ingredientId int64) ([]Substitution, error) {
+	return r.getSubstitutions("SELECT "+substitutionColumns+" FROM substitutions WHERE ingredient_id = $1 ORDER BY id", ingredientId)
+}
+
+func (r SubstitutionRepository) getSubstitutions(query string, args ...interface{}) ([]Substitution, error) {
+	results, err := r.db.Query(context.Background(), query, args...)
+	if err != nil {
+		log.Println(err.Error())
+		return []Substitution{}, &InternalError{err.Error()}
+	}
+	defer results.Close()
+	var subs []Substitution
+	for results.Next() {
+		var sub Substitution
+		err = results.Scan(&sub.Id, &sub.IngredientId, &sub.SubstituteId, &sub.Ratio, &sub.Notes, &sub.Diets)
+		if err != nil {
+			log.Println(err.Error())
+		}
+		subs = append(subs, sub)
+	}
+	return subs, nil
+}
+
+func (r SubstitutionRepository) Create(sub Substitution) (int64, error) {
+	err := r.db.QueryRow(context.Background(), "INSERT INTO substitutions (ingredient_id, substitute_id, ratio, notes, diets) VALUES ($1, $2, $3, $4, $5) RETURNING id", sub.IngredientId, sub.SubstituteId, sub.Ratio, sub.Notes, sub.Diets).Scan(&sub.Id)
+	if err != nil {
+		log.Println(err.Error())
+		return 0, &InternalError{err.Error()}
+	}
+	return sub.Id, nil
+}
+
+func (r SubstitutionRepository) Update(sub Substitution) error {
+	result, err := r.db.Exec(context.Background(), "UPDATE substitutions SET ingredient_id = $1, substitute_id = $2, ratio = $3, notes = $4, diets = $5 WHERE id = $6", sub.IngredientId, sub.SubstituteId, sub.Ratio, sub.Notes, sub.Diets, sub.Id)
+	if err != nil {
+		log.Println(err.Error())
+		return &InternalError{err.Error()}
+	}
+	rowCnt := result.RowsAffected()
+	if rowCnt != 1 {
+		return &NotFound{"substitutions", sub.Id}
+	}
+	return nil
+}
+
+func (r SubstitutionRepository) Delete(id int64) error {
+	result, err := r.db.Exec(context.Background(), "DELETE FROM substitutions WHERE id = $1", id)
+	if err != nil {
+		return &InternalError{err.Error()}
+	}
+	rowCnt := result.RowsAffected()
+	if rowCnt != 1 {
+		return &NotFound{"substitutions", id}
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/cookbook/rpc/pb"
+	"github.com/cookbook/service"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type IngredientServer struct {
+	pb.UnimplementedIngredientServiceServer
+	Service service.IngredientService
+}
+
+func (s IngredientServer) Get(ctx context.Context, req *pb.GetRequest) (*pb.Ingredient, error) {
+	ing, err := s.Service.Get(req.Id)
+	if err != nil {
+		return nil, handleError(err)
+	}
+	return ingredientToPb(ing), nil
+}
+
+func (s IngredientServer) GetList(req *pb.GetListRequest, stream pb.IngredientService_GetListServer) error {
+	ings, err := s.Service.GetList(req.Ids)
+	if err != nil {
+		return handleError(err)
+	}
+	for _, ing := range ings {
+		if err := stream.Send(ingredientToPb(ing)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s IngredientServer) GetAll(req *pb.GetAllRequest, stream pb.IngredientService_GetAllServer) error {
+	ings, err := s.Service.GetAll()
+	if err != nil {
+		return handleError(err)
+	}
+	for _, ing := range ings {
+		if err := stream.Send(ingredientToPb(ing)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s IngredientServer) Create(ctx context.Context, req *pb.Ingredient) (*pb.CreateResponse, error) {
+	id, err := s.Service.Create(ingredientFromPb(req))
+	if err != nil {
+		return nil, handleError(err)
+	}
+	return &pb.CreateResponse{Id: id}, nil
+}
+
+func (s IngredientServer) Update(ctx context.Context, req *pb.Ingredient) (*emptypb.Empty, error) {
+	err := s.Service.Update(ingredientFromPb(req))
+	if err != nil {
+		return nil, handleError(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s IngredientServer) Delete(ctx context.Context, req *pb.DeleteRequest) (*emptypb.Empty, error) {
+	err := s.Service.Delete(req.Id, service.DeleteOptions{Cascade: req.Cascade, ReplaceWith: req.ReplaceWith})
+	if err != nil {
+		return nil, handleError(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func ingredientToPb(i service.Ingredient) *pb.Ingredient {
+	return &pb.Ingredient{
+		Id:   i.Id,
+		Name: i.Name,
+		NutritionalValue: &pb.NutritionalValue{
+			Quantity: &pb.Quantity{
+				Amount: i.Amount,
+				Unit:   i.Unit,
+			},
+			Calories: i.Calories,
+			Protein:  i.Protein,
+			Carbs:    i.Carbs,
+			Fat:      i.Fat,
+		},
+		Allergens: i.Allergens,
+		Diets:     i.Diets,
+	}
+}
+
+func ingredientFromPb(i *pb.Ingredient) service.Ingredient {
+	nv := i.GetNutritionalValue()
+	return service.Ingredient{
+		Id:   i.Id,
+		Name: i.Name,
+		NutritionalValue: service.NutritionalValue{
+			Quantity: service.Quantity{
+				Amount: nv.GetQuantity().GetAmount(),
+				Unit:   nv.GetQuantity().GetUnit(),
+			},
+			Calories: nv.GetCalories(),
+			Protein:  nv.GetProtein(),
+			Carbs:    nv.GetCarbs(),
+			Fat:      nv.GetFat(),
+		},
+		DietLabels: service.DietLabels{
+			Allergens: i.Allergens,
+			Diets:     i.Diets,
+		},
+	}
+}
@@ -0,0 +1,188 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/cookbook/rpc/pb"
+	"github.com/cookbook/service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type MealPlanServer struct {
+	pb.UnimplementedMealPlanServiceServer
+	Service service.MealPlanService
+}
+
+func (s MealPlanServer) Get(ctx context.Context, req *pb.GetRequest) (*pb.MealPlan, error) {
+	mealPlan, err := s.Service.Get(req.Id)
+	if err != nil {
+		return nil, handleError(err)
+	}
+	return mealPlanToPb(mealPlan), nil
+}
+
+func (s MealPlanServer) GetList(req *pb.GetListRequest, stream pb.MealPlanService_GetListServer) error {
+	mealPlans, err := s.Service.GetList(req.Ids)
+	if err != nil {
+		return handleError(err)
+	}
+	for _, mealPlan := range mealPlans {
+		if err := stream.Send(mealPlanToPb(mealPlan)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s MealPlanServer) GetAll(req *pb.GetAllRequest, stream pb.MealPlanService_GetAllServer) error {
+	mealPlans, err := s.Service.GetAll()
+	if err != nil {
+		return handleError(err)
+	}
+	for _, mealPlan := range mealPlans {
+		if err := stream.Send(mealPlanToPb(mealPlan)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s MealPlanServer) GetIdsByMeals(ctx context.Context, req *pb.GetListRequest) (*pb.IdMap, error) {
+	ids, err := s.Service.GetIdsByMeals(req.Ids)
+	if err != nil {
+		return nil, handleError(err)
+	}
+	return idMapToPb(ids), nil
+}
+
+func (s MealPlanServer) GetCalendar(req *pb.CalendarRequest, stream pb.MealPlanService_GetCalendarServer) error {
+	from, err := parseDate("from", req.From)
+	if err != nil {
+		return err
+	}
+	to, err := parseDate("to", req.To)
+	if err != nil {
+		return err
+	}
+	calendar, err := s.Service.GetCalendar(from, to)
+	if err != nil {
+		return handleError(err)
+	}
+	for _, day := range calendar {
+		if err := stream.Send(calendarDayToPb(day)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s MealPlanServer) Create(ctx context.Context, req *pb.MealPlanCreate) (*pb.CreateResponse, error) {
+	mealPlan, err := mealPlanFromPb(req)
+	if err != nil {
+		return nil, err
+	}
+	id, err := s.Service.Create(mealPlan)
+	if err != nil {
+		return nil, handleError(err)
+	}
+	return &pb.CreateResponse{Id: id}, nil
+}
+
+func (s MealPlanServer) Update(ctx context.Context, req *pb.MealPlanCreate) (*emptypb.Empty, error) {
+	mealPlan, err := mealPlanFromPb(req)
+	if err != nil {
+		return nil, err
+	}
+	err = s.Service.Update(mealPlan)
+	if err != nil {
+		return nil, handleError(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s MealPlanServer) Delete(ctx context.Context, req *pb.DeleteRequest) (*emptypb.Empty, error) {
+	err := s.Service.Delete(req.Id)
+	if err != nil {
+		return nil, handleError(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func mealPlanToPb(mp service.MealPlanGet) *pb.MealPlan {
+	mealPlan := &pb.MealPlan{
+		Id:          mp.Id,
+		Name:        mp.Name,
+		DateStarted: timestamppb.New(mp.DateStarted),
+		Followed:    mp.Followed,
+		Cost:        costToPb(mp.Cost),
+		Allergens:   mp.Allergens,
+		Diets:       mp.Diets,
+	}
+	for _, entry := range mp.Entries {
+		mealPlan.Entries = append(mealPlan.Entries, &pb.MealPlanEntry{
+			Date:     entry.Date.String(),
+			Slot:     entry.Slot,
+			Servings: entry.Servings,
+			People:   int32(entry.People),
+			Cooked:   entry.Cooked,
+			Batch:    int32(entry.Batch),
+			Portions: entry.Portions,
+			Leftover: entry.Leftover,
+			Members:  entry.Members,
+			Meal:     mealToPb(entry.Meal),
+		})
+	}
+	return mealPlan
+}
+
+func mealPlanFromPb(mp *pb.MealPlanCreate) (service.MealPlanCreate, error) {
+	mealPlan := service.MealPlanCreate{
+		Id:          mp.Id,
+		Name:        mp.Name,
+		DateStarted: mp.DateStarted.AsTime(),
+		Followed:    mp.Followed,
+	}
+	for _, entry := range mp.Entries {
+		date, err := parseDate("entry date", entry.Date)
+		if err != nil {
+			return service.MealPlanCreate{}, err
+		}
+		mealPlan.Entries = append(mealPlan.Entries, service.MealPlanEntryCreate{
+			Date:     date,
+			Slot:     entry.Slot,
+			MealId:   entry.MealId,
+			Servings: entry.Servings,
+			People:   int(entry.People),
+			Cooked:   entry.Cooked,
+			Batch:    int(entry.Batch),
+			Portions: entry.Portions,
+			Members:  entry.Members,
+		})
+	}
+	return mealPlan, nil
+}
+
+func calendarDayToPb(d service.CalendarDay) *pb.CalendarDay {
+	day := &pb.CalendarDay{Date: d.Date.String()}
+	for _, entry := range d.Entries {
+		day.Entries = append(day.Entries, &pb.CalendarEntry{
+			MealPlanId:   entry.MealPlanId,
+			MealPlanName: entry.MealPlanName,
+			Slot:         entry.Slot,
+			Servings:     entry.Servings,
+			Meal:         mealToPb(entry.Meal),
+		})
+	}
+	return day
+}
+
+func parseDate(name, value string) (service.Date, error) {
+	date, err := service.ParseDate(value)
+	if err != nil {
+		return service.Date{}, status.Errorf(codes.InvalidArgument, "%s must be formatted as YYYY-MM-DD", name)
+	}
+	return date, nil
+}
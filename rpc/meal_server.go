@@ -0,0 +1,104 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/cookbook/rpc/pb"
+	"github.com/cookbook/service"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type MealServer struct {
+	pb.UnimplementedMealServiceServer
+	Service service.MealService
+}
+
+func (s MealServer) Get(ctx context.Context, req *pb.GetRequest) (*pb.Meal, error) {
+	meal, err := s.Service.Get(req.Id)
+	if err != nil {
+		return nil, handleError(err)
+	}
+	return mealToPb(meal), nil
+}
+
+func (s MealServer) GetList(req *pb.GetListRequest, stream pb.MealService_GetListServer) error {
+	meals, err := s.Service.GetList(req.Ids)
+	if err != nil {
+		return handleError(err)
+	}
+	for _, meal := range meals {
+		if err := stream.Send(mealToPb(meal)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s MealServer) GetAll(req *pb.GetAllRequest, stream pb.MealService_GetAllServer) error {
+	meals, err := s.Service.GetAll()
+	if err != nil {
+		return handleError(err)
+	}
+	for _, meal := range meals {
+		if err := stream.Send(mealToPb(meal)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s MealServer) GetIdsByRecipes(ctx context.Context, req *pb.GetListRequest) (*pb.IdMap, error) {
+	ids, err := s.Service.GetIdsByRecipes(req.Ids)
+	if err != nil {
+		return nil, handleError(err)
+	}
+	return idMapToPb(ids), nil
+}
+
+func (s MealServer) Create(ctx context.Context, req *pb.MealCreate) (*pb.CreateResponse, error) {
+	id, err := s.Service.Create(mealFromPb(req))
+	if err != nil {
+		return nil, handleError(err)
+	}
+	return &pb.CreateResponse{Id: id}, nil
+}
+
+func (s MealServer) Update(ctx context.Context, req *pb.MealCreate) (*emptypb.Empty, error) {
+	err := s.Service.Update(mealFromPb(req))
+	if err != nil {
+		return nil, handleError(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s MealServer) Delete(ctx context.Context, req *pb.DeleteRequest) (*emptypb.Empty, error) {
+	err := s.Service.Delete(req.Id, service.DeleteOptions{Cascade: req.Cascade, ReplaceWith: req.ReplaceWith})
+	if err != nil {
+		return nil, handleError(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func mealToPb(m service.MealGet) *pb.Meal {
+	meal := &pb.Meal{
+		Id:        m.Id,
+		Name:      m.Name,
+		Cost:      costToPb(m.Cost),
+		Servings:  m.Servings,
+		Allergens: m.Allergens,
+		Diets:     m.Diets,
+	}
+	for _, recipe := range m.Recipes {
+		meal.Recipes = append(meal.Recipes, recipeToPb(recipe))
+	}
+	return meal
+}
+
+func mealFromPb(m *pb.MealCreate) service.MealCreate {
+	return service.MealCreate{
+		Id:       m.Id,
+		Name:     m.Name,
+		Recipes:  m.Recipes,
+		Servings: m.Servings,
+	}
+}
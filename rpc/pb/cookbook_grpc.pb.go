@@ -0,0 +1,1471 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// IngredientServiceClient is the client API for IngredientService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type IngredientServiceClient interface {
+	Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*Ingredient, error)
+	GetList(ctx context.Context, in *GetListRequest, opts ...grpc.CallOption) (IngredientService_GetListClient, error)
+	GetAll(ctx context.Context, in *GetAllRequest, opts ...grpc.CallOption) (IngredientService_GetAllClient, error)
+	Create(ctx context.Context, in *Ingredient, opts ...grpc.CallOption) (*CreateResponse, error)
+	Update(ctx context.Context, in *Ingredient, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+}
+
+type ingredientServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewIngredientServiceClient(cc grpc.ClientConnInterface) IngredientServiceClient {
+	return &ingredientServiceClient{cc}
+}
+
+func (c *ingredientServiceClient) Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*Ingredient, error) {
+	out := new(Ingredient)
+	err := c.cc.Invoke(ctx, "/cookbook.v1.IngredientService/Get", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ingredientServiceClient) GetList(ctx context.Context, in *GetListRequest, opts ...grpc.CallOption) (IngredientService_GetListClient, error) {
+	stream, err := c.cc.NewStream(ctx, &IngredientService_ServiceDesc.Streams[0], "/cookbook.v1.IngredientService/GetList", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &ingredientServiceGetListClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type IngredientService_GetListClient interface {
+	Recv() (*Ingredient, error)
+	grpc.ClientStream
+}
+
+type ingredientServiceGetListClient struct {
+	grpc.ClientStream
+}
+
+func (x *ingredientServiceGetListClient) Recv() (*Ingredient, error) {
+	m := new(Ingredient)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *ingredientServiceClient) GetAll(ctx context.Context, in *GetAllRequest, opts ...grpc.CallOption) (IngredientService_GetAllClient, error) {
+	stream, err := c.cc.NewStream(ctx, &IngredientService_ServiceDesc.Streams[1], "/cookbook.v1.IngredientService/GetAll", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &ingredientServiceGetAllClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type IngredientService_GetAllClient interface {
+	Recv() (*Ingredient, error)
+	grpc.ClientStream
+}
+
+type ingredientServiceGetAllClient struct {
+	grpc.ClientStream
+}
+
+func (x *ingredientServiceGetAllClient) Recv() (*Ingredient, error) {
+	m := new(Ingredient)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *ingredientServiceClient) Create(ctx context.Context, in *Ingredient, opts ...grpc.CallOption) (*CreateResponse, error) {
+	out := new(CreateResponse)
+	err := c.cc.Invoke(ctx, "/cookbook.v1.IngredientService/Create", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ingredientServiceClient) Update(ctx context.Context, in *Ingredient, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/cookbook.v1.IngredientService/Update", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ingredientServiceClient) Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/cookbook.v1.IngredientService/Delete", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// IngredientServiceServer is the server API for IngredientService service.
+// All implementations must embed UnimplementedIngredientServiceServer
+// for forward compatibility
+type IngredientServiceServer interface {
+	Get(context.Context, *GetRequest) (*Ingredient, error)
+	GetList(*GetListRequest, IngredientService_GetListServer) error
+	GetAll(*GetAllRequest, IngredientService_GetAllServer) error
+	Create(context.Context, *Ingredient) (*CreateResponse, error)
+	Update(context.Context, *Ingredient) (*emptypb.Empty, error)
+	Delete(context.Context, *DeleteRequest) (*emptypb.Empty, error)
+	mustEmbedUnimplementedIngredientServiceServer()
+}
+
+// UnimplementedIngredientServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedIngredientServiceServer struct {
+}
+
+func (UnimplementedIngredientServiceServer) Get(context.Context, *GetRequest) (*Ingredient, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
+}
+func (UnimplementedIngredientServiceServer) GetList(*GetListRequest, IngredientService_GetListServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetList not implemented")
+}
+func (UnimplementedIngredientServiceServer) GetAll(*GetAllRequest, IngredientService_GetAllServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetAll not implemented")
+}
+func (UnimplementedIngredientServiceServer) Create(context.Context, *Ingredient) (*CreateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Create not implemented")
+}
+func (UnimplementedIngredientServiceServer) Update(context.Context, *Ingredient) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Update not implemented")
+}
+func (UnimplementedIngredientServiceServer) Delete(context.Context, *DeleteRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
+}
+func (UnimplementedIngredientServiceServer) mustEmbedUnimplementedIngredientServiceServer() {}
+
+// UnsafeIngredientServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to IngredientServiceServer will
+// result in compilation errors.
+type UnsafeIngredientServiceServer interface {
+	mustEmbedUnimplementedIngredientServiceServer()
+}
+
+func RegisterIngredientServiceServer(s grpc.ServiceRegistrar, srv IngredientServiceServer) {
+	s.RegisterService(&IngredientService_ServiceDesc, srv)
+}
+
+func _IngredientService_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IngredientServiceServer).Get(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cookbook.v1.IngredientService/Get",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IngredientServiceServer).Get(ctx, req.(*GetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IngredientService_GetList_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetListRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(IngredientServiceServer).GetList(m, &ingredientServiceGetListServer{stream})
+}
+
+type IngredientService_GetListServer interface {
+	Send(*Ingredient) error
+	grpc.ServerStream
+}
+
+type ingredientServiceGetListServer struct {
+	grpc.ServerStream
+}
+
+func (x *ingredientServiceGetListServer) Send(m *Ingredient) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _IngredientService_GetAll_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetAllRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(IngredientServiceServer).GetAll(m, &ingredientServiceGetAllServer{stream})
+}
+
+type IngredientService_GetAllServer interface {
+	Send(*Ingredient) error
+	grpc.ServerStream
+}
+
+type ingredientServiceGetAllServer struct {
+	grpc.ServerStream
+}
+
+func (x *ingredientServiceGetAllServer) Send(m *Ingredient) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _IngredientService_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Ingredient)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IngredientServiceServer).Create(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cookbook.v1.IngredientService/Create",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IngredientServiceServer).Create(ctx, req.(*Ingredient))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IngredientService_Update_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Ingredient)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IngredientServiceServer).Update(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cookbook.v1.IngredientService/Update",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IngredientServiceServer).Update(ctx, req.(*Ingredient))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IngredientService_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IngredientServiceServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cookbook.v1.IngredientService/Delete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IngredientServiceServer).Delete(ctx, req.(*DeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// IngredientService_ServiceDesc is the grpc.ServiceDesc for IngredientService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var IngredientService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "cookbook.v1.IngredientService",
+	HandlerType: (*IngredientServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Get",
+			Handler:    _IngredientService_Get_Handler,
+		},
+		{
+			MethodName: "Create",
+			Handler:    _IngredientService_Create_Handler,
+		},
+		{
+			MethodName: "Update",
+			Handler:    _IngredientService_Update_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _IngredientService_Delete_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "GetList",
+			Handler:       _IngredientService_GetList_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "GetAll",
+			Handler:       _IngredientService_GetAll_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "cookbook.proto",
+}
+
+// RecipeServiceClient is the client API for RecipeService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type RecipeServiceClient interface {
+	Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*Recipe, error)
+	GetList(ctx context.Context, in *GetListRequest, opts ...grpc.CallOption) (RecipeService_GetListClient, error)
+	GetAll(ctx context.Context, in *GetAllRequest, opts ...grpc.CallOption) (RecipeService_GetAllClient, error)
+	GetIdsByIngredients(ctx context.Context, in *GetListRequest, opts ...grpc.CallOption) (*IdMap, error)
+	Create(ctx context.Context, in *RecipeCreate, opts ...grpc.CallOption) (*CreateResponse, error)
+	Update(ctx context.Context, in *RecipeCreate, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+}
+
+type recipeServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewRecipeServiceClient(cc grpc.ClientConnInterface) RecipeServiceClient {
+	return &recipeServiceClient{cc}
+}
+
+func (c *recipeServiceClient) Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*Recipe, error) {
+	out := new(Recipe)
+	err := c.cc.Invoke(ctx, "/cookbook.v1.RecipeService/Get", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *recipeServiceClient) GetList(ctx context.Context, in *GetListRequest, opts ...grpc.CallOption) (RecipeService_GetListClient, error) {
+	stream, err := c.cc.NewStream(ctx, &RecipeService_ServiceDesc.Streams[0], "/cookbook.v1.RecipeService/GetList", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &recipeServiceGetListClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type RecipeService_GetListClient interface {
+	Recv() (*Recipe, error)
+	grpc.ClientStream
+}
+
+type recipeServiceGetListClient struct {
+	grpc.ClientStream
+}
+
+func (x *recipeServiceGetListClient) Recv() (*Recipe, error) {
+	m := new(Recipe)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *recipeServiceClient) GetAll(ctx context.Context, in *GetAllRequest, opts ...grpc.CallOption) (RecipeService_GetAllClient, error) {
+	stream, err := c.cc.NewStream(ctx, &RecipeService_ServiceDesc.Streams[1], "/cookbook.v1.RecipeService/GetAll", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &recipeServiceGetAllClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type RecipeService_GetAllClient interface {
+	Recv() (*Recipe, error)
+	grpc.ClientStream
+}
+
+type recipeServiceGetAllClient struct {
+	grpc.ClientStream
+}
+
+func (x *recipeServiceGetAllClient) Recv() (*Recipe, error) {
+	m := new(Recipe)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *recipeServiceClient) GetIdsByIngredients(ctx context.Context, in *GetListRequest, opts ...grpc.CallOption) (*IdMap, error) {
+	out := new(IdMap)
+	err := c.cc.Invoke(ctx, "/cookbook.v1.RecipeService/GetIdsByIngredients", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *recipeServiceClient) Create(ctx context.Context, in *RecipeCreate, opts ...grpc.CallOption) (*CreateResponse, error) {
+	out := new(CreateResponse)
+	err := c.cc.Invoke(ctx, "/cookbook.v1.RecipeService/Create", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *recipeServiceClient) Update(ctx context.Context, in *RecipeCreate, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/cookbook.v1.RecipeService/Update", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *recipeServiceClient) Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/cookbook.v1.RecipeService/Delete", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RecipeServiceServer is the server API for RecipeService service.
+// All implementations must embed UnimplementedRecipeServiceServer
+// for forward compatibility
+type RecipeServiceServer interface {
+	Get(context.Context, *GetRequest) (*Recipe, error)
+	GetList(*GetListRequest, RecipeService_GetListServer) error
+	GetAll(*GetAllRequest, RecipeService_GetAllServer) error
+	GetIdsByIngredients(context.Context, *GetListRequest) (*IdMap, error)
+	Create(context.Context, *RecipeCreate) (*CreateResponse, error)
+	Update(context.Context, *RecipeCreate) (*emptypb.Empty, error)
+	Delete(context.Context, *DeleteRequest) (*emptypb.Empty, error)
+	mustEmbedUnimplementedRecipeServiceServer()
+}
+
+// UnimplementedRecipeServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedRecipeServiceServer struct {
+}
+
+func (UnimplementedRecipeServiceServer) Get(context.Context, *GetRequest) (*Recipe, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
+}
+func (UnimplementedRecipeServiceServer) GetList(*GetListRequest, RecipeService_GetListServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetList not implemented")
+}
+func (UnimplementedRecipeServiceServer) GetAll(*GetAllRequest, RecipeService_GetAllServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetAll not implemented")
+}
+func (UnimplementedRecipeServiceServer) GetIdsByIngredients(context.Context, *GetListRequest) (*IdMap, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetIdsByIngredients not implemented")
+}
+func (UnimplementedRecipeServiceServer) Create(context.Context, *RecipeCreate) (*CreateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Create not implemented")
+}
+func (UnimplementedRecipeServiceServer) Update(context.Context, *RecipeCreate) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Update not implemented")
+}
+func (UnimplementedRecipeServiceServer) Delete(context.Context, *DeleteRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
+}
+func (UnimplementedRecipeServiceServer) mustEmbedUnimplementedRecipeServiceServer() {}
+
+// UnsafeRecipeServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to RecipeServiceServer will
+// result in compilation errors.
+type UnsafeRecipeServiceServer interface {
+	mustEmbedUnimplementedRecipeServiceServer()
+}
+
+func RegisterRecipeServiceServer(s grpc.ServiceRegistrar, srv RecipeServiceServer) {
+	s.RegisterService(&RecipeService_ServiceDesc, srv)
+}
+
+func _RecipeService_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RecipeServiceServer).Get(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cookbook.v1.RecipeService/Get",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RecipeServiceServer).Get(ctx, req.(*GetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RecipeService_GetList_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetListRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RecipeServiceServer).GetList(m, &recipeServiceGetListServer{stream})
+}
+
+type RecipeService_GetListServer interface {
+	Send(*Recipe) error
+	grpc.ServerStream
+}
+
+type recipeServiceGetListServer struct {
+	grpc.ServerStream
+}
+
+func (x *recipeServiceGetListServer) Send(m *Recipe) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _RecipeService_GetAll_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetAllRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RecipeServiceServer).GetAll(m, &recipeServiceGetAllServer{stream})
+}
+
+type RecipeService_GetAllServer interface {
+	Send(*Recipe) error
+	grpc.ServerStream
+}
+
+type recipeServiceGetAllServer struct {
+	grpc.ServerStream
+}
+
+func (x *recipeServiceGetAllServer) Send(m *Recipe) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _RecipeService_GetIdsByIngredients_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RecipeServiceServer).GetIdsByIngredients(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cookbook.v1.RecipeService/GetIdsByIngredients",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RecipeServiceServer).GetIdsByIngredients(ctx, req.(*GetListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RecipeService_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecipeCreate)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RecipeServiceServer).Create(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cookbook.v1.RecipeService/Create",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RecipeServiceServer).Create(ctx, req.(*RecipeCreate))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RecipeService_Update_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecipeCreate)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RecipeServiceServer).Update(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cookbook.v1.RecipeService/Update",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RecipeServiceServer).Update(ctx, req.(*RecipeCreate))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RecipeService_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RecipeServiceServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cookbook.v1.RecipeService/Delete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RecipeServiceServer).Delete(ctx, req.(*DeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// RecipeService_ServiceDesc is the grpc.ServiceDesc for RecipeService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var RecipeService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "cookbook.v1.RecipeService",
+	HandlerType: (*RecipeServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Get",
+			Handler:    _RecipeService_Get_Handler,
+		},
+		{
+			MethodName: "GetIdsByIngredients",
+			Handler:    _RecipeService_GetIdsByIngredients_Handler,
+		},
+		{
+			MethodName: "Create",
+			Handler:    _RecipeService_Create_Handler,
+		},
+		{
+			MethodName: "Update",
+			Handler:    _RecipeService_Update_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _RecipeService_Delete_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "GetList",
+			Handler:       _RecipeService_GetList_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "GetAll",
+			Handler:       _RecipeService_GetAll_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "cookbook.proto",
+}
+
+// MealServiceClient is the client API for MealService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type MealServiceClient interface {
+	Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*Meal, error)
+	GetList(ctx context.Context, in *GetListRequest, opts ...grpc.CallOption) (MealService_GetListClient, error)
+	GetAll(ctx context.Context, in *GetAllRequest, opts ...grpc.CallOption) (MealService_GetAllClient, error)
+	GetIdsByRecipes(ctx context.Context, in *GetListRequest, opts ...grpc.CallOption) (*IdMap, error)
+	Create(ctx context.Context, in *MealCreate, opts ...grpc.CallOption) (*CreateResponse, error)
+	Update(ctx context.Context, in *MealCreate, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+}
+
+type mealServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewMealServiceClient(cc grpc.ClientConnInterface) MealServiceClient {
+	return &mealServiceClient{cc}
+}
+
+func (c *mealServiceClient) Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*Meal, error) {
+	out := new(Meal)
+	err := c.cc.Invoke(ctx, "/cookbook.v1.MealService/Get", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mealServiceClient) GetList(ctx context.Context, in *GetListRequest, opts ...grpc.CallOption) (MealService_GetListClient, error) {
+	stream, err := c.cc.NewStream(ctx, &MealService_ServiceDesc.Streams[0], "/cookbook.v1.MealService/GetList", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &mealServiceGetListClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type MealService_GetListClient interface {
+	Recv() (*Meal, error)
+	grpc.ClientStream
+}
+
+type mealServiceGetListClient struct {
+	grpc.ClientStream
+}
+
+func (x *mealServiceGetListClient) Recv() (*Meal, error) {
+	m := new(Meal)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *mealServiceClient) GetAll(ctx context.Context, in *GetAllRequest, opts ...grpc.CallOption) (MealService_GetAllClient, error) {
+	stream, err := c.cc.NewStream(ctx, &MealService_ServiceDesc.Streams[1], "/cookbook.v1.MealService/GetAll", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &mealServiceGetAllClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type MealService_GetAllClient interface {
+	Recv() (*Meal, error)
+	grpc.ClientStream
+}
+
+type mealServiceGetAllClient struct {
+	grpc.ClientStream
+}
+
+func (x *mealServiceGetAllClient) Recv() (*Meal, error) {
+	m := new(Meal)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *mealServiceClient) GetIdsByRecipes(ctx context.Context, in *GetListRequest, opts ...grpc.CallOption) (*IdMap, error) {
+	out := new(IdMap)
+	err := c.cc.Invoke(ctx, "/cookbook.v1.MealService/GetIdsByRecipes", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mealServiceClient) Create(ctx context.Context, in *MealCreate, opts ...grpc.CallOption) (*CreateResponse, error) {
+	out := new(CreateResponse)
+	err := c.cc.Invoke(ctx, "/cookbook.v1.MealService/Create", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mealServiceClient) Update(ctx context.Context, in *MealCreate, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/cookbook.v1.MealService/Update", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mealServiceClient) Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/cookbook.v1.MealService/Delete", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// MealServiceServer is the server API for MealService service.
+// All implementations must embed UnimplementedMealServiceServer
+// for forward compatibility
+type MealServiceServer interface {
+	Get(context.Context, *GetRequest) (*Meal, error)
+	GetList(*GetListRequest, MealService_GetListServer) error
+	GetAll(*GetAllRequest, MealService_GetAllServer) error
+	GetIdsByRecipes(context.Context, *GetListRequest) (*IdMap, error)
+	Create(context.Context, *MealCreate) (*CreateResponse, error)
+	Update(context.Context, *MealCreate) (*emptypb.Empty, error)
+	Delete(context.Context, *DeleteRequest) (*emptypb.Empty, error)
+	mustEmbedUnimplementedMealServiceServer()
+}
+
+// UnimplementedMealServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedMealServiceServer struct {
+}
+
+func (UnimplementedMealServiceServer) Get(context.Context, *GetRequest) (*Meal, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
+}
+func (UnimplementedMealServiceServer) GetList(*GetListRequest, MealService_GetListServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetList not implemented")
+}
+func (UnimplementedMealServiceServer) GetAll(*GetAllRequest, MealService_GetAllServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetAll not implemented")
+}
+func (UnimplementedMealServiceServer) GetIdsByRecipes(context.Context, *GetListRequest) (*IdMap, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetIdsByRecipes not implemented")
+}
+func (UnimplementedMealServiceServer) Create(context.Context, *MealCreate) (*CreateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Create not implemented")
+}
+func (UnimplementedMealServiceServer) Update(context.Context, *MealCreate) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Update not implemented")
+}
+func (UnimplementedMealServiceServer) Delete(context.Context, *DeleteRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
+}
+func (UnimplementedMealServiceServer) mustEmbedUnimplementedMealServiceServer() {}
+
+// UnsafeMealServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to MealServiceServer will
+// result in compilation errors.
+type UnsafeMealServiceServer interface {
+	mustEmbedUnimplementedMealServiceServer()
+}
+
+func RegisterMealServiceServer(s grpc.ServiceRegistrar, srv MealServiceServer) {
+	s.RegisterService(&MealService_ServiceDesc, srv)
+}
+
+func _MealService_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MealServiceServer).Get(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cookbook.v1.MealService/Get",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MealServiceServer).Get(ctx, req.(*GetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MealService_GetList_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetListRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(MealServiceServer).GetList(m, &mealServiceGetListServer{stream})
+}
+
+type MealService_GetListServer interface {
+	Send(*Meal) error
+	grpc.ServerStream
+}
+
+type mealServiceGetListServer struct {
+	grpc.ServerStream
+}
+
+func (x *mealServiceGetListServer) Send(m *Meal) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _MealService_GetAll_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetAllRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(MealServiceServer).GetAll(m, &mealServiceGetAllServer{stream})
+}
+
+type MealService_GetAllServer interface {
+	Send(*Meal) error
+	grpc.ServerStream
+}
+
+type mealServiceGetAllServer struct {
+	grpc.ServerStream
+}
+
+func (x *mealServiceGetAllServer) Send(m *Meal) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _MealService_GetIdsByRecipes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MealServiceServer).GetIdsByRecipes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cookbook.v1.MealService/GetIdsByRecipes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MealServiceServer).GetIdsByRecipes(ctx, req.(*GetListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MealService_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MealCreate)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MealServiceServer).Create(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cookbook.v1.MealService/Create",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MealServiceServer).Create(ctx, req.(*MealCreate))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MealService_Update_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MealCreate)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MealServiceServer).Update(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cookbook.v1.MealService/Update",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MealServiceServer).Update(ctx, req.(*MealCreate))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MealService_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MealServiceServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cookbook.v1.MealService/Delete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MealServiceServer).Delete(ctx, req.(*DeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// MealService_ServiceDesc is the grpc.ServiceDesc for MealService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var MealService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "cookbook.v1.MealService",
+	HandlerType: (*MealServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Get",
+			Handler:    _MealService_Get_Handler,
+		},
+		{
+			MethodName: "GetIdsByRecipes",
+			Handler:    _MealService_GetIdsByRecipes_Handler,
+		},
+		{
+			MethodName: "Create",
+			Handler:    _MealService_Create_Handler,
+		},
+		{
+			MethodName: "Update",
+			Handler:    _MealService_Update_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _MealService_Delete_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "GetList",
+			Handler:       _MealService_GetList_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "GetAll",
+			Handler:       _MealService_GetAll_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "cookbook.proto",
+}
+
+// MealPlanServiceClient is the client API for MealPlanService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type MealPlanServiceClient interface {
+	Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*MealPlan, error)
+	GetList(ctx context.Context, in *GetListRequest, opts ...grpc.CallOption) (MealPlanService_GetListClient, error)
+	GetAll(ctx context.Context, in *GetAllRequest, opts ...grpc.CallOption) (MealPlanService_GetAllClient, error)
+	GetIdsByMeals(ctx context.Context, in *GetListRequest, opts ...grpc.CallOption) (*IdMap, error)
+	GetCalendar(ctx context.Context, in *CalendarRequest, opts ...grpc.CallOption) (MealPlanService_GetCalendarClient, error)
+	Create(ctx context.Context, in *MealPlanCreate, opts ...grpc.CallOption) (*CreateResponse, error)
+	Update(ctx context.Context, in *MealPlanCreate, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+}
+
+type mealPlanServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewMealPlanServiceClient(cc grpc.ClientConnInterface) MealPlanServiceClient {
+	return &mealPlanServiceClient{cc}
+}
+
+func (c *mealPlanServiceClient) Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*MealPlan, error) {
+	out := new(MealPlan)
+	err := c.cc.Invoke(ctx, "/cookbook.v1.MealPlanService/Get", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mealPlanServiceClient) GetList(ctx context.Context, in *GetListRequest, opts ...grpc.CallOption) (MealPlanService_GetListClient, error) {
+	stream, err := c.cc.NewStream(ctx, &MealPlanService_ServiceDesc.Streams[0], "/cookbook.v1.MealPlanService/GetList", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &mealPlanServiceGetListClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type MealPlanService_GetListClient interface {
+	Recv() (*MealPlan, error)
+	grpc.ClientStream
+}
+
+type mealPlanServiceGetListClient struct {
+	grpc.ClientStream
+}
+
+func (x *mealPlanServiceGetListClient) Recv() (*MealPlan, error) {
+	m := new(MealPlan)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *mealPlanServiceClient) GetAll(ctx context.Context, in *GetAllRequest, opts ...grpc.CallOption) (MealPlanService_GetAllClient, error) {
+	stream, err := c.cc.NewStream(ctx, &MealPlanService_ServiceDesc.Streams[1], "/cookbook.v1.MealPlanService/GetAll", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &mealPlanServiceGetAllClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type MealPlanService_GetAllClient interface {
+	Recv() (*MealPlan, error)
+	grpc.ClientStream
+}
+
+type mealPlanServiceGetAllClient struct {
+	grpc.ClientStream
+}
+
+func (x *mealPlanServiceGetAllClient) Recv() (*MealPlan, error) {
+	m := new(MealPlan)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *mealPlanServiceClient) GetIdsByMeals(ctx context.Context, in *GetListRequest, opts ...grpc.CallOption) (*IdMap, error) {
+	out := new(IdMap)
+	err := c.cc.Invoke(ctx, "/cookbook.v1.MealPlanService/GetIdsByMeals", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mealPlanServiceClient) GetCalendar(ctx context.Context, in *CalendarRequest, opts ...grpc.CallOption) (MealPlanService_GetCalendarClient, error) {
+	stream, err := c.cc.NewStream(ctx, &MealPlanService_ServiceDesc.Streams[2], "/cookbook.v1.MealPlanService/GetCalendar", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &mealPlanServiceGetCalendarClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type MealPlanService_GetCalendarClient interface {
+	Recv() (*CalendarDay, error)
+	grpc.ClientStream
+}
+
+type mealPlanServiceGetCalendarClient struct {
+	grpc.ClientStream
+}
+
+func (x *mealPlanServiceGetCalendarClient) Recv() (*CalendarDay, error) {
+	m := new(CalendarDay)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *mealPlanServiceClient) Create(ctx context.Context, in *MealPlanCreate, opts ...grpc.CallOption) (*CreateResponse, error) {
+	out := new(CreateResponse)
+	err := c.cc.Invoke(ctx, "/cookbook.v1.MealPlanService/Create", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mealPlanServiceClient) Update(ctx context.Context, in *MealPlanCreate, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/cookbook.v1.MealPlanService/Update", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mealPlanServiceClient) Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/cookbook.v1.MealPlanService/Delete", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// MealPlanServiceServer is the server API for MealPlanService service.
+// All implementations must embed UnimplementedMealPlanServiceServer
+// for forward compatibility
+type MealPlanServiceServer interface {
+	Get(context.Context, *GetRequest) (*MealPlan, error)
+	GetList(*GetListRequest, MealPlanService_GetListServer) error
+	GetAll(*GetAllRequest, MealPlanService_GetAllServer) error
+	GetIdsByMeals(context.Context, *GetListRequest) (*IdMap, error)
+	GetCalendar(*CalendarRequest, MealPlanService_GetCalendarServer) error
+	Create(context.Context, *MealPlanCreate) (*CreateResponse, error)
+	Update(context.Context, *MealPlanCreate) (*emptypb.Empty, error)
+	Delete(context.Context, *DeleteRequest) (*emptypb.Empty, error)
+	mustEmbedUnimplementedMealPlanServiceServer()
+}
+
+// UnimplementedMealPlanServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedMealPlanServiceServer struct {
+}
+
+func (UnimplementedMealPlanServiceServer) Get(context.Context, *GetRequest) (*MealPlan, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
+}
+func (UnimplementedMealPlanServiceServer) GetList(*GetListRequest, MealPlanService_GetListServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetList not implemented")
+}
+func (UnimplementedMealPlanServiceServer) GetAll(*GetAllRequest, MealPlanService_GetAllServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetAll not implemented")
+}
+func (UnimplementedMealPlanServiceServer) GetIdsByMeals(context.Context, *GetListRequest) (*IdMap, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetIdsByMeals not implemented")
+}
+func (UnimplementedMealPlanServiceServer) GetCalendar(*CalendarRequest, MealPlanService_GetCalendarServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetCalendar not implemented")
+}
+func (UnimplementedMealPlanServiceServer) Create(context.Context, *MealPlanCreate) (*CreateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Create not implemented")
+}
+func (UnimplementedMealPlanServiceServer) Update(context.Context, *MealPlanCreate) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Update not implemented")
+}
+func (UnimplementedMealPlanServiceServer) Delete(context.Context, *DeleteRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
+}
+func (UnimplementedMealPlanServiceServer) mustEmbedUnimplementedMealPlanServiceServer() {}
+
+// UnsafeMealPlanServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to MealPlanServiceServer will
+// result in compilation errors.
+type UnsafeMealPlanServiceServer interface {
+	mustEmbedUnimplementedMealPlanServiceServer()
+}
+
+func RegisterMealPlanServiceServer(s grpc.ServiceRegistrar, srv MealPlanServiceServer) {
+	s.RegisterService(&MealPlanService_ServiceDesc, srv)
+}
+
+func _MealPlanService_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MealPlanServiceServer).Get(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cookbook.v1.MealPlanService/Get",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MealPlanServiceServer).Get(ctx, req.(*GetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MealPlanService_GetList_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetListRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(MealPlanServiceServer).GetList(m, &mealPlanServiceGetListServer{stream})
+}
+
+type MealPlanService_GetListServer interface {
+	Send(*MealPlan) error
+	grpc.ServerStream
+}
+
+type mealPlanServiceGetListServer struct {
+	grpc.ServerStream
+}
+
+func (x *mealPlanServiceGetListServer) Send(m *MealPlan) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _MealPlanService_GetAll_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetAllRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(MealPlanServiceServer).GetAll(m, &mealPlanServiceGetAllServer{stream})
+}
+
+type MealPlanService_GetAllServer interface {
+	Send(*MealPlan) error
+	grpc.ServerStream
+}
+
+type mealPlanServiceGetAllServer struct {
+	grpc.ServerStream
+}
+
+func (x *mealPlanServiceGetAllServer) Send(m *MealPlan) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _MealPlanService_GetIdsByMeals_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MealPlanServiceServer).GetIdsByMeals(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cookbook.v1.MealPlanService/GetIdsByMeals",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MealPlanServiceServer).GetIdsByMeals(ctx, req.(*GetListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MealPlanService_GetCalendar_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(CalendarRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(MealPlanServiceServer).GetCalendar(m, &mealPlanServiceGetCalendarServer{stream})
+}
+
+type MealPlanService_GetCalendarServer interface {
+	Send(*CalendarDay) error
+	grpc.ServerStream
+}
+
+type mealPlanServiceGetCalendarServer struct {
+	grpc.ServerStream
+}
+
+func (x *mealPlanServiceGetCalendarServer) Send(m *CalendarDay) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _MealPlanService_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MealPlanCreate)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MealPlanServiceServer).Create(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cookbook.v1.MealPlanService/Create",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MealPlanServiceServer).Create(ctx, req.(*MealPlanCreate))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MealPlanService_Update_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MealPlanCreate)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MealPlanServiceServer).Update(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cookbook.v1.MealPlanService/Update",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MealPlanServiceServer).Update(ctx, req.(*MealPlanCreate))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MealPlanService_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MealPlanServiceServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cookbook.v1.MealPlanService/Delete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MealPlanServiceServer).Delete(ctx, req.(*DeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// MealPlanService_ServiceDesc is the grpc.ServiceDesc for MealPlanService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var MealPlanService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "cookbook.v1.MealPlanService",
+	HandlerType: (*MealPlanServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Get",
+			Handler:    _MealPlanService_Get_Handler,
+		},
+		{
+			MethodName: "GetIdsByMeals",
+			Handler:    _MealPlanService_GetIdsByMeals_Handler,
+		},
+		{
+			MethodName: "Create",
+			Handler:    _MealPlanService_Create_Handler,
+		},
+		{
+			MethodName: "Update",
+			Handler:    _MealPlanService_Update_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _MealPlanService_Delete_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "GetList",
+			Handler:       _MealPlanService_GetList_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "GetAll",
+			Handler:       _MealPlanService_GetAll_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "GetCalendar",
+			Handler:       _MealPlanService_GetCalendar_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "cookbook.proto",
+}
@@ -0,0 +1,156 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/cookbook/rpc/pb"
+	"github.com/cookbook/service"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type RecipeServer struct {
+	pb.UnimplementedRecipeServiceServer
+	Service service.RecipeService
+}
+
+func (s RecipeServer) Get(ctx context.Context, req *pb.GetRequest) (*pb.Recipe, error) {
+	recipe, err := s.Service.Get(req.Id)
+	if err != nil {
+		return nil, handleError(err)
+	}
+	return recipeToPb(recipe), nil
+}
+
+func (s RecipeServer) GetList(req *pb.GetListRequest, stream pb.RecipeService_GetListServer) error {
+	recipes, err := s.Service.GetList(req.Ids)
+	if err != nil {
+		return handleError(err)
+	}
+	for _, recipe := range recipes {
+		if err := stream.Send(recipeToPb(recipe)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s RecipeServer) GetAll(req *pb.GetAllRequest, stream pb.RecipeService_GetAllServer) error {
+	recipes, err := s.Service.GetAll()
+	if err != nil {
+		return handleError(err)
+	}
+	for _, recipe := range recipes {
+		if err := stream.Send(recipeToPb(recipe)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s RecipeServer) GetIdsByIngredients(ctx context.Context, req *pb.GetListRequest) (*pb.IdMap, error) {
+	ids, err := s.Service.GetIdsByIngredients(req.Ids)
+	if err != nil {
+		return nil, handleError(err)
+	}
+	return idMapToPb(ids), nil
+}
+
+func (s RecipeServer) Create(ctx context.Context, req *pb.RecipeCreate) (*pb.CreateResponse, error) {
+	id, err := s.Service.Create(recipeFromPb(req))
+	if err != nil {
+		return nil, handleError(err)
+	}
+	return &pb.CreateResponse{Id: id}, nil
+}
+
+func (s RecipeServer) Update(ctx context.Context, req *pb.RecipeCreate) (*emptypb.Empty, error) {
+	err := s.Service.Update(recipeFromPb(req))
+	if err != nil {
+		return nil, handleError(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s RecipeServer) Delete(ctx context.Context, req *pb.DeleteRequest) (*emptypb.Empty, error) {
+	err := s.Service.Delete(req.Id, service.DeleteOptions{Cascade: req.Cascade, ReplaceWith: req.ReplaceWith})
+	if err != nil {
+		return nil, handleError(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func recipeToPb(r service.RecipeGet) *pb.Recipe {
+	recipe := &pb.Recipe{
+		Id:             r.Id,
+		Name:           r.Name,
+		Calories:       r.Calories,
+		Protein:        r.Protein,
+		Carbs:          r.Carbs,
+		Fat:            r.Fat,
+		Steps:          r.Steps,
+		PrepMinutes:    r.PrepMinutes,
+		CookMinutes:    r.CookMinutes,
+		Servings:       r.Servings,
+		Cost:           costToPb(r.Cost),
+		CostPerServing: r.CostPerServing,
+		Allergens:      r.Allergens,
+		Diets:          r.Diets,
+		Tags:           r.Tags,
+		RatingCount:    int32(r.RatingCount),
+		TimesCooked:    int32(r.TimesCooked),
+	}
+	if r.Rating != nil {
+		recipe.Rating = *r.Rating
+	}
+	if r.LastCooked != nil {
+		recipe.LastCooked = r.LastCooked.String()
+	}
+	for _, ing := range r.Ingredients {
+		recipe.Ingredients = append(recipe.Ingredients, ingredientToPb(ing))
+	}
+	for _, image := range r.Images {
+		recipe.Images = append(recipe.Images, recipeImageToPb(image))
+	}
+	return recipe
+}
+
+func recipeImageToPb(i service.RecipeImage) *pb.RecipeImage {
+	image := &pb.RecipeImage{
+		Id:          i.Id,
+		RecipeId:    i.RecipeId,
+		Step:        i.Step,
+		ContentType: i.ContentType,
+		Width:       i.Width,
+		Height:      i.Height,
+		Size:        i.Size,
+		Url:         i.Url,
+	}
+	for _, thumbnail := range i.Thumbnails {
+		image.Thumbnails = append(image.Thumbnails, &pb.Thumbnail{Size: thumbnail.Size, Width: thumbnail.Width, Height: thumbnail.Height, Url: thumbnail.Url})
+	}
+	return image
+}
+
+func recipeFromPb(r *pb.RecipeCreate) service.RecipeCreate {
+	recipe := service.RecipeCreate{
+		Id:          r.Id,
+		Name:        r.Name,
+		Steps:       r.Steps,
+		PrepMinutes: r.PrepMinutes,
+		CookMinutes: r.CookMinutes,
+		Servings:    r.Servings,
+		Tags:        r.Tags,
+	}
+	for _, ing := range r.Ingredients {
+		recipe.Ingredients = append(recipe.Ingredients, service.IngredientShort{
+			Id:     ing.Id,
+			Amount: ing.Amount,
+			Unit:   ing.Unit,
+		})
+	}
+	return recipe
+}
+
+func costToPb(c service.Cost) *pb.Cost {
+	return &pb.Cost{Total: c.Total, Unpriced: c.Unpriced}
+}
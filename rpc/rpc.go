@@ -0,0 +1,41 @@
+package rpc
+
+//go:generate protoc -I ../proto --go_out=pb --go_opt=paths=source_relative --go-grpc_out=pb --go-grpc_opt=paths=source_relative ../proto/cookbook.proto
+
+import (
+	"github.com/cookbook/rpc/pb"
+	"github.com/cookbook/service"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func NewServer(is service.IngredientService, rs service.RecipeService, ms service.MealService, mps service.MealPlanService) *grpc.Server {
+	s := grpc.NewServer()
+	pb.RegisterIngredientServiceServer(s, IngredientServer{Service: is})
+	pb.RegisterRecipeServiceServer(s, RecipeServer{Service: rs})
+	pb.RegisterMealServiceServer(s, MealServer{Service: ms})
+	pb.RegisterMealPlanServiceServer(s, MealPlanServer{Service: mps})
+	return s
+}
+
+func handleError(err error) error {
+	switch x := err.(type) {
+	case *service.NotFound:
+		return status.Error(codes.NotFound, x.Error())
+	case *service.ValidationError:
+		return status.Error(codes.InvalidArgument, x.Error())
+	case *service.Conflict:
+		return status.Error(codes.FailedPrecondition, x.Error())
+	default:
+		return status.Error(codes.Internal, "Internal server error, if the error persists contact server admin")
+	}
+}
+
+func idMapToPb(ids map[int64][]int64) *pb.IdMap {
+	idMap := &pb.IdMap{Ids: make(map[int64]*pb.Ids, len(ids))}
+	for key, list := range ids {
+		idMap.Ids[key] = &pb.Ids{Ids: list}
+	}
+	return idMap
+}
@@ -0,0 +1,80 @@
+package service
+
+import "time"
+
+// ArchiveFormat and ArchiveVersion identify archives written by Export,
+// imports accept archives up to the current version. Version 2 adds the
+// household members and the members meal plan entries are planned for.
+const (
+	ArchiveFormat  = "cookbook-archive"
+	ArchiveVersion = 2
+)
+
+// Files of an archive. Entities reference each other by the ids they have
+// in the archive, images are stored as uploaded below archiveImageDir.
+const (
+	archiveManifestFile    = "manifest.json"
+	archiveIngredientsFile = "ingredients.json"
+	archiveRecipesFile     = "recipes.json"
+	archiveMealsFile       = "meals.json"
+	archiveMealPlansFile   = "meal_plans.json"
+	archiveMembersFile     = "members.json"
+	archiveImageDir        = "images/"
+)
+
+// Policies for entities of an archive named like existing ones. Fail rejects
+// the import, skip keeps the existing entity and uses it in place of the
+// archived one, replace overwrites the existing entity and duplicate imports
+// the archived one next to it.
+const (
+	ConflictFail      = "fail"
+	ConflictSkip      = "skip"
+	ConflictReplace   = "replace"
+	ConflictDuplicate = "duplicate"
+)
+
+var ConflictPolicies = []string{ConflictFail, ConflictSkip, ConflictReplace, ConflictDuplicate}
+
+// ArchiveManifest describes the archive, Counts holds the number of entities
+// per file.
+type ArchiveManifest struct {
+	Format  string         `json:"format"`
+	Version int            `json:"version"`
+	Created time.Time      `json:"created"`
+	Counts  map[string]int `json:"counts"`
+}
+
+// ArchiveRecipe is a recipe as stored in an archive, along with its images.
+type ArchiveRecipe struct {
+	RecipeCreate
+	Images []ArchiveImage `json:"images"`
+}
+
+// ArchiveImage is an image of an archived recipe, File is its path in the
+// archive.
+type ArchiveImage struct {
+	Id          int64  `json:"id"`
+	Step        int32  `json:"step"`
+	ContentType string `json:"content_type"`
+	File        string `json:"file"`
+}
+
+// ImportResult counts the imported entities per type.
+type ImportResult struct {
+	Ingredients ImportedEntities `json:"ingredients"`
+	Recipes     ImportedEntities `json:"recipes"`
+	Meals       ImportedEntities `json:"meals"`
+	MealPlans   ImportedEntities `json:"meal_plans"`
+	Members     ImportedEntities `json:"members"`
+	Images      int              `json:"images"`
+}
+
+// ImportedEntities counts the entities of a type created, replaced and
+// skipped by an import. Ids maps their ids in the archive to the ids of the
+// entities they were imported as or skipped for.
+type ImportedEntities struct {
+	Created  int             `json:"created"`
+	Replaced int             `json:"replaced"`
+	Skipped  int             `json:"skipped"`
+	Ids      map[int64]int64 `json:"ids"`
+}
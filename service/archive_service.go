@@ -0,0 +1,750 @@
+package service
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxArchiveFileSize limits the size of the JSON files read from an archive.
+const maxArchiveFileSize = 64 << 20
+
+var imageExtensions = map[string]string{"image/jpeg": ".jpg", "image/png": ".png", "image/gif": ".gif"}
+
+type ArchiveService interface {
+	Export(w io.Writer) error
+	Import(r io.ReaderAt, size int64, policy string) (ImportResult, error)
+}
+
+type ArchiveServiceImpl struct {
+	ingService      IngredientService
+	rcpService      RecipeService
+	mealService     MealService
+	mealPlanService MealPlanService
+	imageService    RecipeImageService
+	memberService   MemberService
+}
+
+func NewArchiveService(is IngredientService, rs RecipeService, ms MealService, mps MealPlanService, ims RecipeImageService, mbs MemberService) ArchiveService {
+	return ArchiveServiceImpl{
+		ingService:      is,
+		rcpService:      rs,
+		mealService:     ms,
+		mealPlanService: mps,
+		imageService:    ims,
+		memberService:   mbs,
+	}
+}
+
+// Export writes a zip archive of all ingredients, recipes with their images,
+// meals, meal plans and members. Everything but the images is read before the
+// first byte is written.
+func (s ArchiveServiceImpl) Export(w io.Writer) error {
+	ingredients, err := s.ingService.GetAll()
+	if err != nil {
+		return err
+	}
+	rRecipes, err := s.rcpService.GetAll()
+	if err != nil {
+		return err
+	}
+	rMeals, err := s.mealService.GetAll()
+	if err != nil {
+		return err
+	}
+	rMealPlans, err := s.mealPlanService.GetAll()
+	if err != nil {
+		return err
+	}
+	rMembers, err := s.memberService.GetAll()
+	if err != nil {
+		return err
+	}
+
+	recipes := make([]ArchiveRecipe, len(rRecipes))
+	var images []ArchiveImage
+	for index, recipe := range rRecipes {
+		recipes[index] = archiveRecipe(recipe)
+		images = append(images, recipes[index].Images...)
+	}
+	meals := make([]MealCreate, len(rMeals))
+	for index, meal := range rMeals {
+		meals[index] = archiveMeal(meal)
+	}
+	mealPlans := make([]MealPlanCreate, len(rMealPlans))
+	for index, mealPlan := range rMealPlans {
+		mealPlans[index] = archiveMealPlan(mealPlan)
+	}
+	members := make([]MemberCreate, len(rMembers))
+	for index, member := range rMembers {
+		members[index] = archiveMember(member)
+	}
+
+	archive := zip.NewWriter(w)
+	files := []struct {
+		name  string
+		value interface{}
+	}{
+		{archiveManifestFile, ArchiveManifest{
+			Format:  ArchiveFormat,
+			Version: ArchiveVersion,
+			Created: time.Now().UTC(),
+			Counts: map[string]int{
+				"ingredients": len(ingredients),
+				"recipes":     len(recipes),
+				"meals":       len(meals),
+				"meal_plans":  len(mealPlans),
+				"members":     len(members),
+				"images":      len(images),
+			},
+		}},
+		{archiveIngredientsFile, ingredients},
+		{archiveRecipesFile, recipes},
+		{archiveMealsFile, meals},
+		{archiveMealPlansFile, mealPlans},
+		{archiveMembersFile, members},
+	}
+	for _, file := range files {
+		f, err := archive.Create(file.name)
+		if err != nil {
+			return &InternalError{"Failed to write archive: " + err.Error()}
+		}
+		encoder := json.NewEncoder(f)
+		encoder.SetIndent("", "  ")
+		if err = encoder.Encode(file.value); err != nil {
+			return &InternalError{"Failed to write archive: " + err.Error()}
+		}
+	}
+	for _, image := range images {
+		err = s.exportImage(archive, image)
+		if err != nil {
+			return err
+		}
+	}
+	if err = archive.Close(); err != nil {
+		return &InternalError{"Failed to write archive: " + err.Error()}
+	}
+	return nil
+}
+
+// exportImage stores the image as uploaded, without compressing it again.
+func (s ArchiveServiceImpl) exportImage(archive *zip.Writer, image ArchiveImage) error {
+	blob, _, err := s.imageService.Open(image.Id, "")
+	if err != nil {
+		return err
+	}
+	defer blob.Close()
+	f, err := archive.CreateHeader(&zip.FileHeader{Name: image.File, Method: zip.Store, Modified: time.Now()})
+	if err == nil {
+		_, err = io.Copy(f, blob)
+	}
+	if err != nil {
+		return &InternalError{"Failed to write archive: " + err.Error()}
+	}
+	return nil
+}
+
+// archiveRecipe returns the recipe as it's stored in an archive, and as it's
+// restored when an import replacing it fails.
+func archiveRecipe(recipe RecipeGet) ArchiveRecipe {
+	archived := ArchiveRecipe{
+		RecipeCreate: RecipeCreate{
+			Id:          recipe.Id,
+			Name:        recipe.Name,
+			Steps:       recipe.Steps,
+			PrepMinutes: recipe.PrepMinutes,
+			CookMinutes: recipe.CookMinutes,
+			Servings:    recipe.Servings,
+			Tags:        recipe.Tags,
+			Ingredients: []IngredientShort{},
+		},
+		Images: []ArchiveImage{},
+	}
+	for _, ing := range recipe.Ingredients {
+		archived.Ingredients = append(archived.Ingredients, IngredientShort{Id: ing.Id, Amount: ing.Quantity.Amount, Unit: ing.Quantity.Unit})
+	}
+	for _, image := range recipe.Images {
+		archived.Images = append(archived.Images, ArchiveImage{
+			Id:          image.Id,
+			Step:        image.Step,
+			ContentType: image.ContentType,
+			File:        archiveImageDir + strconv.FormatInt(image.Id, 10) + imageExtensions[image.ContentType],
+		})
+	}
+	return archived
+}
+
+func archiveMeal(meal MealGet) MealCreate {
+	archived := MealCreate{Id: meal.Id, Name: meal.Name, Recipes: []int64{}, Servings: meal.Servings}
+	for _, recipe := range meal.Recipes {
+		archived.Recipes = append(archived.Recipes, recipe.Id)
+	}
+	return archived
+}
+
+func archiveMealPlan(mealPlan MealPlanGet) MealPlanCreate {
+	archived := MealPlanCreate{
+		Id:          mealPlan.Id,
+		Name:        mealPlan.Name,
+		DateStarted: mealPlan.DateStarted,
+		Followed:    mealPlan.Followed,
+		Entries:     []MealPlanEntryCreate{},
+	}
+	for _, entry := range mealPlan.Entries {
+		archived.Entries = append(archived.Entries, MealPlanEntryCreate{
+			Date:     entry.Date,
+			Slot:     entry.Slot,
+			MealId:   entry.Meal.Id,
+			Servings: entry.Servings,
+			People:   entry.People,
+			Cooked:   entry.Cooked,
+			Batch:    entry.Batch,
+			Portions: entry.Portions,
+			Members:  entry.Members,
+		})
+	}
+	return archived
+}
+
+// archiveMember keeps the targets set for the member, computed ones follow
+// the profile.
+func archiveMember(member MemberGet) MemberCreate {
+	return MemberCreate{
+		Id:            member.Id,
+		Name:          member.Name,
+		BirthDate:     member.BirthDate,
+		Sex:           member.Sex,
+		Weight:        member.Weight,
+		Height:        member.Height,
+		ActivityLevel: member.ActivityLevel,
+		Goal:          member.Goal,
+		Targets:       member.CustomTargets,
+		Allergies:     member.Allergies,
+	}
+}
+
+// Import restores an archive written by Export. Entities get new ids and the
+// references between them are remapped. Those named like existing entities
+// of their type are handled as the conflict policy says. The whole archive is
+// validated before anything is written, if writing fails midway what was
+// written is undone: created entities and images are deleted and replaced
+// entities restored. Changes made by others to the same entities meanwhile
+// may be overwritten when undoing.
+func (s ArchiveServiceImpl) Import(r io.ReaderAt, size int64, policy string) (ImportResult, error) {
+	if policy == "" {
+		policy = ConflictFail
+	}
+	if !contains(ConflictPolicies, policy) {
+		return ImportResult{}, &ValidationError{fields: []FieldError{{"conflict", "Conflict policy must be one of " + strings.Join(ConflictPolicies, ", ")}}}
+	}
+	archive, err := zip.NewReader(r, size)
+	if err != nil {
+		return ImportResult{}, &ValidationError{fields: []FieldError{{"archive", "Archive could not be read: " + err.Error()}}}
+	}
+	files := make(map[string]*zip.File, len(archive.File))
+	for _, f := range archive.File {
+		files[f.Name] = f
+	}
+	var manifest ArchiveManifest
+	if err = readArchiveJson(files, archiveManifestFile, &manifest); err != nil {
+		return ImportResult{}, err
+	}
+	if manifest.Format != ArchiveFormat || manifest.Version < 1 || manifest.Version > ArchiveVersion {
+		return ImportResult{}, &ValidationError{fields: []FieldError{{archiveManifestFile, fmt.Sprintf("Archive must be a %s of version 1 to %d, got %s version %d", ArchiveFormat, ArchiveVersion, manifest.Format, manifest.Version)}}}
+	}
+	var ingredients []Ingredient
+	var recipes []ArchiveRecipe
+	var meals []MealCreate
+	var mealPlans []MealPlanCreate
+	var members []MemberCreate
+	for _, file := range []struct {
+		name  string
+		value interface{}
+	}{
+		{archiveIngredientsFile, &ingredients},
+		{archiveRecipesFile, &recipes},
+		{archiveMealsFile, &meals},
+		{archiveMealPlansFile, &mealPlans},
+	} {
+		if err = readArchiveJson(files, file.name, file.value); err != nil {
+			return ImportResult{}, err
+		}
+	}
+	// Archives of version 1 have no members.
+	if manifest.Version >= 2 {
+		if err = readArchiveJson(files, archiveMembersFile, &members); err != nil {
+			return ImportResult{}, err
+		}
+	}
+	if fields := validateArchive(files, ingredients, recipes, meals, mealPlans, members); len(fields) > 0 {
+		return ImportResult{}, &ValidationError{fields: fields}
+	}
+
+	existing, err := s.existingNames()
+	if err != nil {
+		return ImportResult{}, err
+	}
+	if policy == ConflictFail {
+		if err = archiveConflicts(existing, ingredients, recipes, meals, mealPlans, members); err != nil {
+			return ImportResult{}, err
+		}
+	}
+
+	// What was written is undone if the import fails, so it imports all of
+	// the archive or nothing. The images of replaced recipes are deleted once
+	// everything else is written.
+	var undo importUndo
+	var replacedImages []int64
+	result := ImportResult{
+		Ingredients: ImportedEntities{Ids: map[int64]int64{}},
+		Recipes:     ImportedEntities{Ids: map[int64]int64{}},
+		Meals:       ImportedEntities{Ids: map[int64]int64{}},
+		MealPlans:   ImportedEntities{Ids: map[int64]int64{}},
+		Members:     ImportedEntities{Ids: map[int64]int64{}},
+	}
+	for _, ing := range ingredients {
+		ing := ing
+		archiveId := ing.Id
+		_, err = importEntity(&result.Ingredients, archiveId, existing.ingredients, ing.Name, policy, func() (int64, error) {
+			ing.Id = 0
+			id, err := s.ingService.Create(ing)
+			if err == nil {
+				undo.add(func() error { return s.ingService.Delete(id, DeleteOptions{}) })
+			}
+			return id, err
+		}, func(id int64) error {
+			current, err := s.ingService.Get(id)
+			if err != nil {
+				return err
+			}
+			ing.Id = id
+			if err = s.ingService.Update(ing); err != nil {
+				return err
+			}
+			undo.add(func() error { return s.ingService.Update(current) })
+			return nil
+		})
+		if err != nil {
+			return ImportResult{}, undo.revert(importError("ingredient", archiveId, err))
+		}
+	}
+	for _, recipe := range recipes {
+		create := recipe.RecipeCreate
+		create.Ingredients = make([]IngredientShort, len(recipe.Ingredients))
+		for index, ing := range recipe.Ingredients {
+			ing.Id = result.Ingredients.Ids[ing.Id]
+			create.Ingredients[index] = ing
+		}
+		skipped, err := importEntity(&result.Recipes, recipe.Id, existing.recipes, recipe.Name, policy, func() (int64, error) {
+			create.Id = 0
+			id, err := s.rcpService.Create(create)
+			if err == nil {
+				undo.add(func() error { return s.rcpService.Delete(id, DeleteOptions{}) })
+			}
+			return id, err
+		}, func(id int64) error {
+			current, err := s.rcpService.Get(id)
+			if err != nil {
+				return err
+			}
+			create.Id = id
+			if err = s.rcpService.Update(create); err != nil {
+				return err
+			}
+			undo.add(func() error { return s.rcpService.Update(archiveRecipe(current).RecipeCreate) })
+			// The archived images replace those of the existing recipe.
+			for _, image := range current.Images {
+				replacedImages = append(replacedImages, image.Id)
+			}
+			return nil
+		})
+		if err != nil {
+			return ImportResult{}, undo.revert(importError("recipe", recipe.Id, err))
+		}
+		if skipped {
+			continue
+		}
+		for _, image := range recipe.Images {
+			imported, err := s.importImage(files[image.File], result.Recipes.Ids[recipe.Id], image.Step)
+			if err != nil {
+				return ImportResult{}, undo.revert(importError("image", image.Id, err))
+			}
+			undo.add(func() error { return s.imageService.Delete(imported.Id) })
+			result.Images++
+		}
+	}
+	for _, meal := range meals {
+		create := meal
+		create.Recipes = make([]int64, len(meal.Recipes))
+		for index, id := range meal.Recipes {
+			create.Recipes[index] = result.Recipes.Ids[id]
+		}
+		create.Servings = make(map[int64]float32, len(meal.Servings))
+		for id, servings := range meal.Servings {
+			create.Servings[result.Recipes.Ids[id]] = servings
+		}
+		_, err = importEntity(&result.Meals, meal.Id, existing.meals, meal.Name, policy, func() (int64, error) {
+			create.Id = 0
+			id, err := s.mealService.Create(create)
+			if err == nil {
+				undo.add(func() error { return s.mealService.Delete(id, DeleteOptions{}) })
+			}
+			return id, err
+		}, func(id int64) error {
+			current, err := s.mealService.Get(id)
+			if err != nil {
+				return err
+			}
+			create.Id = id
+			if err = s.mealService.Update(create); err != nil {
+				return err
+			}
+			undo.add(func() error { return s.mealService.Update(archiveMeal(current)) })
+			return nil
+		})
+		if err != nil {
+			return ImportResult{}, undo.revert(importError("meal", meal.Id, err))
+		}
+	}
+	for _, member := range members {
+		member := member
+		archiveId := member.Id
+		_, err = importEntity(&result.Members, archiveId, existing.members, member.Name, policy, func() (int64, error) {
+			member.Id = 0
+			id, err := s.memberService.Create(member)
+			if err == nil {
+				undo.add(func() error { return s.memberService.Delete(id) })
+			}
+			return id, err
+		}, func(id int64) error {
+			current, err := s.memberService.Get(id)
+			if err != nil {
+				return err
+			}
+			member.Id = id
+			if err = s.memberService.Update(member); err != nil {
+				return err
+			}
+			undo.add(func() error { return s.memberService.Update(archiveMember(current)) })
+			return nil
+		})
+		if err != nil {
+			return ImportResult{}, undo.revert(importError("member", archiveId, err))
+		}
+	}
+	for _, mealPlan := range mealPlans {
+		create := mealPlan
+		create.Entries = make([]MealPlanEntryCreate, len(mealPlan.Entries))
+		for index, entry := range mealPlan.Entries {
+			entry.MealId = result.Meals.Ids[entry.MealId]
+			if entry.Members != nil {
+				memberIds := make([]int64, len(entry.Members))
+				for i, id := range entry.Members {
+					memberIds[i] = result.Members.Ids[id]
+				}
+				entry.Members = memberIds
+			}
+			create.Entries[index] = entry
+		}
+		_, err = importEntity(&result.MealPlans, mealPlan.Id, existing.mealPlans, mealPlan.Name, policy, func() (int64, error) {
+			create.Id = 0
+			id, err := s.mealPlanService.Create(create)
+			if err == nil {
+				undo.add(func() error { return s.mealPlanService.Delete(id) })
+			}
+			return id, err
+		}, func(id int64) error {
+			current, err := s.mealPlanService.Get(id)
+			if err != nil {
+				return err
+			}
+			create.Id = id
+			if err = s.mealPlanService.Update(create); err != nil {
+				return err
+			}
+			undo.add(func() error { return s.mealPlanService.Update(archiveMealPlan(current)) })
+			return nil
+		})
+		if err != nil {
+			return ImportResult{}, undo.revert(importError("meal plan", mealPlan.Id, err))
+		}
+	}
+	// The import is complete at this point, images that fail to be deleted
+	// are left on their recipe.
+	for _, id := range replacedImages {
+		if err = s.imageService.Delete(id); err != nil {
+			log.Printf("Failed to delete image %d of a replaced recipe: %v", id, err)
+		}
+	}
+	return result, nil
+}
+
+func (s ArchiveServiceImpl) importImage(f *zip.File, recipeId int64, step int32) (RecipeImage, error) {
+	data, err := f.Open()
+	if err != nil {
+		return RecipeImage{}, &ValidationError{fields: []FieldError{{f.Name, "Image could not be read: " + err.Error()}}}
+	}
+	defer data.Close()
+	return s.imageService.Upload(recipeId, step, data)
+}
+
+// importError reports which entity of the archive failed to import.
+func importError(entity string, archiveId int64, err error) error {
+	log.Printf("Import stopped at %s %d of the archive: %v", entity, archiveId, err)
+	var validation *ValidationError
+	if errors.As(err, &validation) {
+		return err
+	}
+	return &InternalError{fmt.Sprintf("Import stopped at %s %d of the archive, nothing was imported: %v", entity, archiveId, err)}
+}
+
+// importUndo holds the steps undoing what an import wrote, in the order it
+// was written.
+type importUndo []func() error
+
+func (u *importUndo) add(step func() error) {
+	*u = append(*u, step)
+}
+
+// revert runs the steps newest first, so entities are deleted before those
+// they reference, and returns err. Steps that fail are logged and the others
+// still run.
+func (u importUndo) revert(err error) error {
+	for index := len(u) - 1; index >= 0; index-- {
+		if undoErr := u[index](); undoErr != nil {
+			log.Printf("Failed to undo the import: %v", undoErr)
+		}
+	}
+	return err
+}
+
+// importEntity creates the archived entity, or if one of its type is named
+// the same, skips or replaces it as the policy says. The id it's imported as
+// is recorded for the archive id, skipped reports if the existing entity was
+// kept instead.
+func importEntity(entities *ImportedEntities, archiveId int64, existing nameIndex, name, policy string, create func() (int64, error), replace func(id int64) error) (skipped bool, err error) {
+	id, exists := existing.find(name)
+	switch {
+	case exists && policy == ConflictSkip:
+		entities.Skipped++
+		skipped = true
+	case exists && policy == ConflictReplace:
+		if err = replace(id); err != nil {
+			return false, err
+		}
+		entities.Replaced++
+	default:
+		if id, err = create(); err != nil {
+			return false, err
+		}
+		entities.Created++
+	}
+	entities.Ids[archiveId] = id
+	return skipped, nil
+}
+
+// nameIndex maps the names of entities of a type to their ids, ignoring case.
+type nameIndex map[string]int64
+
+func (n nameIndex) add(name string, id int64) {
+	key := strings.ToLower(strings.TrimSpace(name))
+	if _, ok := n[key]; !ok {
+		n[key] = id
+	}
+}
+
+func (n nameIndex) find(name string) (int64, bool) {
+	id, ok := n[strings.ToLower(strings.TrimSpace(name))]
+	return id, ok
+}
+
+type existingNames struct {
+	ingredients, recipes, meals, mealPlans, members nameIndex
+}
+
+func (s ArchiveServiceImpl) existingNames() (existingNames, error) {
+	names := existingNames{nameIndex{}, nameIndex{}, nameIndex{}, nameIndex{}, nameIndex{}}
+	ingredients, err := s.ingService.GetAll()
+	if err != nil {
+		return names, err
+	}
+	for _, ing := range ingredients {
+		names.ingredients.add(ing.Name, ing.Id)
+	}
+	recipes, err := s.rcpService.GetAll()
+	if err != nil {
+		return names, err
+	}
+	for _, recipe := range recipes {
+		names.recipes.add(recipe.Name, recipe.Id)
+	}
+	meals, err := s.mealService.GetAll()
+	if err != nil {
+		return names, err
+	}
+	for _, meal := range meals {
+		names.meals.add(meal.Name, meal.Id)
+	}
+	mealPlans, err := s.mealPlanService.GetAll()
+	if err != nil {
+		return names, err
+	}
+	for _, mealPlan := range mealPlans {
+		names.mealPlans.add(mealPlan.Name, mealPlan.Id)
+	}
+	members, err := s.memberService.GetAll()
+	if err != nil {
+		return names, err
+	}
+	for _, member := range members {
+		names.members.add(member.Name, member.Id)
+	}
+	return names, nil
+}
+
+// maxListedConflicts limits the conflicts named in the error of the fail
+// policy.
+const maxListedConflicts = 10
+
+func archiveConflicts(existing existingNames, ingredients []Ingredient, recipes []ArchiveRecipe, meals []MealCreate, mealPlans []MealPlanCreate, members []MemberCreate) error {
+	var conflicts []string
+	check := func(index nameIndex, entity, name string) {
+		if _, ok := index.find(name); ok {
+			conflicts = append(conflicts, fmt.Sprintf("%s %q", entity, name))
+		}
+	}
+	for _, ing := range ingredients {
+		check(existing.ingredients, "ingredient", ing.Name)
+	}
+	for _, recipe := range recipes {
+		check(existing.recipes, "recipe", recipe.Name)
+	}
+	for _, meal := range meals {
+		check(existing.meals, "meal", meal.Name)
+	}
+	for _, mealPlan := range mealPlans {
+		check(existing.mealPlans, "meal plan", mealPlan.Name)
+	}
+	for _, member := range members {
+		check(existing.members, "member", member.Name)
+	}
+	if len(conflicts) == 0 {
+		return nil
+	}
+	listed := conflicts
+	if len(listed) > maxListedConflicts {
+		listed = append(listed[:maxListedConflicts:maxListedConflicts], fmt.Sprintf("%d more", len(conflicts)-maxListedConflicts))
+	}
+	return &Conflict{message: fmt.Sprintf("The archive contains entities named like existing ones: %s. Import with conflict policy %s, %s or %s",
+		strings.Join(listed, ", "), ConflictSkip, ConflictReplace, ConflictDuplicate)}
+}
+
+func readArchiveJson(files map[string]*zip.File, name string, v interface{}) error {
+	f, ok := files[name]
+	if !ok {
+		return &ValidationError{fields: []FieldError{{name, "Missing from the archive"}}}
+	}
+	data, err := f.Open()
+	if err != nil {
+		return &ValidationError{fields: []FieldError{{name, "Could not be read: " + err.Error()}}}
+	}
+	defer data.Close()
+	if err = json.NewDecoder(io.LimitReader(data, maxArchiveFileSize)).Decode(v); err != nil {
+		return &ValidationError{fields: []FieldError{{name, "Invalid JSON: " + err.Error()}}}
+	}
+	return nil
+}
+
+// validateArchive checks the archived entities as they would be on creation,
+// and that their references and images are part of the archive.
+func validateArchive(files map[string]*zip.File, ingredients []Ingredient, recipes []ArchiveRecipe, meals []MealCreate, mealPlans []MealPlanCreate, members []MemberCreate) []FieldError {
+	var fields []FieldError
+	ids := func(name string, count int, id func(int) int64) map[int64]bool {
+		seen := make(map[int64]bool, count)
+		for index := 0; index < count; index++ {
+			if seen[id(index)] {
+				fields = append(fields, FieldError{fmt.Sprintf("%s[%d].id", name, index), fmt.Sprintf("Id %d is used more than once", id(index))})
+			}
+			seen[id(index)] = true
+		}
+		return seen
+	}
+	ingredientIds := ids("ingredients", len(ingredients), func(i int) int64 { return ingredients[i].Id })
+	recipeIds := ids("recipes", len(recipes), func(i int) int64 { return recipes[i].Id })
+	mealIds := ids("meals", len(meals), func(i int) int64 { return meals[i].Id })
+	ids("meal_plans", len(mealPlans), func(i int) int64 { return mealPlans[i].Id })
+	memberIds := ids("members", len(members), func(i int) int64 { return members[i].Id })
+
+	for index, ing := range ingredients {
+		fields = append(fields, prefixFields(fmt.Sprintf("ingredients[%d]", index), validateIngredient(ing))...)
+	}
+	for index, recipe := range recipes {
+		prefix := fmt.Sprintf("recipes[%d]", index)
+		fields = append(fields, prefixFields(prefix, validateRecipe(recipe.RecipeCreate))...)
+		for i, ing := range recipe.Ingredients {
+			if !ingredientIds[ing.Id] {
+				fields = append(fields, FieldError{fmt.Sprintf("%s.ingredients[%d].id", prefix, i), fmt.Sprintf("Ingredient %d isn't part of the archive", ing.Id)})
+			}
+		}
+		steps := len(SplitSteps(recipe.Steps))
+		for i, image := range recipe.Images {
+			field := fmt.Sprintf("%s.images[%d]", prefix, i)
+			f, ok := files[image.File]
+			switch {
+			case !strings.HasPrefix(image.File, archiveImageDir) || !ok:
+				fields = append(fields, FieldError{field + ".file", fmt.Sprintf("Image %s isn't part of the archive", image.File)})
+			case f.UncompressedSize64 > MaxImageSize:
+				fields = append(fields, FieldError{field + ".file", fmt.Sprintf("Image must not be larger than %d MB", MaxImageSize>>20)})
+			}
+			if image.Step < 0 || int(image.Step) > steps {
+				fields = append(fields, FieldError{field + ".step", fmt.Sprintf("Recipe has no step %d", image.Step)})
+			}
+		}
+	}
+	for index, meal := range meals {
+		prefix := fmt.Sprintf("meals[%d]", index)
+		fields = append(fields, prefixFields(prefix, validateMeal(meal))...)
+		for i, id := range meal.Recipes {
+			if !recipeIds[id] {
+				fields = append(fields, FieldError{fmt.Sprintf("%s.recipes[%d]", prefix, i), fmt.Sprintf("Recipe %d isn't part of the archive", id)})
+			}
+		}
+	}
+	for index, mealPlan := range mealPlans {
+		prefix := fmt.Sprintf("meal_plans[%d]", index)
+		fields = append(fields, prefixFields(prefix, validateMealPlan(mealPlan))...)
+		for i, entry := range mealPlan.Entries {
+			if !mealIds[entry.MealId] {
+				fields = append(fields, FieldError{fmt.Sprintf("%s.entries[%d].meal_id", prefix, i), fmt.Sprintf("Meal %d isn't part of the archive", entry.MealId)})
+			}
+			for j, id := range entry.Members {
+				if !memberIds[id] {
+					fields = append(fields, FieldError{fmt.Sprintf("%s.entries[%d].members[%d]", prefix, i, j), fmt.Sprintf("Member %d isn't part of the archive", id)})
+				}
+			}
+		}
+	}
+	for index, member := range members {
+		fields = append(fields, prefixFields(fmt.Sprintf("members[%d]", index), validateMember(member))...)
+	}
+	return fields
+}
+
+// prefixFields returns the fields of a validation error below the prefix.
+func prefixFields(prefix string, err error) []FieldError {
+	var validation *ValidationError
+	if !errors.As(err, &validation) {
+		return nil
+	}
+	fields := make([]FieldError, len(validation.fields))
+	for index, field := range validation.fields {
+		fields[index] = FieldError{prefix + "." + field.Field, field.Message}
+	}
+	return fields
+}
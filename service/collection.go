@@ -0,0 +1,17 @@
+package service
+
+// CollectionCreate is a named collection of recipes, a cookbook. Recipes are
+// listed in the order they should be shown.
+type CollectionCreate struct {
+	Id          int64   `json:"id"`
+	Name        string  `json:"name" validate:"required"`
+	Description string  `json:"description"`
+	Recipes     []int64 `json:"recipes"`
+}
+
+type CollectionGet struct {
+	Id          int64       `json:"id"`
+	Name        string      `json:"name"`
+	Description string      `json:"description"`
+	Recipes     []RecipeGet `json:"recipes"`
+}
@@ -0,0 +1,146 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/cookbook/repository"
+)
+
+type CollectionService interface {
+	Get(int64) (CollectionGet, error)
+	GetAll() ([]CollectionGet, error)
+	Create(CollectionCreate) (int64, error)
+	Update(CollectionCreate) error
+	Delete(int64) error
+}
+
+type CollectionServiceImpl struct {
+	repo       *repository.CollectionRepository
+	rcpService RecipeService
+}
+
+func NewCollectionService(r *repository.CollectionRepository, rs RecipeService) CollectionService {
+	return CollectionServiceImpl{
+		repo:       r,
+		rcpService: rs,
+	}
+}
+
+func (s CollectionServiceImpl) Get(id int64) (CollectionGet, error) {
+	rCollection, err := s.repo.Get(id)
+	if err != nil {
+		return CollectionGet{}, handleError(err)
+	}
+	collections, err := s.convertRepoModel(rCollection)
+	if err != nil {
+		return CollectionGet{}, err
+	}
+	return collections[0], nil
+}
+
+func (s CollectionServiceImpl) GetAll() ([]CollectionGet, error) {
+	rCollections, err := s.repo.GetAll()
+	if err != nil {
+		return []CollectionGet{}, handleError(err)
+	}
+	return s.convertRepoModel(rCollections...)
+}
+
+func (s CollectionServiceImpl) Create(collection CollectionCreate) (int64, error) {
+	err := s.validate(collection)
+	if err != nil {
+		return 0, err
+	}
+	id, err := s.repo.Create(toRepoCollection(collection))
+	if err != nil {
+		return 0, handleError(err)
+	}
+	return id, nil
+}
+
+func (s CollectionServiceImpl) Update(collection CollectionCreate) error {
+	err := s.validate(collection)
+	if err != nil {
+		return err
+	}
+	err = s.repo.Update(toRepoCollection(collection))
+	if err != nil {
+		return handleError(err)
+	}
+	return nil
+}
+
+func (s CollectionServiceImpl) Delete(id int64) error {
+	err := s.repo.Delete(id)
+	if err != nil {
+		return handleError(err)
+	}
+	return nil
+}
+
+func (s CollectionServiceImpl) convertRepoModel(repoCollections ...repository.Collection) ([]CollectionGet, error) {
+	var ids []int64
+	for _, rCollection := range repoCollections {
+		ids = append(ids, rCollection.Recipes...)
+	}
+	recipes := make(map[int64]RecipeGet)
+	if len(ids) > 0 {
+		list, err := s.rcpService.GetList(ids)
+		if err != nil {
+			return []CollectionGet{}, err
+		}
+		for _, recipe := range list {
+			recipes[recipe.Id] = recipe
+		}
+	}
+	collections := make([]CollectionGet, len(repoCollections))
+	for index, rCollection := range repoCollections {
+		collections[index] = CollectionGet{
+			Id:          rCollection.Id,
+			Name:        rCollection.Name,
+			Description: rCollection.Description,
+			Recipes:     make([]RecipeGet, 0, len(rCollection.Recipes)),
+		}
+		for _, recipeId := range rCollection.Recipes {
+			collections[index].Recipes = append(collections[index].Recipes, recipes[recipeId])
+		}
+	}
+	return collections, nil
+}
+
+func toRepoCollection(collection CollectionCreate) repository.Collection {
+	return repository.Collection{
+		Id:          collection.Id,
+		Name:        collection.Name,
+		Description: collection.Description,
+		Recipes:     append([]int64{}, collection.Recipes...),
+	}
+}
+
+func (s CollectionServiceImpl) validate(collection CollectionCreate) error {
+	err := validateCollection(collection)
+	if err != nil {
+		return err
+	}
+	return referenceError(collection.Recipes, "Recipe", func(index int) string {
+		return fmt.Sprintf("recipes[%d]", index)
+	}, s.rcpService.GetExistingIds)
+}
+
+func validateCollection(collection CollectionCreate) error {
+	var fields []FieldError
+	if collection.Name == "" {
+		fields = append(fields, FieldError{"name", "Collection name must be provided"})
+	}
+	seen := make(map[int64]bool)
+	for index, recipeId := range collection.Recipes {
+		if seen[recipeId] {
+			fields = append(fields, FieldError{fmt.Sprintf("recipes[%d]", index), fmt.Sprintf("Recipe %d is already in the collection", recipeId)})
+		}
+		seen[recipeId] = true
+	}
+	if len(fields) > 0 {
+		return &ValidationError{fields: fields}
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package service
+
+import (
+	"github.com/cookbook/repository"
+)
+
+type CookLogService interface {
+	Get(int64) (CookLogEntry, error)
+	GetAll(recipeId int64) ([]CookLogEntry, error)
+	Create(CookLogEntry) (int64, error)
+	Update(CookLogEntry) error
+	Delete(int64) error
+}
+
+type CookLogServiceImpl struct {
+	repo       *repository.CookLogRepository
+	rcpService RecipeService
+}
+
+func NewCookLogService(r *repository.CookLogRepository, rs RecipeService) CookLogService {
+	return CookLogServiceImpl{
+		repo:       r,
+		rcpService: rs,
+	}
+}
+
+func (s CookLogServiceImpl) Get(id int64) (CookLogEntry, error) {
+	rEntry, err := s.repo.Get(id)
+	if err != nil {
+		return CookLogEntry{}, handleError(err)
+	}
+	return convertRepoCookLogEntry(rEntry), nil
+}
+
+// GetAll returns the cook log of the recipe, or of all recipes if recipeId is
+// 0, the most recent first.
+func (s CookLogServiceImpl) GetAll(recipeId int64) ([]CookLogEntry, error) {
+	rEntries, err := s.repo.GetAll(recipeId)
+	if err != nil {
+		return []CookLogEntry{}, handleError(err)
+	}
+	entries := make([]CookLogEntry, len(rEntries))
+	for index, rEntry := range rEntries {
+		entries[index] = convertRepoCookLogEntry(rEntry)
+	}
+	return entries, nil
+}
+
+func (s CookLogServiceImpl) Create(entry CookLogEntry) (int64, error) {
+	err := s.validate(entry)
+	if err != nil {
+		return 0, err
+	}
+	id, err := s.repo.Create(toRepoCookLogEntry(entry))
+	if err != nil {
+		return 0, handleError(err)
+	}
+	return id, nil
+}
+
+func (s CookLogServiceImpl) Update(entry CookLogEntry) error {
+	err := s.validate(entry)
+	if err != nil {
+		return err
+	}
+	err = s.repo.Update(toRepoCookLogEntry(entry))
+	if err != nil {
+		return handleError(err)
+	}
+	return nil
+}
+
+func (s CookLogServiceImpl) Delete(id int64) error {
+	err := s.repo.Delete(id)
+	if err != nil {
+		return handleError(err)
+	}
+	return nil
+}
+
+func convertRepoCookLogEntry(rEntry repository.CookLogEntry) CookLogEntry {
+	return CookLogEntry{
+		Id:            rEntry.Id,
+		RecipeId:      rEntry.RecipeId,
+		Date:          NewDate(rEntry.Date),
+		Servings:      rEntry.Servings,
+		Notes:         rEntry.Notes,
+		Modifications: rEntry.Modifications,
+	}
+}
+
+func toRepoCookLogEntry(entry CookLogEntry) repository.CookLogEntry {
+	servings := entry.Servings
+	if servings == 0 {
+		servings = 1
+	}
+	return repository.CookLogEntry{
+		Id:            entry.Id,
+		RecipeId:      entry.RecipeId,
+		Date:          entry.Date.Time,
+		Servings:      servings,
+		Notes:         entry.Notes,
+		Modifications: entry.Modifications,
+	}
+}
+
+func (s CookLogServiceImpl) validate(entry CookLogEntry) error {
+	err := validateCookLogEntry(entry)
+	if err != nil {
+		return err
+	}
+	return referenceError([]int64{entry.RecipeId}, "Recipe", func(int) string {
+		return "recipe_id"
+	}, s.rcpService.GetExistingIds)
+}
+
+func validateCookLogEntry(entry CookLogEntry) error {
+	var fields []FieldError
+	if entry.Date.IsZero() {
+		fields = append(fields, FieldError{"date", "Date must be provided"})
+	}
+	if entry.Servings < 0 {
+		fields = append(fields, FieldError{"servings", "Servings must not be negative"})
+	}
+	if len(fields) > 0 {
+		return &ValidationError{fields: fields}
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package service
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const dateLayout = "2006-01-02"
+
+// Date is a calendar day, encoded in JSON as YYYY-MM-DD.
+type Date struct {
+	time.Time
+}
+
+func NewDate(t time.Time) Date {
+	year, month, day := t.Date()
+	return Date{time.Date(year, month, day, 0, 0, 0, 0, time.UTC)}
+}
+
+func ParseDate(s string) (Date, error) {
+	t, err := time.Parse(dateLayout, s)
+	if err != nil {
+		return Date{}, err
+	}
+	return Date{t}, nil
+}
+
+func (d Date) String() string {
+	return d.Format(dateLayout)
+}
+
+func (d Date) AddDays(days int) Date {
+	return Date{d.AddDate(0, 0, days)}
+}
+
+// DaysSince returns the number of days from start to d.
+func (d Date) DaysSince(start Date) int {
+	return int(d.Sub(start.Time).Hours() / 24)
+}
+
+func (d Date) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+func (d *Date) UnmarshalJSON(b []byte) error {
+	var s string
+	err := json.Unmarshal(b, &s)
+	if err != nil {
+		return err
+	}
+	*d, err = ParseDate(s)
+	return err
+}
@@ -0,0 +1,141 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Allergens are the union of the 14 allergens labelled in the EU and the US
+// major food allergens. Gluten covers all cereals containing it, wheat is
+// listed separately as the US labels it on its own.
+var Allergens = []string{
+	"celery", "crustacean", "egg", "fish", "gluten", "lupin", "milk", "mollusc",
+	"mustard", "peanut", "sesame", "soy", "sulphite", "tree_nut", "wheat",
+}
+
+// Diets are the diets an ingredient can be compatible with.
+var Diets = []string{"halal", "kosher", "pescatarian", "vegan", "vegetarian"}
+
+// impliedDiets lists the diets compatible with everything a diet allows.
+var impliedDiets = map[string][]string{
+	"vegan":      {"vegetarian", "pescatarian"},
+	"vegetarian": {"pescatarian"},
+}
+
+// DietFilter selects entities containing none of ExcludeAllergens that are
+// compatible with all of Diets.
+type DietFilter struct {
+	ExcludeAllergens []string
+	Diets            []string
+}
+
+func (f DietFilter) IsEmpty() bool {
+	return len(f.ExcludeAllergens) == 0 && len(f.Diets) == 0
+}
+
+// Matches reports whether something with the allergens and diet labels passes
+// the filter.
+func (f DietFilter) Matches(allergens, diets []string) bool {
+	for _, allergen := range f.ExcludeAllergens {
+		if contains(allergens, allergen) {
+			return false
+		}
+	}
+	for _, diet := range f.Diets {
+		if !contains(diets, diet) {
+			return false
+		}
+	}
+	return true
+}
+
+// Validate returns a ValidationError naming the query parameters holding
+// unknown allergens or diets.
+func (f DietFilter) Validate() error {
+	var fields []FieldError
+	for _, allergen := range f.ExcludeAllergens {
+		if !contains(Allergens, allergen) {
+			fields = append(fields, FieldError{"exclude_allergens", fmt.Sprintf("Unknown allergen %s", allergen)})
+		}
+	}
+	for _, diet := range f.Diets {
+		if !contains(Diets, diet) {
+			fields = append(fields, FieldError{"diet", fmt.Sprintf("Unknown diet %s", diet)})
+		}
+	}
+	if len(fields) > 0 {
+		return &ValidationError{fields: fields}
+	}
+	return nil
+}
+
+// DietLabels are the allergens contained in a recipe, meal or meal plan and
+// the diets all of its ingredients are compatible with. Something without
+// ingredients has no diet labels.
+type DietLabels struct {
+	Allergens []string `json:"allergens" validate:"allergen"`
+	Diets     []string `json:"diets" validate:"diet"`
+}
+
+// combineDietLabels derives the labels of something made of the parts.
+func combineDietLabels(parts ...DietLabels) DietLabels {
+	labels := DietLabels{Allergens: []string{}, Diets: []string{}}
+	for index, part := range parts {
+		for _, allergen := range part.Allergens {
+			if !contains(labels.Allergens, allergen) {
+				labels.Allergens = append(labels.Allergens, allergen)
+			}
+		}
+		if index == 0 {
+			labels.Diets = append(labels.Diets, part.Diets...)
+			continue
+		}
+		kept := labels.Diets[:0]
+		for _, diet := range labels.Diets {
+			if contains(part.Diets, diet) {
+				kept = append(kept, diet)
+			}
+		}
+		labels.Diets = kept
+	}
+	sort.Strings(labels.Allergens)
+	return labels
+}
+
+// normalizeDiets adds the implied diets and sorts the result.
+func normalizeDiets(diets []string) []string {
+	normalized := []string{}
+	for _, diet := range diets {
+		for _, d := range append([]string{diet}, impliedDiets[diet]...) {
+			if !contains(normalized, d) {
+				normalized = append(normalized, d)
+			}
+		}
+	}
+	sort.Strings(normalized)
+	return normalized
+}
+
+func validateDietLabels(labels DietLabels) []FieldError {
+	var fields []FieldError
+	for index, allergen := range labels.Allergens {
+		if !contains(Allergens, allergen) {
+			fields = append(fields, FieldError{fmt.Sprintf("allergens[%d]", index), fmt.Sprintf("Unknown allergen %s", allergen)})
+		}
+	}
+	for index, diet := range labels.Diets {
+		if !contains(Diets, diet) {
+			fields = append(fields, FieldError{fmt.Sprintf("diets[%d]", index), fmt.Sprintf("Unknown diet %s", diet)})
+		}
+	}
+	return fields
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -2,6 +2,7 @@ package service
 
 type Ingredient struct {
 	Id               int64  `json:"id"`
-	Name             string `json:"name"`
+	Name             string `json:"name" validate:"required"`
 	NutritionalValue `json:"nutritional_value"`
+	DietLabels
 }
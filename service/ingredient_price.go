@@ -0,0 +1,20 @@
+package service
+
+// IngredientPrice is the price paid for a package of an ingredient, Quantity
+// being the package size.
+type IngredientPrice struct {
+	Id           int64    `json:"id"`
+	IngredientId int64    `json:"ingredient_id"`
+	Price        float32  `json:"price" validate:"gte=0"`
+	Quantity     Quantity `json:"quantity"`
+	Store        string   `json:"store"`
+	Date         Date     `json:"date" validate:"required"`
+}
+
+// Cost is the cost of an amount of food at the latest known prices. Unpriced
+// lists the ingredients left out of the total because they have no price or
+// their price uses a unit that can't be converted.
+type Cost struct {
+	Total    float32 `json:"total"`
+	Unpriced []int64 `json:"unpriced"`
+}
@@ -0,0 +1,193 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/cookbook/repository"
+)
+
+// maxStoreLength limits the length of store names.
+const maxStoreLength = 64
+
+type IngredientPriceService interface {
+	Get(int64) (IngredientPrice, error)
+	GetAll() ([]IngredientPrice, error)
+	GetLatest([]int64) (map[int64]IngredientPrice, error)
+	Create(IngredientPrice) (int64, error)
+	Update(IngredientPrice) error
+	Delete(int64) error
+}
+
+type IngredientPriceServiceImpl struct {
+	repo       *repository.IngredientPriceRepository
+	ingService IngredientService
+}
+
+func NewIngredientPriceService(r *repository.IngredientPriceRepository, is IngredientService) IngredientPriceService {
+	return IngredientPriceServiceImpl{
+		repo:       r,
+		ingService: is,
+	}
+}
+
+func (s IngredientPriceServiceImpl) Get(id int64) (IngredientPrice, error) {
+	rPrice, err := s.repo.Get(id)
+	if err != nil {
+		return IngredientPrice{}, handleError(err)
+	}
+	return convertRepoPrice(rPrice), nil
+}
+
+// GetAll returns the price records, the most recent first.
+func (s IngredientPriceServiceImpl) GetAll() ([]IngredientPrice, error) {
+	rPrices, err := s.repo.GetAll()
+	if err != nil {
+		return []IngredientPrice{}, handleError(err)
+	}
+	prices := make([]IngredientPrice, len(rPrices))
+	for index, rPrice := range rPrices {
+		prices[index] = convertRepoPrice(rPrice)
+	}
+	return prices, nil
+}
+
+// GetLatest returns the most recent price of each of the ingredients, keyed
+// by ingredient id. Ingredients without prices are left out.
+func (s IngredientPriceServiceImpl) GetLatest(ingredientIds []int64) (map[int64]IngredientPrice, error) {
+	rPrices, err := s.repo.GetLatest(ingredientIds)
+	if err != nil {
+		return nil, handleError(err)
+	}
+	prices := make(map[int64]IngredientPrice)
+	for _, rPrice := range rPrices {
+		prices[rPrice.IngredientId] = convertRepoPrice(rPrice)
+	}
+	return prices, nil
+}
+
+func (s IngredientPriceServiceImpl) Create(price IngredientPrice) (int64, error) {
+	err := s.validate(price)
+	if err != nil {
+		return 0, err
+	}
+	id, err := s.repo.Create(toRepoPrice(price))
+	if err != nil {
+		return 0, handleError(err)
+	}
+	return id, nil
+}
+
+func (s IngredientPriceServiceImpl) Update(price IngredientPrice) error {
+	err := s.validate(price)
+	if err != nil {
+		return err
+	}
+	err = s.repo.Update(toRepoPrice(price))
+	if err != nil {
+		return handleError(err)
+	}
+	return nil
+}
+
+func (s IngredientPriceServiceImpl) Delete(id int64) error {
+	err := s.repo.Delete(id)
+	if err != nil {
+		return handleError(err)
+	}
+	return nil
+}
+
+func convertRepoPrice(rPrice repository.IngredientPrice) IngredientPrice {
+	return IngredientPrice{
+		Id:           rPrice.Id,
+		IngredientId: rPrice.IngredientId,
+		Price:        rPrice.Price,
+		Quantity:     Quantity{Amount: rPrice.Amount, Unit: rPrice.Unit},
+		Store:        rPrice.Store,
+		Date:         NewDate(rPrice.Date),
+	}
+}
+
+func toRepoPrice(price IngredientPrice) repository.IngredientPrice {
+	return repository.IngredientPrice{
+		Id:           price.Id,
+		IngredientId: price.IngredientId,
+		Price:        price.Price,
+		Amount:       price.Quantity.Amount,
+		Unit:         price.Quantity.Unit,
+		Store:        price.Store,
+		Date:         price.Date.Time,
+	}
+}
+
+func (s IngredientPriceServiceImpl) validate(price IngredientPrice) error {
+	err := validatePrice(price)
+	if err != nil {
+		return err
+	}
+	return referenceError([]int64{price.IngredientId}, "Ingredient", func(int) string {
+		return "ingredient_id"
+	}, s.ingService.GetExistingIds)
+}
+
+func validatePrice(price IngredientPrice) error {
+	var fields []FieldError
+	if price.Price < 0 {
+		fields = append(fields, FieldError{"price", "Price must not be negative"})
+	}
+	if !isUnitValid(price.Quantity.Unit) {
+		fields = append(fields, FieldError{"quantity.unit", fmt.Sprintf("Invalid measurement unit %s", price.Quantity.Unit)})
+	}
+	if price.Quantity.Amount <= 0 {
+		fields = append(fields, FieldError{"quantity.amount", "Package size must be greater then 0"})
+	}
+	if price.Date.IsZero() {
+		fields = append(fields, FieldError{"date", "Date must be provided"})
+	}
+	if len(price.Store) > maxStoreLength {
+		fields = append(fields, FieldError{"store", fmt.Sprintf("Store must be at most %d characters long", maxStoreLength)})
+	}
+	if len(fields) > 0 {
+		return &ValidationError{fields: fields}
+	}
+	return nil
+}
+
+// priceQuantity returns the cost of the quantity at the price, using the same
+// scaling as scaleIngredient.
+func priceQuantity(price IngredientPrice, quantity Quantity) (float32, error) {
+	scale, err := quantityScale(price.Quantity, quantity)
+	if err != nil {
+		return 0, err
+	}
+	return price.Price * scale, nil
+}
+
+// add adds the cost of a quantity of the ingredient, or marks the ingredient
+// unpriced.
+func (c *Cost) add(ingredientId int64, quantity Quantity, prices map[int64]IngredientPrice) {
+	if price, ok := prices[ingredientId]; ok {
+		if cost, err := priceQuantity(price, quantity); err == nil {
+			c.Total += cost
+			return
+		}
+	}
+	c.addUnpriced(ingredientId)
+}
+
+// addCost adds another cost, multiplied by factor.
+func (c *Cost) addCost(other Cost, factor float32) {
+	c.Total += other.Total * factor
+	for _, id := range other.Unpriced {
+		c.addUnpriced(id)
+	}
+}
+
+func (c *Cost) addUnpriced(ingredientId int64) {
+	for _, id := range c.Unpriced {
+		if id == ingredientId {
+			return
+		}
+	}
+	c.Unpriced = append(c.Unpriced, ingredientId)
+}
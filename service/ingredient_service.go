@@ -11,9 +11,10 @@ type IngredientService interface {
 	Get(int64) (Ingredient, error)
 	GetAll() ([]Ingredient, error)
 	GetList(ids []int64) ([]Ingredient, error)
-	Create(Ingredient) error
+	Create(Ingredient) (int64, error)
 	Update(Ingredient) error
-	Delete(int64) error
+	GetExistingIds([]int64) (map[int64]bool, error)
+	Delete(int64, DeleteOptions) error
 }
 
 type NotFound struct {
@@ -32,12 +33,55 @@ func (e *InternalError) Error() string {
 	return e.message
 }
 
+// FieldError describes an invalid value, Field is the JSON path of the value
+// within the request body, e.g. ingredients[2].unit.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
 type ValidationError struct {
-	messages []string
+	fields []FieldError
 }
 
 func (e *ValidationError) Error() string {
-	return strings.Join(e.messages, "\n")
+	messages := make([]string, len(e.fields))
+	for i, field := range e.fields {
+		messages[i] = field.Message
+	}
+	return strings.Join(messages, "\n")
+}
+
+func (e *ValidationError) Fields() []FieldError {
+	return e.fields
+}
+
+// Conflict is returned when deleting an entity other entities still reference.
+type Conflict struct {
+	message    string
+	Dependents []Dependents
+}
+
+// Dependents are the entities of a type referencing a deleted entity.
+type Dependents struct {
+	Type string
+	Ids  []int64
+}
+
+func (e *Conflict) Error() string {
+	return e.message
+}
+
+// DeleteOptions controls what happens to the entities referencing a deleted
+// one. By default the delete fails with a Conflict listing them. Data owned by
+// the deleted entity, like its reviews or prices, is always deleted with it.
+type DeleteOptions struct {
+	// Cascade removes the references from the referencing entities.
+	Cascade bool
+	// ReplaceWith references another entity instead, 0 if unused. An entity
+	// referencing both keeps a single reference, recipes add up the amounts
+	// of both ingredients if their units convert.
+	ReplaceWith int64
 }
 
 type ServiceImpl struct {
@@ -81,23 +125,25 @@ func (s ServiceImpl) GetAll() ([]Ingredient, error) {
 	return s.convertRepoModel(ri...), nil
 }
 
-func (s ServiceImpl) Create(i Ingredient) (err error) {
+func (s ServiceImpl) Create(i Ingredient) (id int64, err error) {
 	err = validateIngredient(i)
 	if err != nil {
-		return err
+		return 0, err
 	}
 
 	ri := repository.Ingredient{
-		Name:     i.Name,
-		Calories: i.Calories,
-		Protein:  i.Protein,
-		Carbs:    i.Carbs,
-		Fat:      i.Fat,
-		Amount:   i.Amount,
-		Unit:     i.Unit,
+		Name:      i.Name,
+		Calories:  i.Calories,
+		Protein:   i.Protein,
+		Carbs:     i.Carbs,
+		Fat:       i.Fat,
+		Amount:    i.Amount,
+		Unit:      i.Unit,
+		Allergens: i.Allergens,
+		Diets:     normalizeDiets(i.Diets),
 	}
 
-	err = s.repo.Create(ri)
+	id, err = s.repo.Create(ri)
 	if err != nil {
 		err = handleError(err)
 	}
@@ -111,14 +157,16 @@ func (s ServiceImpl) Update(i Ingredient) (err error) {
 	}
 
 	ri := repository.Ingredient{
-		Id:       i.Id,
-		Name:     i.Name,
-		Calories: i.Calories,
-		Protein:  i.Protein,
-		Carbs:    i.Carbs,
-		Fat:      i.Fat,
-		Amount:   i.Amount,
-		Unit:     i.Unit,
+		Id:        i.Id,
+		Name:      i.Name,
+		Calories:  i.Calories,
+		Protein:   i.Protein,
+		Carbs:     i.Carbs,
+		Fat:       i.Fat,
+		Amount:    i.Amount,
+		Unit:      i.Unit,
+		Allergens: i.Allergens,
+		Diets:     normalizeDiets(i.Diets),
 	}
 
 	err = s.repo.Update(ri)
@@ -128,9 +176,20 @@ func (s ServiceImpl) Update(i Ingredient) (err error) {
 	return
 }
 
-func (s ServiceImpl) Delete(id int64) (err error) {
+func (s ServiceImpl) GetExistingIds(ids []int64) (map[int64]bool, error) {
+	existing, err := s.repo.GetExistingIds(ids)
+	if err != nil {
+		return nil, handleError(err)
+	}
+	return existing, nil
+}
 
-	err = s.repo.Delete(id)
+func (s ServiceImpl) Delete(id int64, opts DeleteOptions) (err error) {
+	err = validateDeleteOptions(id, opts, s.GetExistingIds)
+	if err != nil {
+		return err
+	}
+	err = s.repo.Delete(id, repository.DeleteOptions{Cascade: opts.Cascade, ReplaceWith: opts.ReplaceWith, ConvertUnit: ConvertUnit})
 	if err != nil {
 		err = handleError(err)
 	}
@@ -154,36 +213,80 @@ func (s ServiceImpl) convertRepoModel(repoIngredients ...repository.Ingredient)
 				Carbs:    i.Carbs,
 				Protein:  i.Protein,
 			},
+			DietLabels: DietLabels{
+				Allergens: append([]string{}, i.Allergens...),
+				Diets:     append([]string{}, i.Diets...),
+			},
 		}
 	}
 	return ings
 }
 
 func validateIngredient(i Ingredient) error {
-	var messages []string
+	var fields []FieldError
 	if i.Name == "" {
-		messages = append(messages, "Ingredient name must be provided")
+		fields = append(fields, FieldError{"name", "Ingredient name must be provided"})
 	}
 	if !isUnitValid(i.Unit) {
-		messages = append(messages, fmt.Sprintf("Invalid measurement unit: %s", i.Unit))
+		fields = append(fields, FieldError{"nutritional_value.quantity.unit", fmt.Sprintf("Invalid measurement unit: %s", i.Unit)})
 	}
 	if i.Amount <= 0.0 {
-		messages = append(messages, "Ingredient amount must be greater then 0")
+		fields = append(fields, FieldError{"nutritional_value.quantity.amount", "Ingredient amount must be greater then 0"})
 	}
 	if i.Calories < 0.0 {
-		messages = append(messages, "Calories amount must be a positive value")
+		fields = append(fields, FieldError{"nutritional_value.calories", "Calories amount must be a positive value"})
 	}
 	if i.Protein < 0.0 {
-		messages = append(messages, "Protein amount must be a positive value")
+		fields = append(fields, FieldError{"nutritional_value.protein", "Protein amount must be a positive value"})
 	}
 	if i.Carbs < 0.0 {
-		messages = append(messages, "Carb amount must be a positive value")
+		fields = append(fields, FieldError{"nutritional_value.carbs", "Carb amount must be a positive value"})
 	}
 	if i.Fat < 0.0 {
-		messages = append(messages, "Fat amount must be a positive value")
+		fields = append(fields, FieldError{"nutritional_value.fat", "Fat amount must be a positive value"})
+	}
+	fields = append(fields, validateDietLabels(i.DietLabels)...)
+	if len(fields) > 0 {
+		return &ValidationError{fields: fields}
+	}
+	return nil
+}
+
+func validateDeleteOptions(id int64, opts DeleteOptions, getExistingIds func([]int64) (map[int64]bool, error)) error {
+	if opts.ReplaceWith == 0 {
+		return nil
+	}
+	if opts.Cascade {
+		return &ValidationError{fields: []FieldError{{"cascade", "cascade and replace_with can't be combined"}}}
+	}
+	if opts.ReplaceWith == id {
+		return &ValidationError{fields: []FieldError{{"replace_with", "An entity can't be replaced with itself"}}}
+	}
+	existing, err := getExistingIds([]int64{opts.ReplaceWith})
+	if err != nil {
+		return err
 	}
-	if len(messages) > 0 {
-		return &ValidationError{messages: messages}
+	if !existing[opts.ReplaceWith] {
+		return &ValidationError{fields: []FieldError{{"replace_with", fmt.Sprintf("Replacement with id %d doesn't exist", opts.ReplaceWith)}}}
+	}
+	return nil
+}
+
+// referenceError returns a ValidationError for the referenced ids that don't
+// exist, field returns the JSON path of the reference at the given position.
+func referenceError(ids []int64, name string, field func(index int) string, getExistingIds func([]int64) (map[int64]bool, error)) error {
+	existing, err := getExistingIds(ids)
+	if err != nil {
+		return err
+	}
+	var fields []FieldError
+	for index, id := range ids {
+		if !existing[id] {
+			fields = append(fields, FieldError{field(index), fmt.Sprintf("%s with id %d doesn't exist", name, id)})
+		}
+	}
+	if len(fields) > 0 {
+		return &ValidationError{fields: fields}
 	}
 	return nil
 }
@@ -192,6 +295,12 @@ func handleError(e error) error {
 	switch x := e.(type) {
 	case *repository.NotFound:
 		return &NotFound{x.Error()}
+	case *repository.Conflict:
+		conflict := &Conflict{message: x.Error()}
+		for _, dependents := range x.Dependents {
+			conflict.Dependents = append(conflict.Dependents, Dependents{dependents.Table, dependents.Ids})
+		}
+		return conflict
 	default:
 		return &InternalError{x.Error()}
 	}
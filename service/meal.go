@@ -1,13 +1,39 @@
 package service
 
+// MealGet is a meal, its cost is the cost of a portion.
 type MealGet struct {
-	Id      int64       `json:"id"`
-	Name    string      `json:"name"`
-	Recipes []RecipeGet `json:"recipes"`
+	Id       int64             `json:"id"`
+	Name     string            `json:"name"`
+	Recipes  []RecipeGet       `json:"recipes"`
+	Servings map[int64]float32 `json:"servings"`
+	Cost     Cost              `json:"cost"`
+	DietLabels
 }
 
+// MealCreate is a meal of the recipes. Servings holds the servings of recipes
+// in a portion of the meal by recipe id, a portion includes the whole of the
+// recipes missing from it.
 type MealCreate struct {
-	Id      int64   `json:"id"`
-	Name    string  `json:"name"`
-	Recipes []int64 `json:"recipes"`
+	Id       int64             `json:"id"`
+	Name     string            `json:"name" validate:"required"`
+	Recipes  []int64           `json:"recipes"`
+	Servings map[int64]float32 `json:"servings"`
+}
+
+// RecipeShare returns the share of the recipe's ingredients, nutrition and
+// cost in a portion of the meal.
+func (meal MealGet) RecipeShare(recipe RecipeGet) float32 {
+	servings, ok := meal.Servings[recipe.Id]
+	if !ok {
+		return 1
+	}
+	return servings / recipeGetServings(recipe)
+}
+
+// recipeGetServings returns the servings the recipe makes, one if not given.
+func recipeGetServings(recipe RecipeGet) float32 {
+	if recipe.Servings == 0 {
+		return 1
+	}
+	return float32(recipe.Servings)
 }
@@ -2,16 +2,181 @@ package service
 
 import "time"
 
-type MealPlanGet struct {
-	Id          int64       `json:"id"`
-	Name        string      `json:"name"`
-	DateStarted time.Time   `json:"date_started"`
-	Meals       [][]MealGet `json:"meals"`
+// Meal slots in the order they are eaten during a day. Any other non-empty
+// slot name is a custom slot and sorts after these.
+const (
+	SlotBreakfast = "breakfast"
+	SlotLunch     = "lunch"
+	SlotDinner    = "dinner"
+	SlotSnack     = "snack"
+)
+
+var slotOrder = map[string]int{
+	SlotBreakfast: 0,
+	SlotLunch:     1,
+	SlotDinner:    2,
+	SlotSnack:     3,
 }
 
-type MealPlanCreate struct {
+type MealPlanGet struct {
 	Id          int64     `json:"id"`
 	Name        string    `json:"name"`
 	DateStarted time.Time `json:"date_started"`
-	Meals       [][]int64 `json:"meals"`
+	Followed    bool      `json:"followed"`
+	Cost        Cost      `json:"cost"`
+	DietLabels
+	Entries []MealPlanEntryGet `json:"entries"`
+}
+
+// MealPlanEntryGet is a planned meal. Leftover is set for the entries of a
+// batch after the one cooking it.
+type MealPlanEntryGet struct {
+	Date     Date    `json:"date"`
+	Slot     string  `json:"slot"`
+	Servings float32 `json:"servings"`
+	People   int     `json:"people"`
+	Members  []int64 `json:"members"`
+	Cooked   bool    `json:"cooked"`
+	Batch    int     `json:"batch"`
+	Portions float32 `json:"portions"`
+	Leftover bool    `json:"leftover"`
+	Meal     MealGet `json:"meal"`
+}
+
+// CookedServings returns the portions of the meal cooked for the entry, 0 for
+// leftovers.
+func (entry MealPlanEntryGet) CookedServings() float32 {
+	if entry.Leftover {
+		return 0
+	}
+	return entry.Servings * float32(entry.People)
+}
+
+// CookedShare returns the share of the recipe's ingredients cooked for the
+// entry.
+func (entry MealPlanEntryGet) CookedShare(recipe RecipeGet) float32 {
+	return entry.CookedServings() * entry.Meal.RecipeShare(recipe)
+}
+
+// PersonShare returns the share of the recipe's ingredients and nutrition
+// eaten by each person at the entry.
+func (entry MealPlanEntryGet) PersonShare(recipe RecipeGet) float32 {
+	if entry.People == 0 {
+		return 0
+	}
+	return entry.EatenShare(recipe) / float32(entry.People)
+}
+
+// EatenShare returns the share of the recipe's ingredients and nutrition
+// eaten at the entry: the portions of a meal not cooked in a batch, or the
+// portions eaten from the batch. A portion of a batch has the servings of the
+// meal's recipes, or one serving of recipes the meal includes whole.
+func (entry MealPlanEntryGet) EatenShare(recipe RecipeGet) float32 {
+	if entry.Batch == 0 {
+		return entry.CookedServings() * entry.Meal.RecipeShare(recipe)
+	}
+	servings, ok := entry.Meal.Servings[recipe.Id]
+	if !ok {
+		servings = 1
+	}
+	return entry.Portions * float32(entry.People) * servings / recipeGetServings(recipe)
+}
+
+// MealPlanClone is a copy of a meal plan starting on DateStarted, named after
+// the original if Name is empty.
+type MealPlanClone struct {
+	Name        string `json:"name"`
+	DateStarted Date   `json:"date_started" validate:"required"`
+}
+
+// MealPlanRepeat appends Times copies of the days of a meal plan, each
+// starting Period days after the previous one. Period defaults to the number
+// of days of the meal plan and can't be shorter.
+type MealPlanRepeat struct {
+	Times  int `json:"times" validate:"gt=0"`
+	Period int `json:"period" validate:"gte=0"`
+}
+
+// MealPlanShift moves every day of a meal plan by Days, earlier if negative.
+type MealPlanShift struct {
+	Days int `json:"days"`
+}
+
+type MealPlanCreate struct {
+	Id          int64                 `json:"id"`
+	Name        string                `json:"name" validate:"required"`
+	DateStarted time.Time             `json:"date_started"`
+	Followed    bool                  `json:"followed"`
+	Entries     []MealPlanEntryCreate `json:"entries"`
+}
+
+// MealPlanEntryCreate plans a meal for a slot of a day, eaten by People who
+// each have Servings portions of it. Members are the household members among
+// them. Servings defaults to 1 and People to the number of Members, or 1
+// without members. Entries with the same Batch above 0 share one batch of the
+// meal, cooked by the first of them in day and slot order, and each person
+// eats Portions portions of it. Servings of the other entries are ignored.
+type MealPlanEntryCreate struct {
+	Date     Date    `json:"date" validate:"required"`
+	Slot     string  `json:"slot" validate:"required"`
+	MealId   int64   `json:"meal_id"`
+	Servings float32 `json:"servings" validate:"gte=0"`
+	People   int     `json:"people" validate:"gte=0"`
+	Members  []int64 `json:"members"`
+	Cooked   bool    `json:"cooked"`
+	Batch    int     `json:"batch" validate:"gte=0"`
+	Portions float32 `json:"portions" validate:"gte=0"`
+}
+
+// CalendarDay lists the meals planned for a date across all followed meal plans.
+type CalendarDay struct {
+	Date    Date            `json:"date"`
+	Entries []CalendarEntry `json:"entries"`
+}
+
+type CalendarEntry struct {
+	MealPlanId   int64   `json:"meal_plan_id"`
+	MealPlanName string  `json:"meal_plan_name"`
+	Slot         string  `json:"slot"`
+	Servings     float32 `json:"servings"`
+	Meal         MealGet `json:"meal"`
+}
+
+// MealPlanGenerate describes the meal plan to generate. Targets are per day,
+// a target of 0 is ignored. MaxRepeats limits how often a meal may appear in
+// the plan, 0 means no limit. The same seed generates the same plan.
+type MealPlanGenerate struct {
+	Name                string           `json:"name"`
+	DateStarted         Date             `json:"date_started" validate:"required"`
+	Days                int              `json:"days" validate:"gt=0"`
+	MealsPerDay         int              `json:"meals_per_day" validate:"gt=0"`
+	Targets             NutritionTargets `json:"targets"`
+	ExcludedIngredients []int64          `json:"excluded_ingredients"`
+	MaxRepeats          int              `json:"max_repeats" validate:"gte=0"`
+	Seed                int64            `json:"seed"`
+}
+
+type NutritionTargets struct {
+	Calories float32 `json:"calories" validate:"gte=0"`
+	Protein  float32 `json:"protein" validate:"gte=0"`
+	Carbs    float32 `json:"carbs" validate:"gte=0"`
+	Fat      float32 `json:"fat" validate:"gte=0"`
+}
+
+// GeneratedMealPlan is a generated meal plan ready to be saved, with the
+// nutrition of each of its days. Deviation is the mean absolute difference
+// between the days and the targets. Seed reproduces the plan.
+type GeneratedMealPlan struct {
+	MealPlan  MealPlanCreate   `json:"meal_plan"`
+	Days      []DayNutrition   `json:"days"`
+	Deviation NutritionTargets `json:"deviation"`
+	Seed      int64            `json:"seed"`
+}
+
+type DayNutrition struct {
+	Date     Date    `json:"date"`
+	Calories float32 `json:"calories"`
+	Protein  float32 `json:"protein"`
+	Carbs    float32 `json:"carbs"`
+	Fat      float32 `json:"fat"`
 }
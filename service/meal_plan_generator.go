@@ -0,0 +1,300 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"time"
+)
+
+const (
+	maxGeneratedMealsPerDay = 6
+	// generatorIterations is the number of local search moves per planned meal.
+	generatorIterations    = 2000
+	maxGeneratorIterations = 200000
+)
+
+// nutrition holds calories, protein, carbs and fat in this order.
+type nutrition [4]float64
+
+func (n nutrition) add(o nutrition, sign float64) nutrition {
+	for k := range n {
+		n[k] += sign * o[k]
+	}
+	return n
+}
+
+// mealNutrition returns the nutrition of a portion of the meal.
+func mealNutrition(meal MealGet) (n nutrition) {
+	for _, recipe := range meal.Recipes {
+		n = n.add(nutrition{float64(recipe.Calories), float64(recipe.Protein), float64(recipe.Carbs), float64(recipe.Fat)}, float64(meal.RecipeShare(recipe)))
+	}
+	return n
+}
+
+// generatedSlots returns the slots of a day with the given number of meals.
+// Meals beyond breakfast, lunch and dinner are snacks.
+func generatedSlots(mealsPerDay int) []string {
+	switch mealsPerDay {
+	case 1:
+		return []string{SlotDinner}
+	case 2:
+		return []string{SlotLunch, SlotDinner}
+	}
+	slots := []string{SlotBreakfast, SlotLunch, SlotDinner}
+	for len(slots) < mealsPerDay {
+		slots = append(slots, SlotSnack)
+	}
+	return slots
+}
+
+// planSearch assigns candidate meals to every slot of every day. It keeps
+// the nutrition of each day so moves are scored by the days they change.
+type planSearch struct {
+	candidates []nutrition
+	targets    nutrition
+	maxUses    int
+	plan       [][]int
+	uses       []int
+	days       []nutrition
+}
+
+// dayCost is the squared relative deviation of a day from the targets.
+func (p *planSearch) dayCost(day nutrition) float64 {
+	var cost float64
+	for k, target := range p.targets {
+		if target > 0 {
+			d := (day[k] - target) / target
+			cost += d * d
+		}
+	}
+	return cost
+}
+
+func (p *planSearch) cost() float64 {
+	var cost float64
+	for _, day := range p.days {
+		cost += p.dayCost(day)
+	}
+	return cost
+}
+
+func (p *planSearch) hasMeal(day, candidate, except int) bool {
+	for slot, c := range p.plan[day] {
+		if c == candidate && slot != except {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *planSearch) set(day, slot, candidate int) {
+	old := p.plan[day][slot]
+	p.uses[old]--
+	p.days[day] = p.days[day].add(p.candidates[old], -1)
+	p.plan[day][slot] = candidate
+	p.uses[candidate]++
+	p.days[day] = p.days[day].add(p.candidates[candidate], 1)
+}
+
+// move changes the plan at random, either replacing a meal with another
+// candidate or swapping the meals of two days. It returns the change in cost
+// and a function undoing the move, or ok false if the move would break the
+// variety constraints.
+func (p *planSearch) move(rnd *rand.Rand) (delta float64, undo func(), ok bool) {
+	d1, s1 := rnd.Intn(len(p.plan)), rnd.Intn(len(p.plan[0]))
+	c1 := p.plan[d1][s1]
+	if rnd.Intn(2) == 0 || len(p.plan) == 1 {
+		c := rnd.Intn(len(p.candidates))
+		if c == c1 || p.uses[c] >= p.maxUses || p.hasMeal(d1, c, s1) {
+			return 0, nil, false
+		}
+		before := p.dayCost(p.days[d1])
+		p.set(d1, s1, c)
+		return p.dayCost(p.days[d1]) - before, func() { p.set(d1, s1, c1) }, true
+	}
+	d2, s2 := rnd.Intn(len(p.plan)), rnd.Intn(len(p.plan[0]))
+	c2 := p.plan[d2][s2]
+	if d1 == d2 || c1 == c2 || p.hasMeal(d1, c2, s1) || p.hasMeal(d2, c1, s2) {
+		return 0, nil, false
+	}
+	before := p.dayCost(p.days[d1]) + p.dayCost(p.days[d2])
+	p.set(d1, s1, c2)
+	p.set(d2, s2, c1)
+	undo = func() {
+		p.set(d1, s1, c1)
+		p.set(d2, s2, c2)
+	}
+	return p.dayCost(p.days[d1]) + p.dayCost(p.days[d2]) - before, undo, true
+}
+
+func copyPlan(plan [][]int) [][]int {
+	copied := make([][]int, len(plan))
+	for day := range plan {
+		copied[day] = append([]int(nil), plan[day]...)
+	}
+	return copied
+}
+
+// Generate plans meals from the catalogue for the requested days using
+// simulated annealing. Each day's nutrition is pulled towards the targets,
+// while no meal appears twice on a day or more than MaxRepeats times overall.
+func (s MealPlanServiceImpl) Generate(req MealPlanGenerate) (GeneratedMealPlan, error) {
+	err := validateMealPlanGenerate(req)
+	if err != nil {
+		return GeneratedMealPlan{}, err
+	}
+	meals, err := s.mealService.GetAll()
+	if err != nil {
+		return GeneratedMealPlan{}, err
+	}
+	candidates := excludeIngredients(meals, req.ExcludedIngredients)
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].Id < candidates[j].Id })
+	slots := generatedSlots(req.MealsPerDay)
+	maxUses := req.MaxRepeats
+	if maxUses == 0 {
+		maxUses = req.Days * req.MealsPerDay
+	}
+	if len(candidates) < len(slots) || len(candidates)*maxUses < req.Days*len(slots) {
+		return GeneratedMealPlan{}, &ValidationError{fields: []FieldError{{"days", fmt.Sprintf("Only %d meals are left to choose from, not enough for %d days of %d meals", len(candidates), req.Days, len(slots))}}}
+	}
+
+	seed := req.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	rnd := rand.New(rand.NewSource(seed))
+	search := &planSearch{
+		targets: nutrition{float64(req.Targets.Calories), float64(req.Targets.Protein), float64(req.Targets.Carbs), float64(req.Targets.Fat)},
+		maxUses: maxUses,
+		uses:    make([]int, len(candidates)),
+		days:    make([]nutrition, req.Days),
+		plan:    make([][]int, req.Days),
+	}
+	for _, meal := range candidates {
+		search.candidates = append(search.candidates, mealNutrition(meal))
+	}
+	// Walking a shuffled candidate list round robin gives a start that meets
+	// the variety constraints, see the check above.
+	order := rnd.Perm(len(candidates))
+	for day := range search.plan {
+		search.plan[day] = make([]int, len(slots))
+		for slot := range slots {
+			c := order[(day*len(slots)+slot)%len(order)]
+			search.plan[day][slot] = c
+			search.uses[c]++
+			search.days[day] = search.days[day].add(search.candidates[c], 1)
+		}
+	}
+
+	iterations := generatorIterations * req.Days * len(slots)
+	if iterations > maxGeneratorIterations {
+		iterations = maxGeneratorIterations
+	}
+	cost := search.cost()
+	best, bestCost := copyPlan(search.plan), cost
+	temperature, cooling := 0.1, math.Pow(1e-4, 1/float64(iterations))
+	for i := 0; i < iterations && bestCost > 0; i++ {
+		temperature *= cooling
+		delta, undo, ok := search.move(rnd)
+		if !ok {
+			continue
+		}
+		if delta > 0 && rnd.Float64() >= math.Exp(-delta/temperature) {
+			undo()
+			continue
+		}
+		cost += delta
+		if cost < bestCost {
+			best, bestCost = copyPlan(search.plan), cost
+		}
+	}
+
+	name := req.Name
+	if name == "" {
+		name = "Meal plan from " + req.DateStarted.String()
+	}
+	generated := GeneratedMealPlan{
+		MealPlan: MealPlanCreate{Name: name, DateStarted: req.DateStarted.Time},
+		Seed:     seed,
+	}
+	var deviation nutrition
+	for day, dayMeals := range best {
+		date := req.DateStarted.AddDays(day)
+		var total nutrition
+		for slot, c := range dayMeals {
+			total = total.add(search.candidates[c], 1)
+			generated.MealPlan.Entries = append(generated.MealPlan.Entries, MealPlanEntryCreate{
+				Date:     date,
+				Slot:     slots[slot],
+				MealId:   candidates[c].Id,
+				Servings: 1,
+			})
+		}
+		generated.Days = append(generated.Days, DayNutrition{
+			Date:     date,
+			Calories: float32(total[0]),
+			Protein:  float32(total[1]),
+			Carbs:    float32(total[2]),
+			Fat:      float32(total[3]),
+		})
+		for k, target := range search.targets {
+			if target > 0 {
+				deviation[k] += math.Abs(total[k]-target) / float64(req.Days)
+			}
+		}
+	}
+	generated.Deviation = NutritionTargets{
+		Calories: float32(deviation[0]),
+		Protein:  float32(deviation[1]),
+		Carbs:    float32(deviation[2]),
+		Fat:      float32(deviation[3]),
+	}
+	return generated, nil
+}
+
+// excludeIngredients returns the meals none of whose recipes use any of the
+// ingredients.
+func excludeIngredients(meals []MealGet, ingredientIds []int64) []MealGet {
+	excluded := make(map[int64]bool)
+	for _, id := range ingredientIds {
+		excluded[id] = true
+	}
+	var kept []MealGet
+meals:
+	for _, meal := range meals {
+		for _, recipe := range meal.Recipes {
+			for _, ingredient := range recipe.Ingredients {
+				if excluded[ingredient.Id] {
+					continue meals
+				}
+			}
+		}
+		kept = append(kept, meal)
+	}
+	return kept
+}
+
+func validateMealPlanGenerate(req MealPlanGenerate) error {
+	var fields []FieldError
+	if req.DateStarted.IsZero() {
+		fields = append(fields, FieldError{"date_started", "Start date must be provided"})
+	}
+	if req.Days < 1 || req.Days > maxCalendarDays {
+		fields = append(fields, FieldError{"days", fmt.Sprintf("Days must be between 1 and %d", maxCalendarDays)})
+	}
+	if req.MealsPerDay < 1 || req.MealsPerDay > maxGeneratedMealsPerDay {
+		fields = append(fields, FieldError{"meals_per_day", fmt.Sprintf("Meals per day must be between 1 and %d", maxGeneratedMealsPerDay)})
+	}
+	if req.MaxRepeats < 0 {
+		fields = append(fields, FieldError{"max_repeats", "Max repeats must not be negative"})
+	}
+	if req.Targets.Calories < 0 || req.Targets.Protein < 0 || req.Targets.Carbs < 0 || req.Targets.Fat < 0 {
+		fields = append(fields, FieldError{"targets", "Targets must not be negative"})
+	}
+	if len(fields) > 0 {
+		return &ValidationError{fields: fields}
+	}
+	return nil
+}
@@ -1,28 +1,61 @@
 package service
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"fmt"
+	"sort"
 
 	"github.com/cookbook/repository"
 )
 
+// maxCalendarDays limits the range of a calendar query.
+const maxCalendarDays = 366
+
+// maxSlotLength limits the length of custom slot names.
+const maxSlotLength = 32
+
+// maxMealPlanDays limits the length of repeated meal plans.
+const maxMealPlanDays = 2 * 366
+
 type MealPlanService interface {
 	Get(int64) (MealPlanGet, error)
+	GetList([]int64) ([]MealPlanGet, error)
 	GetAll() ([]MealPlanGet, error)
-	Create(MealPlanCreate) error
+	GetIdsByMeals([]int64) (map[int64][]int64, error)
+	GetCalendar(from, to Date) ([]CalendarDay, error)
+	Generate(MealPlanGenerate) (GeneratedMealPlan, error)
+	GetShoppingList(int64) ([]ShoppingListItem, error)
+	GetShoppingListBetween(id int64, from, to Date) ([]ShoppingListItem, error)
+	GetPrepSchedule(int64, PrepQuery) (PrepSchedule, error)
+	GetIntake(int64) ([]MemberIntake, error)
+	Cook(id int64, index int) (CookedEntry, error)
+	GetByFeedToken(string) (MealPlanGet, error)
+	CreateFeedToken(int64) (string, error)
+	DeleteFeedToken(int64) error
+	Create(MealPlanCreate) (int64, error)
 	Update(MealPlanCreate) error
+	Clone(int64, MealPlanClone) (int64, error)
+	Repeat(int64, MealPlanRepeat) error
+	Shift(int64, MealPlanShift) error
 	Delete(int64) error
 }
 
 type MealPlanServiceImpl struct {
-	repo        *repository.MealPlanRepository
-	mealService MealService
+	repo          *repository.MealPlanRepository
+	mealService   MealService
+	pantryService PantryService
+	priceService  IngredientPriceService
+	memberService MemberService
 }
 
-func NewMealPlanService(r *repository.MealPlanRepository, ms MealService) MealPlanService {
+func NewMealPlanService(r *repository.MealPlanRepository, ms MealService, ps PantryService, prs IngredientPriceService, mbs MemberService) MealPlanService {
 	return MealPlanServiceImpl{
-		repo:        r,
-		mealService: ms,
+		repo:          r,
+		mealService:   ms,
+		pantryService: ps,
+		priceService:  prs,
+		memberService: mbs,
 	}
 }
 
@@ -42,6 +75,14 @@ func (s MealPlanServiceImpl) Get(id int64) (mealPlan MealPlanGet, err error) {
 	return mealPlans[0], nil
 }
 
+func (s MealPlanServiceImpl) GetList(ids []int64) ([]MealPlanGet, error) {
+	rMealPlans, err := s.repo.GetList(ids)
+	if err != nil {
+		return []MealPlanGet{}, handleError(err)
+	}
+	return s.convertRepoModel(rMealPlans...)
+}
+
 func (s MealPlanServiceImpl) GetAll() ([]MealPlanGet, error) {
 	rMealPlans, err := s.repo.GetAll()
 	if err != nil {
@@ -50,17 +91,16 @@ func (s MealPlanServiceImpl) GetAll() ([]MealPlanGet, error) {
 	return s.convertRepoModel(rMealPlans...)
 }
 
-func (s MealPlanServiceImpl) Create(mealPlan MealPlanCreate) (err error) {
+func (s MealPlanServiceImpl) Create(mealPlan MealPlanCreate) (id int64, err error) {
 	err = validateMealPlan(mealPlan)
 	if err != nil {
-		return err
+		return 0, err
 	}
-	rMealPlan := repository.MealPlan{
-		Name:      mealPlan.Name,
-		StartDate: mealPlan.DateStarted,
+	err = s.validateReferences(mealPlan)
+	if err != nil {
+		return 0, err
 	}
-	rMealPlan.Meals = mealPlan.Meals
-	err = s.repo.Create(rMealPlan)
+	id, err = s.repo.Create(toRepoMealPlan(mealPlan))
 	if err != nil {
 		err = handleError(err)
 	}
@@ -72,19 +112,195 @@ func (s MealPlanServiceImpl) Update(mealPlan MealPlanCreate) (err error) {
 	if err != nil {
 		return err
 	}
-	rMealPlan := repository.MealPlan{
-		Id:        mealPlan.Id,
-		Name:      mealPlan.Name,
-		StartDate: mealPlan.DateStarted,
+	err = s.validateReferences(mealPlan)
+	if err != nil {
+		return err
 	}
-	rMealPlan.Meals = mealPlan.Meals
-	err = s.repo.Update(rMealPlan)
+	err = s.repo.Update(toRepoMealPlan(mealPlan))
 	if err != nil {
 		err = handleError(err)
 	}
 	return
 }
 
+// GetIdsByMeals returns the ids of the meal plans containing each of the given meals.
+func (s MealPlanServiceImpl) GetIdsByMeals(mealIds []int64) (map[int64][]int64, error) {
+	ids, err := s.repo.GetIdsByMeals(mealIds)
+	if err != nil {
+		return nil, handleError(err)
+	}
+	return ids, nil
+}
+
+// GetCalendar returns every day between from and to, both inclusive, with
+// the meals planned for it by the followed meal plans.
+func (s MealPlanServiceImpl) GetCalendar(from, to Date) ([]CalendarDay, error) {
+	days := to.DaysSince(from) + 1
+	if days < 1 {
+		return nil, &ValidationError{fields: []FieldError{{"to", "to must not be before from"}}}
+	}
+	if days > maxCalendarDays {
+		return nil, &ValidationError{fields: []FieldError{{"to", fmt.Sprintf("The calendar can span at most %d days", maxCalendarDays)}}}
+	}
+	rEntries, err := s.repo.GetCalendar(from.Time, to.Time)
+	if err != nil {
+		return nil, handleError(err)
+	}
+	var ids []int64
+	for _, entry := range rEntries {
+		ids = append(ids, entry.MealId)
+	}
+	meals, err := s.getMeals(ids)
+	if err != nil {
+		return nil, err
+	}
+	calendar := make([]CalendarDay, days)
+	for day := range calendar {
+		calendar[day] = CalendarDay{Date: from.AddDays(day), Entries: []CalendarEntry{}}
+	}
+	for _, entry := range rEntries {
+		day := NewDate(entry.Date).DaysSince(from)
+		calendar[day].Entries = append(calendar[day].Entries, CalendarEntry{
+			MealPlanId:   entry.MealPlanId,
+			MealPlanName: entry.MealPlanName,
+			Slot:         entry.Slot,
+			Servings:     entry.Servings,
+			Meal:         meals[entry.MealId],
+		})
+	}
+	for _, day := range calendar {
+		sort.SliceStable(day.Entries, func(i, j int) bool {
+			return slotLess(day.Entries[i].Slot, day.Entries[j].Slot)
+		})
+	}
+	return calendar, nil
+}
+
+// GetByFeedToken returns the meal plan subscribed to with the calendar feed token.
+func (s MealPlanServiceImpl) GetByFeedToken(token string) (MealPlanGet, error) {
+	id, err := s.repo.GetIdByFeedToken(token)
+	if err != nil {
+		if _, ok := err.(*repository.NotFound); ok {
+			return MealPlanGet{}, &NotFound{"Calendar feed doesn't exist"}
+		}
+		return MealPlanGet{}, handleError(err)
+	}
+	return s.Get(id)
+}
+
+// CreateFeedToken generates a new calendar feed token for the meal plan,
+// replacing the previous one.
+func (s MealPlanServiceImpl) CreateFeedToken(id int64) (string, error) {
+	b := make([]byte, 16)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", &InternalError{err.Error()}
+	}
+	token := hex.EncodeToString(b)
+	err = s.repo.SetFeedToken(id, token)
+	if err != nil {
+		return "", handleError(err)
+	}
+	return token, nil
+}
+
+// DeleteFeedToken disables the calendar feed of the meal plan.
+func (s MealPlanServiceImpl) DeleteFeedToken(id int64) error {
+	err := s.repo.SetFeedToken(id, "")
+	if err != nil {
+		return handleError(err)
+	}
+	return nil
+}
+
+// Clone copies the meal plan to start on another date, the copy isn't
+// followed and none of its entries are cooked.
+func (s MealPlanServiceImpl) Clone(id int64, clone MealPlanClone) (int64, error) {
+	err := validateMealPlanClone(clone)
+	if err != nil {
+		return 0, err
+	}
+	if clone.Name == "" {
+		mealPlan, err := s.repo.Get(id)
+		if err != nil {
+			return 0, handleError(err)
+		}
+		clone.Name = cloneName(mealPlan.Name, clone.Name)
+	}
+	cloneId, err := s.repo.Clone(id, clone.Name, clone.DateStarted.Time)
+	if err != nil {
+		return 0, handleError(err)
+	}
+	return cloneId, nil
+}
+
+// Repeat appends copies of the days of the meal plan after them, for plans
+// rotating through the same days.
+func (s MealPlanServiceImpl) Repeat(id int64, repeat MealPlanRepeat) error {
+	err := validateMealPlanRepeat(repeat)
+	if err != nil {
+		return err
+	}
+	mealPlan, err := s.repo.Get(id)
+	if err != nil {
+		return handleError(err)
+	}
+	days := int(mealPlan.Days())
+	if days == 0 {
+		return &ValidationError{fields: []FieldError{{"times", fmt.Sprintf("Meal plan %d has no entries to repeat", id)}}}
+	}
+	if repeat.Period == 0 {
+		repeat.Period = days
+	}
+	if repeat.Period < days {
+		return &ValidationError{fields: []FieldError{{"period", fmt.Sprintf("Period must not be shorter than the %d days of the meal plan", days)}}}
+	}
+	if repeat.Times > (maxMealPlanDays-days)/repeat.Period {
+		return &ValidationError{fields: []FieldError{{"times", fmt.Sprintf("Repeated meal plan must not be longer than %d days", maxMealPlanDays)}}}
+	}
+	err = s.repo.Repeat(id, repeat.Times, repeat.Period)
+	if err != nil {
+		return handleError(err)
+	}
+	return nil
+}
+
+func validateMealPlanClone(clone MealPlanClone) error {
+	if clone.DateStarted.IsZero() {
+		return &ValidationError{fields: []FieldError{{"date_started", "Start date of the copy must be provided"}}}
+	}
+	return nil
+}
+
+// validateMealPlanRepeat checks the request on its own, the days of the meal
+// plan limit it further.
+func validateMealPlanRepeat(repeat MealPlanRepeat) error {
+	var fields []FieldError
+	if repeat.Times < 1 {
+		fields = append(fields, FieldError{"times", "Times must be a positive integer"})
+	}
+	if repeat.Period < 0 {
+		fields = append(fields, FieldError{"period", "Period must not be negative"})
+	}
+	if len(fields) > 0 {
+		return &ValidationError{fields: fields}
+	}
+	return nil
+}
+
+// Shift moves the meal plan by a number of days, keeping its entries as
+// they are.
+func (s MealPlanServiceImpl) Shift(id int64, shift MealPlanShift) error {
+	if shift.Days < -maxMealPlanDays || shift.Days > maxMealPlanDays {
+		return &ValidationError{fields: []FieldError{{"days", fmt.Sprintf("Days must be between -%d and %d", maxMealPlanDays, maxMealPlanDays)}}}
+	}
+	err := s.repo.Shift(id, shift.Days)
+	if err != nil {
+		return handleError(err)
+	}
+	return nil
+}
+
 func (s MealPlanServiceImpl) Delete(id int64) (err error) {
 	err = s.repo.Delete(id)
 	if err != nil {
@@ -95,56 +311,212 @@ func (s MealPlanServiceImpl) Delete(id int64) (err error) {
 
 func (s MealPlanServiceImpl) convertRepoModel(repoMealPlans ...repository.MealPlan) ([]MealPlanGet, error) {
 	var mealPlans = make([]MealPlanGet, len(repoMealPlans))
-	usedMeals, err := s.getAllMeals(repoMealPlans...)
+	var ids []int64
+	for _, rMealPlan := range repoMealPlans {
+		for _, entry := range rMealPlan.Entries {
+			ids = append(ids, entry.MealId)
+		}
+	}
+	usedMeals, err := s.getMeals(ids)
 	if err != nil {
 		return []MealPlanGet{}, handleError(err)
 	}
 	for index, rMealPlan := range repoMealPlans {
+		startDate := NewDate(rMealPlan.StartDate)
 		mealPlans[index] = MealPlanGet{
 			Id:          rMealPlan.Id,
 			Name:        rMealPlan.Name,
 			DateStarted: rMealPlan.StartDate,
+			Followed:    rMealPlan.Followed,
+			Cost:        Cost{Unpriced: []int64{}},
+			Entries:     make([]MealPlanEntryGet, len(rMealPlan.Entries)),
 		}
-		mealPlans[index].Meals = make([][]MealGet, len(rMealPlan.Meals))
-		for day, dayMeals := range rMealPlan.Meals {
-			for _, mealId := range dayMeals {
-				mealPlans[index].Meals[day] = append(mealPlans[index].Meals[day], (*usedMeals)[mealId])
+		// Entries are in day and slot order, the first entry of a batch
+		// cooks it.
+		batches := make(map[int]bool)
+		for i, entry := range rMealPlan.Entries {
+			mealPlans[index].Entries[i] = MealPlanEntryGet{
+				Date:     startDate.AddDays(int(entry.Day)),
+				Slot:     entry.Slot,
+				Servings: entry.Servings,
+				People:   entry.People,
+				Members:  append([]int64{}, entry.Members...),
+				Cooked:   entry.Cooked,
+				Batch:    entry.Batch,
+				Portions: entry.Portions,
+				Leftover: batches[entry.Batch],
+				Meal:     usedMeals[entry.MealId],
+			}
+			if entry.Batch > 0 {
+				batches[entry.Batch] = true
 			}
+			mealPlans[index].Cost.addCost(usedMeals[entry.MealId].Cost, mealPlans[index].Entries[i].CookedServings())
 		}
+		var labels []DietLabels
+		for _, entry := range mealPlans[index].Entries {
+			labels = append(labels, entry.Meal.DietLabels)
+		}
+		mealPlans[index].DietLabels = combineDietLabels(labels...)
 	}
 	return mealPlans, nil
 }
 
-func (s MealPlanServiceImpl) getAllMeals(mealPlans ...repository.MealPlan) (*map[int64]MealGet, error) {
+// getMeals loads the given meals once each, keyed by id.
+func (s MealPlanServiceImpl) getMeals(ids []int64) (map[int64]MealGet, error) {
 	meals := make(map[int64]MealGet)
-	var ids []int64
-	for _, mealPlan := range mealPlans {
-		for _, dayMeals := range mealPlan.Meals {
-			for _, mealId := range dayMeals {
-				if _, ok := meals[mealId]; !ok {
-					meals[mealId] = MealGet{}
-					ids = append(ids, mealId)
-				}
-			}
+	var unique []int64
+	for _, id := range ids {
+		if _, ok := meals[id]; !ok {
+			meals[id] = MealGet{}
+			unique = append(unique, id)
 		}
 	}
-	rMeals, err := s.mealService.GetList(ids)
+	if len(unique) == 0 {
+		return meals, nil
+	}
+	rMeals, err := s.mealService.GetList(unique)
 	if err != nil {
 		return nil, err
 	}
 	for _, meal := range rMeals {
 		meals[meal.Id] = meal
 	}
-	return &meals, nil
+	return meals, nil
+}
+
+func (s MealPlanServiceImpl) validateReferences(mealPlan MealPlanCreate) error {
+	ids := make([]int64, len(mealPlan.Entries))
+	for index, entry := range mealPlan.Entries {
+		ids[index] = entry.MealId
+	}
+	err := referenceError(ids, "Meal", func(index int) string {
+		return fmt.Sprintf("entries[%d].meal_id", index)
+	}, s.mealService.GetExistingIds)
+	if err != nil {
+		return err
+	}
+	var memberIds []int64
+	var memberFields []string
+	for index, entry := range mealPlan.Entries {
+		for i, memberId := range entry.Members {
+			memberIds = append(memberIds, memberId)
+			memberFields = append(memberFields, fmt.Sprintf("entries[%d].members[%d]", index, i))
+		}
+	}
+	return referenceError(memberIds, "Member", func(index int) string {
+		return memberFields[index]
+	}, s.memberService.GetExistingIds)
+}
+
+// toRepoMealPlan converts entry dates to day offsets from the start date and
+// orders the entries by day and slot.
+func toRepoMealPlan(mealPlan MealPlanCreate) repository.MealPlan {
+	rMealPlan := repository.MealPlan{
+		Id:        mealPlan.Id,
+		Name:      mealPlan.Name,
+		StartDate: mealPlan.DateStarted,
+		Followed:  mealPlan.Followed,
+	}
+	startDate := NewDate(mealPlan.DateStarted)
+	for _, entry := range mealPlan.Entries {
+		servings := entry.Servings
+		if servings == 0 {
+			servings = 1
+		}
+		people := entry.People
+		if people == 0 {
+			people = len(entry.Members)
+		}
+		if people == 0 {
+			people = 1
+		}
+		rMealPlan.Entries = append(rMealPlan.Entries, repository.MealPlanEntry{
+			MealId:   entry.MealId,
+			Day:      int64(entry.Date.DaysSince(startDate)),
+			Slot:     entry.Slot,
+			Servings: servings,
+			People:   people,
+			Members:  entry.Members,
+			Cooked:   entry.Cooked,
+			Batch:    entry.Batch,
+			Portions: entry.Portions,
+		})
+	}
+	sort.SliceStable(rMealPlan.Entries, func(i, j int) bool {
+		a, b := rMealPlan.Entries[i], rMealPlan.Entries[j]
+		if a.Day != b.Day {
+			return a.Day < b.Day
+		}
+		return slotLess(a.Slot, b.Slot)
+	})
+	return rMealPlan
+}
+
+// slotLess orders the predefined slots by time of day, custom slots after them.
+func slotLess(a, b string) bool {
+	orderA, okA := slotOrder[a]
+	orderB, okB := slotOrder[b]
+	switch {
+	case okA && okB:
+		return orderA < orderB
+	case okA != okB:
+		return okA
+	default:
+		return a < b
+	}
 }
 
 func validateMealPlan(mealPlan MealPlanCreate) error {
-	var messages []string
+	var fields []FieldError
 	if mealPlan.Name == "" {
-		messages = append(messages, "Meal plan name must be provided")
+		fields = append(fields, FieldError{"name", "Meal plan name must be provided"})
+	}
+	startDate := NewDate(mealPlan.DateStarted)
+	batchMeals := make(map[int]int64)
+	for index, entry := range mealPlan.Entries {
+		if entry.Date.IsZero() {
+			fields = append(fields, FieldError{fmt.Sprintf("entries[%d].date", index), "Entry date must be provided"})
+		} else if entry.Date.Before(startDate.Time) {
+			fields = append(fields, FieldError{fmt.Sprintf("entries[%d].date", index), fmt.Sprintf("Entry date %s is before the meal plan start date %s", entry.Date, startDate)})
+		}
+		if entry.Slot == "" {
+			fields = append(fields, FieldError{fmt.Sprintf("entries[%d].slot", index), "Entry slot must be provided"})
+		} else if len(entry.Slot) > maxSlotLength {
+			fields = append(fields, FieldError{fmt.Sprintf("entries[%d].slot", index), fmt.Sprintf("Entry slot must be at most %d characters long", maxSlotLength)})
+		}
+		if entry.Servings < 0 {
+			fields = append(fields, FieldError{fmt.Sprintf("entries[%d].servings", index), "Entry servings must be a positive value"})
+		}
+		if entry.People < 0 {
+			fields = append(fields, FieldError{fmt.Sprintf("entries[%d].people", index), "Entry people must be a positive value"})
+		} else if entry.People > 0 && entry.People < len(entry.Members) {
+			fields = append(fields, FieldError{fmt.Sprintf("entries[%d].people", index), "Entry people must include all of its members"})
+		}
+		assigned := make(map[int64]bool)
+		for i, memberId := range entry.Members {
+			if assigned[memberId] {
+				fields = append(fields, FieldError{fmt.Sprintf("entries[%d].members[%d]", index, i), fmt.Sprintf("Member %d is assigned twice", memberId)})
+			}
+			assigned[memberId] = true
+		}
+		switch {
+		case entry.Batch < 0:
+			fields = append(fields, FieldError{fmt.Sprintf("entries[%d].batch", index), "Entry batch must be a positive value"})
+		case entry.Batch == 0 && entry.Portions != 0:
+			fields = append(fields, FieldError{fmt.Sprintf("entries[%d].portions", index), "Portions can only be eaten from a batch"})
+		case entry.Batch > 0 && entry.Portions <= 0:
+			fields = append(fields, FieldError{fmt.Sprintf("entries[%d].portions", index), "Entry portions must be a positive value"})
+		}
+		if entry.Batch > 0 {
+			if mealId, ok := batchMeals[entry.Batch]; ok && mealId != entry.MealId {
+				fields = append(fields, FieldError{fmt.Sprintf("entries[%d].meal_id", index), fmt.Sprintf("Entries of batch %d must all be of meal %d", entry.Batch, mealId)})
+			} else {
+				batchMeals[entry.Batch] = entry.MealId
+			}
+		}
 	}
-	if len(messages) > 0 {
-		return &ValidationError{messages: messages}
+	if len(fields) > 0 {
+		return &ValidationError{fields: fields}
 	}
 	return nil
 }
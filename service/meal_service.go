@@ -2,6 +2,7 @@ package service
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/cookbook/repository"
 )
@@ -10,9 +11,12 @@ type MealService interface {
 	Get(int64) (MealGet, error)
 	GetList([]int64) ([]MealGet, error)
 	GetAll() ([]MealGet, error)
-	Create(MealCreate) error
+	GetIdsByRecipes([]int64) (map[int64][]int64, error)
+	Create(MealCreate) (int64, error)
 	Update(MealCreate) error
-	Delete(int64) error
+	Clone(id int64, name string) (int64, error)
+	GetExistingIds([]int64) (map[int64]bool, error)
+	Delete(int64, DeleteOptions) error
 }
 
 type MealServiceImpl struct {
@@ -61,41 +65,77 @@ func (s MealServiceImpl) GetAll() ([]MealGet, error) {
 	return s.convertRepoModel(rMeals...)
 }
 
-func (s MealServiceImpl) Create(meal MealCreate) (err error) {
+func (s MealServiceImpl) Create(meal MealCreate) (id int64, err error) {
 	err = validateMeal(meal)
 	if err != nil {
-		return err
+		return 0, err
 	}
-	rMeal := repository.Meal{
-		Name: meal.Name,
+	err = s.validateReferences(meal)
+	if err != nil {
+		return 0, err
 	}
-	rMeal.Recipes = append(rMeal.Recipes, meal.Recipes...)
-	err = s.repo.Create(rMeal)
+	id, err = s.repo.Create(toRepoMeal(meal))
 	if err != nil {
 		err = handleError(err)
 	}
 	return
 }
 
+// Clone copies the meal with its recipes.
+func (s MealServiceImpl) Clone(id int64, name string) (int64, error) {
+	if name == "" {
+		meal, err := s.repo.Get(id)
+		if err != nil {
+			return 0, handleError(err)
+		}
+		name = cloneName(meal.Name, name)
+	}
+	cloneId, err := s.repo.Clone(id, name)
+	if err != nil {
+		return 0, handleError(err)
+	}
+	return cloneId, nil
+}
+
 func (s MealServiceImpl) Update(meal MealCreate) (err error) {
 	err = validateMeal(meal)
 	if err != nil {
 		return err
 	}
-	rMeal := repository.Meal{
-		Id:   meal.Id,
-		Name: meal.Name,
+	err = s.validateReferences(meal)
+	if err != nil {
+		return err
 	}
-	rMeal.Recipes = append(rMeal.Recipes, meal.Recipes...)
-	err = s.repo.Update(rMeal)
+	err = s.repo.Update(toRepoMeal(meal))
 	if err != nil {
 		err = handleError(err)
 	}
 	return
 }
 
-func (s MealServiceImpl) Delete(id int64) (err error) {
-	err = s.repo.Delete(id)
+// GetIdsByRecipes returns the ids of the meals containing each of the given recipes.
+func (s MealServiceImpl) GetIdsByRecipes(recipeIds []int64) (map[int64][]int64, error) {
+	ids, err := s.repo.GetIdsByRecipes(recipeIds)
+	if err != nil {
+		return nil, handleError(err)
+	}
+	return ids, nil
+}
+
+func (s MealServiceImpl) GetExistingIds(ids []int64) (map[int64]bool, error) {
+	existing, err := s.repo.GetExistingIds(ids)
+	if err != nil {
+		return nil, handleError(err)
+	}
+	return existing, nil
+}
+
+func (s MealServiceImpl) Delete(id int64, opts DeleteOptions) (err error) {
+	err = validateDeleteOptions(id, opts, s.GetExistingIds)
+	if err != nil {
+		return err
+	}
+	err = s.repo.Delete(id, repository.DeleteOptions{Cascade: opts.Cascade, ReplaceWith: opts.ReplaceWith})
 	if err != nil {
 		err = handleError(err)
 	}
@@ -110,12 +150,25 @@ func (s MealServiceImpl) convertRepoModel(repoMeals ...repository.Meal) ([]MealG
 	}
 	for index, rMeal := range repoMeals {
 		meals[index] = MealGet{
-			Id:   rMeal.Id,
-			Name: rMeal.Name,
+			Id:       rMeal.Id,
+			Name:     rMeal.Name,
+			Servings: make(map[int64]float32),
+			Cost:     Cost{Unpriced: []int64{}},
 		}
-		for _, recipeId := range rMeal.Recipes {
+		for i, recipeId := range rMeal.Recipes {
 			meals[index].Recipes = append(meals[index].Recipes, (*usedRecipes)[recipeId])
+			if i < len(rMeal.Servings) && rMeal.Servings[i] > 0 {
+				meals[index].Servings[recipeId] = rMeal.Servings[i]
+			}
+		}
+		for _, recipe := range meals[index].Recipes {
+			meals[index].Cost.addCost(recipe.Cost, meals[index].RecipeShare(recipe))
 		}
+		var labels []DietLabels
+		for _, recipe := range meals[index].Recipes {
+			labels = append(labels, recipe.DietLabels)
+		}
+		meals[index].DietLabels = combineDietLabels(labels...)
 	}
 	return meals, nil
 }
@@ -141,13 +194,47 @@ func (s MealServiceImpl) getAllRecipes(meals ...repository.Meal) (*map[int64]Rec
 	return &recipes, nil
 }
 
+func (s MealServiceImpl) validateReferences(meal MealCreate) error {
+	return referenceError(meal.Recipes, "Recipe", func(index int) string {
+		return fmt.Sprintf("recipes[%d]", index)
+	}, s.rcpService.GetExistingIds)
+}
+
+func toRepoMeal(meal MealCreate) repository.Meal {
+	rMeal := repository.Meal{
+		Id:   meal.Id,
+		Name: meal.Name,
+	}
+	for _, recipeId := range meal.Recipes {
+		rMeal.Recipes = append(rMeal.Recipes, recipeId)
+		rMeal.Servings = append(rMeal.Servings, meal.Servings[recipeId])
+	}
+	return rMeal
+}
+
 func validateMeal(meal MealCreate) error {
-	var messages []string
+	var fields []FieldError
 	if meal.Name == "" {
-		messages = append(messages, "Meal name must be provided")
+		fields = append(fields, FieldError{"name", "Meal name must be provided"})
+	}
+	inMeal := make(map[int64]bool)
+	for _, recipeId := range meal.Recipes {
+		inMeal[recipeId] = true
+	}
+	recipeIds := make([]int64, 0, len(meal.Servings))
+	for recipeId := range meal.Servings {
+		recipeIds = append(recipeIds, recipeId)
+	}
+	sort.Slice(recipeIds, func(i, j int) bool { return recipeIds[i] < recipeIds[j] })
+	for _, recipeId := range recipeIds {
+		if !inMeal[recipeId] {
+			fields = append(fields, FieldError{fmt.Sprintf("servings.%d", recipeId), fmt.Sprintf("Recipe %d is not in the meal", recipeId)})
+		} else if meal.Servings[recipeId] <= 0 {
+			fields = append(fields, FieldError{fmt.Sprintf("servings.%d", recipeId), "Recipe servings must be a positive value"})
+		}
 	}
-	if len(messages) > 0 {
-		return &ValidationError{messages: messages}
+	if len(fields) > 0 {
+		return &ValidationError{fields: fields}
 	}
 	return nil
 }
@@ -0,0 +1,167 @@
+package service
+
+import (
+	"math"
+	"time"
+)
+
+// Sexes used by the BMR equation.
+var Sexes = []string{"female", "male"}
+
+// Activity levels and the factors turning the BMR into the TDEE.
+const (
+	ActivitySedentary  = "sedentary"
+	ActivityLight      = "light"
+	ActivityModerate   = "moderate"
+	ActivityActive     = "active"
+	ActivityVeryActive = "very_active"
+)
+
+var ActivityLevels = []string{ActivitySedentary, ActivityLight, ActivityModerate, ActivityActive, ActivityVeryActive}
+
+var activityFactors = map[string]float64{
+	ActivitySedentary:  1.2,
+	ActivityLight:      1.375,
+	ActivityModerate:   1.55,
+	ActivityActive:     1.725,
+	ActivityVeryActive: 1.9,
+}
+
+// Goals adjust the calories of the TDEE and set the protein per kilogram of
+// body weight.
+const (
+	GoalLose     = "lose"
+	GoalMaintain = "maintain"
+	GoalGain     = "gain"
+)
+
+var Goals = []string{GoalLose, GoalMaintain, GoalGain}
+
+var goalCalories = map[string]float64{GoalLose: -500, GoalMaintain: 0, GoalGain: 300}
+
+var goalProtein = map[string]float64{GoalLose: 1.6, GoalMaintain: 1.2, GoalGain: 1.6}
+
+// fatShare is the share of the target calories coming from fat, the calories
+// left after protein and fat come from carbs.
+const fatShare = 0.3
+
+// MemberCreate is a person of the household. Weight is in kilograms, height
+// in centimetres. ActivityLevel defaults to moderate and Goal to maintain.
+// Targets set their daily targets, targets of 0 are computed from the
+// profile. Allergies are allergens the member must not eat.
+type MemberCreate struct {
+	Id            int64            `json:"id"`
+	Name          string           `json:"name" validate:"required"`
+	BirthDate     Date             `json:"birth_date" validate:"required"`
+	Sex           string           `json:"sex" validate:"required,sex"`
+	Weight        float32          `json:"weight" validate:"gt=0"`
+	Height        float32          `json:"height" validate:"gt=0"`
+	ActivityLevel string           `json:"activity_level" validate:"activity_level"`
+	Goal          string           `json:"goal" validate:"goal"`
+	Targets       NutritionTargets `json:"targets"`
+	Allergies     []string         `json:"allergies" validate:"allergen"`
+}
+
+// MemberGet is a person of the household with the BMR and TDEE of their
+// profile in kcal per day. Targets are their daily targets, the ones set in
+// CustomTargets or computed.
+type MemberGet struct {
+	Id            int64            `json:"id"`
+	Name          string           `json:"name"`
+	BirthDate     Date             `json:"birth_date"`
+	Age           int              `json:"age"`
+	Sex           string           `json:"sex"`
+	Weight        float32          `json:"weight"`
+	Height        float32          `json:"height"`
+	ActivityLevel string           `json:"activity_level"`
+	Goal          string           `json:"goal"`
+	Allergies     []string         `json:"allergies"`
+	Bmr           float32          `json:"bmr"`
+	Tdee          float32          `json:"tdee"`
+	CustomTargets NutritionTargets `json:"custom_targets"`
+	Targets       NutritionTargets `json:"targets"`
+}
+
+// MemberIntake is what a member eats on each day of a meal plan against their
+// daily targets.
+type MemberIntake struct {
+	MemberId int64            `json:"member_id"`
+	Name     string           `json:"name"`
+	Targets  NutritionTargets `json:"targets"`
+	Days     []DayIntake      `json:"days"`
+}
+
+// DayIntake is the nutrition a member eats on a day. Percent is the intake as
+// a percentage of each target, 0 for targets of 0. Allergens are the
+// allergies of the member in the meals of the day.
+type DayIntake struct {
+	DayNutrition
+	Meals     int           `json:"meals"`
+	Percent   IntakePercent `json:"percent"`
+	Allergens []string      `json:"allergens"`
+}
+
+type IntakePercent struct {
+	Calories float32 `json:"calories"`
+	Protein  float32 `json:"protein"`
+	Carbs    float32 `json:"carbs"`
+	Fat      float32 `json:"fat"`
+}
+
+// ageOn returns the age in whole years on the date.
+func ageOn(birthDate Date, date time.Time) int {
+	year, month, day := date.Date()
+	age := year - birthDate.Year()
+	if month < birthDate.Month() || month == birthDate.Month() && day < birthDate.Day() {
+		age--
+	}
+	return age
+}
+
+// bmr estimates the basal metabolic rate with the Mifflin-St Jeor equation,
+// which is meant for adults.
+func bmr(sex string, weight, height float32, age int) float64 {
+	value := 10*float64(weight) + 6.25*float64(height) - 5*float64(age)
+	if sex == "male" {
+		return value + 5
+	}
+	return value - 161
+}
+
+// memberTargets derives daily targets from the TDEE and the goal: protein by
+// body weight, fat by share of the calories and carbs for the rest. Custom
+// targets above 0 replace the derived ones and are taken into account by the
+// targets derived from them.
+func memberTargets(tdee float64, weight float32, goal string, custom NutritionTargets) NutritionTargets {
+	orDerived := func(target float32, derived float64) float64 {
+		if target > 0 {
+			return float64(target)
+		}
+		return derived
+	}
+	calories := orDerived(custom.Calories, math.Max(tdee+goalCalories[goal], 0))
+	protein := orDerived(custom.Protein, float64(weight)*goalProtein[goal])
+	fat := orDerived(custom.Fat, calories*fatShare/9)
+	carbs := orDerived(custom.Carbs, math.Max((calories-protein*4-fat*9)/4, 0))
+	return NutritionTargets{
+		Calories: float32(math.Round(calories)),
+		Protein:  float32(math.Round(protein)),
+		Carbs:    float32(math.Round(carbs)),
+		Fat:      float32(math.Round(fat)),
+	}
+}
+
+func intakePercent(intake DayNutrition, targets NutritionTargets) IntakePercent {
+	percent := func(value, target float32) float32 {
+		if target == 0 {
+			return 0
+		}
+		return float32(math.Round(float64(value / target * 100)))
+	}
+	return IntakePercent{
+		Calories: percent(intake.Calories, targets.Calories),
+		Protein:  percent(intake.Protein, targets.Protein),
+		Carbs:    percent(intake.Carbs, targets.Carbs),
+		Fat:      percent(intake.Fat, targets.Fat),
+	}
+}
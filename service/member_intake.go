@@ -0,0 +1,84 @@
+package service
+
+import "sort"
+
+// GetIntake reports for each member assigned to entries of the meal plan what
+// they eat on each day of it against their current daily targets. Members eat
+// a person's share of the entries they are assigned to, leftovers included.
+func (s MealPlanServiceImpl) GetIntake(id int64) ([]MemberIntake, error) {
+	mealPlan, err := s.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	var memberIds []int64
+	seen := make(map[int64]bool)
+	for _, entry := range mealPlan.Entries {
+		for _, memberId := range entry.Members {
+			if !seen[memberId] {
+				seen[memberId] = true
+				memberIds = append(memberIds, memberId)
+			}
+		}
+	}
+	members, err := s.memberService.GetList(memberIds)
+	if err != nil {
+		return nil, err
+	}
+	start := NewDate(mealPlan.DateStarted)
+	days := 0
+	for _, entry := range mealPlan.Entries {
+		if day := entry.Date.DaysSince(start) + 1; day > days {
+			days = day
+		}
+	}
+	intakes := make([]MemberIntake, len(members))
+	for index, member := range members {
+		intakes[index] = memberIntake(member, mealPlan, start, days)
+	}
+	return intakes, nil
+}
+
+func memberIntake(member MemberGet, mealPlan MealPlanGet, start Date, days int) MemberIntake {
+	intake := MemberIntake{
+		MemberId: member.Id,
+		Name:     member.Name,
+		Targets:  member.Targets,
+		Days:     make([]DayIntake, days),
+	}
+	for day := range intake.Days {
+		intake.Days[day] = DayIntake{DayNutrition: DayNutrition{Date: start.AddDays(day)}, Allergens: []string{}}
+	}
+	for _, entry := range mealPlan.Entries {
+		if !containsId(entry.Members, member.Id) {
+			continue
+		}
+		day := &intake.Days[entry.Date.DaysSince(start)]
+		day.Meals++
+		for _, recipe := range entry.Meal.Recipes {
+			share := entry.PersonShare(recipe)
+			day.Calories += recipe.Calories * share
+			day.Protein += recipe.Protein * share
+			day.Carbs += recipe.Carbs * share
+			day.Fat += recipe.Fat * share
+		}
+		for _, allergen := range entry.Meal.Allergens {
+			if contains(member.Allergies, allergen) && !contains(day.Allergens, allergen) {
+				day.Allergens = append(day.Allergens, allergen)
+			}
+		}
+	}
+	for day := range intake.Days {
+		sort.Strings(intake.Days[day].Allergens)
+		intake.Days[day].Percent = intakePercent(intake.Days[day].DayNutrition, intake.Targets)
+	}
+	return intake
+}
+
+func containsId(ids []int64, id int64) bool {
+	for _, v := range ids {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
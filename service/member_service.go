@@ -0,0 +1,203 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/cookbook/repository"
+)
+
+// maxMemberNameLength limits the length of member names.
+const maxMemberNameLength = 64
+
+type MemberService interface {
+	Get(int64) (MemberGet, error)
+	GetList([]int64) ([]MemberGet, error)
+	GetAll() ([]MemberGet, error)
+	GetExistingIds([]int64) (map[int64]bool, error)
+	Create(MemberCreate) (int64, error)
+	Update(MemberCreate) error
+	Delete(int64) error
+}
+
+type MemberServiceImpl struct {
+	repo *repository.MemberRepository
+}
+
+func NewMemberService(r *repository.MemberRepository) MemberService {
+	return MemberServiceImpl{
+		repo: r,
+	}
+}
+
+func (s MemberServiceImpl) Get(id int64) (MemberGet, error) {
+	rMember, err := s.repo.Get(id)
+	if err != nil {
+		return MemberGet{}, handleError(err)
+	}
+	return convertRepoMembers(time.Now(), rMember)[0], nil
+}
+
+func (s MemberServiceImpl) GetList(ids []int64) ([]MemberGet, error) {
+	rMembers, err := s.repo.GetList(ids)
+	if err != nil {
+		return []MemberGet{}, handleError(err)
+	}
+	return convertRepoMembers(time.Now(), rMembers...), nil
+}
+
+// GetAll returns the members ordered by name.
+func (s MemberServiceImpl) GetAll() ([]MemberGet, error) {
+	rMembers, err := s.repo.GetAll()
+	if err != nil {
+		return []MemberGet{}, handleError(err)
+	}
+	return convertRepoMembers(time.Now(), rMembers...), nil
+}
+
+func (s MemberServiceImpl) GetExistingIds(ids []int64) (map[int64]bool, error) {
+	existing, err := s.repo.GetExistingIds(ids)
+	if err != nil {
+		return nil, handleError(err)
+	}
+	return existing, nil
+}
+
+func (s MemberServiceImpl) Create(member MemberCreate) (int64, error) {
+	member = defaultMember(member)
+	err := validateMember(member)
+	if err != nil {
+		return 0, err
+	}
+	id, err := s.repo.Create(toRepoMember(member))
+	if err != nil {
+		return 0, handleError(err)
+	}
+	return id, nil
+}
+
+func (s MemberServiceImpl) Update(member MemberCreate) error {
+	member = defaultMember(member)
+	err := validateMember(member)
+	if err != nil {
+		return err
+	}
+	err = s.repo.Update(toRepoMember(member))
+	if err != nil {
+		return handleError(err)
+	}
+	return nil
+}
+
+// Delete removes the member, unassigning it from meal plan entries.
+func (s MemberServiceImpl) Delete(id int64) error {
+	err := s.repo.Delete(id)
+	if err != nil {
+		return handleError(err)
+	}
+	return nil
+}
+
+// convertRepoMembers computes the age, BMR, TDEE and targets of the members
+// on the date.
+func convertRepoMembers(date time.Time, rMembers ...repository.Member) []MemberGet {
+	members := make([]MemberGet, len(rMembers))
+	for index, rMember := range rMembers {
+		birthDate := NewDate(rMember.BirthDate)
+		members[index] = MemberGet{
+			Id:            rMember.Id,
+			Name:          rMember.Name,
+			BirthDate:     birthDate,
+			Age:           ageOn(birthDate, date),
+			Sex:           rMember.Sex,
+			Weight:        rMember.Weight,
+			Height:        rMember.Height,
+			ActivityLevel: rMember.ActivityLevel,
+			Goal:          rMember.Goal,
+			Allergies:     append([]string{}, rMember.Allergies...),
+			CustomTargets: NutritionTargets{
+				Calories: rMember.TargetCalories,
+				Protein:  rMember.TargetProtein,
+				Carbs:    rMember.TargetCarbs,
+				Fat:      rMember.TargetFat,
+			},
+		}
+		member := &members[index]
+		basal := bmr(member.Sex, member.Weight, member.Height, member.Age)
+		tdee := basal * activityFactors[member.ActivityLevel]
+		member.Bmr = float32(math.Round(basal))
+		member.Tdee = float32(math.Round(tdee))
+		member.Targets = memberTargets(tdee, member.Weight, member.Goal, member.CustomTargets)
+	}
+	return members
+}
+
+func toRepoMember(member MemberCreate) repository.Member {
+	return repository.Member{
+		Id:             member.Id,
+		Name:           member.Name,
+		BirthDate:      member.BirthDate.Time,
+		Sex:            member.Sex,
+		Weight:         member.Weight,
+		Height:         member.Height,
+		ActivityLevel:  member.ActivityLevel,
+		Goal:           member.Goal,
+		TargetCalories: member.Targets.Calories,
+		TargetProtein:  member.Targets.Protein,
+		TargetCarbs:    member.Targets.Carbs,
+		TargetFat:      member.Targets.Fat,
+		Allergies:      append([]string{}, member.Allergies...),
+	}
+}
+
+func defaultMember(member MemberCreate) MemberCreate {
+	if member.ActivityLevel == "" {
+		member.ActivityLevel = ActivityModerate
+	}
+	if member.Goal == "" {
+		member.Goal = GoalMaintain
+	}
+	return member
+}
+
+func validateMember(member MemberCreate) error {
+	var fields []FieldError
+	if member.Name == "" {
+		fields = append(fields, FieldError{"name", "Member name must be provided"})
+	} else if len(member.Name) > maxMemberNameLength {
+		fields = append(fields, FieldError{"name", fmt.Sprintf("Member name must be at most %d characters long", maxMemberNameLength)})
+	}
+	if member.BirthDate.IsZero() {
+		fields = append(fields, FieldError{"birth_date", "Birth date must be provided"})
+	} else if member.BirthDate.After(time.Now()) {
+		fields = append(fields, FieldError{"birth_date", "Birth date must not be in the future"})
+	}
+	if !contains(Sexes, member.Sex) {
+		fields = append(fields, FieldError{"sex", fmt.Sprintf("Sex must be one of %v", Sexes)})
+	}
+	if member.Weight <= 0 {
+		fields = append(fields, FieldError{"weight", "Weight must be greater than 0"})
+	}
+	if member.Height <= 0 {
+		fields = append(fields, FieldError{"height", "Height must be greater than 0"})
+	}
+	if !contains(ActivityLevels, member.ActivityLevel) {
+		fields = append(fields, FieldError{"activity_level", fmt.Sprintf("Unknown activity level %s", member.ActivityLevel)})
+	}
+	if !contains(Goals, member.Goal) {
+		fields = append(fields, FieldError{"goal", fmt.Sprintf("Unknown goal %s", member.Goal)})
+	}
+	if member.Targets.Calories < 0 || member.Targets.Protein < 0 || member.Targets.Carbs < 0 || member.Targets.Fat < 0 {
+		fields = append(fields, FieldError{"targets", "Targets must not be negative"})
+	}
+	for index, allergen := range member.Allergies {
+		if !contains(Allergens, allergen) {
+			fields = append(fields, FieldError{fmt.Sprintf("allergies[%d]", index), fmt.Sprintf("Unknown allergen %s", allergen)})
+		}
+	}
+	if len(fields) > 0 {
+		return &ValidationError{fields: fields}
+	}
+	return nil
+}
@@ -1,18 +1,21 @@
 package service
 
-import "fmt"
+import (
+	"fmt"
+	"sort"
+)
 
 type Quantity struct {
-	Amount float32 `json:"amount"`
-	Unit   string  `json:"unit"`
+	Amount float32 `json:"amount" validate:"gt=0"`
+	Unit   string  `json:"unit" validate:"required,unit"`
 }
 
 type NutritionalValue struct {
 	Quantity `json:"quantity"`
-	Calories float32 `json:"calories"`
-	Protein  float32 `json:"protein"`
-	Carbs    float32 `json:"carbs"`
-	Fat      float32 `json:"fat"`
+	Calories float32 `json:"calories" validate:"gte=0"`
+	Protein  float32 `json:"protein" validate:"gte=0"`
+	Carbs    float32 `json:"carbs" validate:"gte=0"`
+	Fat      float32 `json:"fat" validate:"gte=0"`
 }
 
 func ConvertUnit(src, dst string) (float32, error) {
@@ -32,6 +35,16 @@ func isUnitValid(unit string) bool {
 	return ok
 }
 
+// Units returns the supported measurement units in alphabetical order.
+func Units() []string {
+	units := make([]string, 0, len(unitConversionTable))
+	for unit := range unitConversionTable {
+		units = append(units, unit)
+	}
+	sort.Strings(units)
+	return units
+}
+
 var unitConversionTable = map[string]map[string]float32{
 	"kg": {
 		"g":  1000.0,
@@ -40,7 +53,7 @@ var unitConversionTable = map[string]map[string]float32{
 	},
 	"g": {
 		"kg": 0.001,
-		"g":  0.0022046,
+		"lb": 0.0022046,
 		"oz": 0.0352739619,
 	},
 	"lb": {
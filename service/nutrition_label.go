@@ -0,0 +1,251 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// Nutrition label styles, US follows the FDA Nutrition Facts rules and EU the
+// nutrition declaration of Regulation (EU) No 1169/2011.
+const (
+	LabelStyleUS = "us"
+	LabelStyleEU = "eu"
+)
+
+var LabelStyles = []string{LabelStyleUS, LabelStyleEU}
+
+const kilojoulesPerKilocalorie = 4.184
+
+// ReferenceIntakes are the daily amounts the percentages of a label are
+// based on, nutrients with a reference of 0 get no percentage.
+type ReferenceIntakes struct {
+	Calories float32 `json:"calories"`
+	Fat      float32 `json:"fat"`
+	Carbs    float32 `json:"carbs"`
+	Protein  float32 `json:"protein"`
+}
+
+// DefaultReferenceIntakes returns the FDA daily values for US labels and the
+// reference intakes of an average adult for EU labels.
+func DefaultReferenceIntakes(style string) ReferenceIntakes {
+	if style == LabelStyleEU {
+		return ReferenceIntakes{Calories: 2000, Fat: 70, Carbs: 260, Protein: 50}
+	}
+	return ReferenceIntakes{Calories: 2000, Fat: 78, Carbs: 275, Protein: 50}
+}
+
+// NutritionLabel is a nutrition panel for a recipe or meal split into
+// servings. ServingSize is the weight of a serving in grams, 0 if some
+// ingredients aren't measured by weight.
+type NutritionLabel struct {
+	Style       string              `json:"style"`
+	Name        string              `json:"name"`
+	Servings    float32             `json:"servings"`
+	ServingSize float32             `json:"serving_size"`
+	Intakes     ReferenceIntakes    `json:"reference_intakes"`
+	Rows        []NutritionLabelRow `json:"rows"`
+}
+
+// NutritionLabelRow declares a nutrient per serving, and per 100 g on EU
+// labels if the serving size is known. Amount is rounded as the label style
+// requires, if LessThan is set it is an upper bound. Declared is the amount
+// as printed, e.g. "<1 g". DailyValue is the rounded percentage of the
+// reference intake, nil if there is none.
+type NutritionLabelRow struct {
+	Nutrient   string  `json:"nutrient"`
+	Unit       string  `json:"unit"`
+	Amount     float32 `json:"amount"`
+	LessThan   bool    `json:"less_than"`
+	Declared   string  `json:"declared"`
+	Per100g    string  `json:"per_100g,omitempty"`
+	DailyValue *int    `json:"daily_value"`
+}
+
+// RecipeNutritionLabel returns the label of the recipe split into servings,
+// its own servings if 0.
+func RecipeNutritionLabel(recipe RecipeGet, style string, servings float32, intakes ReferenceIntakes) NutritionLabel {
+	if servings == 0 {
+		servings = float32(recipe.Servings)
+	}
+	totals := nutrition{float64(recipe.Calories), float64(recipe.Protein), float64(recipe.Carbs), float64(recipe.Fat)}
+	return newNutritionLabel(style, recipe.Name, totals, recipeWeight(recipe), servings, intakes)
+}
+
+// MealNutritionLabel returns the label of a portion of the meal split into
+// servings, the portion is a single serving if 0.
+func MealNutritionLabel(meal MealGet, style string, servings float32, intakes ReferenceIntakes) NutritionLabel {
+	if servings == 0 {
+		servings = 1
+	}
+	var weight float64
+	for _, recipe := range meal.Recipes {
+		recipeWeight := recipeWeight(recipe)
+		if recipeWeight == 0 {
+			weight = 0
+			break
+		}
+		weight += recipeWeight * float64(meal.RecipeShare(recipe))
+	}
+	return newNutritionLabel(style, meal.Name, mealNutrition(meal), weight, servings, intakes)
+}
+
+// recipeWeight returns the weight of the ingredients in grams, 0 if some of
+// them aren't measured by weight.
+func recipeWeight(recipe RecipeGet) float64 {
+	var weight float64
+	for _, ing := range recipe.Ingredients {
+		scale, err := ConvertUnit(ing.Quantity.Unit, "g")
+		if err != nil {
+			return 0
+		}
+		weight += float64(ing.Quantity.Amount * scale)
+	}
+	return weight
+}
+
+func newNutritionLabel(style, name string, totals nutrition, weight float64, servings float32, intakes ReferenceIntakes) NutritionLabel {
+	if servings <= 0 {
+		servings = 1
+	}
+	label := NutritionLabel{
+		Style:       style,
+		Name:        name,
+		Servings:    servings,
+		ServingSize: float32(math.Round(weight / float64(servings))),
+		Intakes:     intakes,
+	}
+	serving := totals
+	for k := range serving {
+		serving[k] /= float64(servings)
+	}
+	references := nutrition{float64(intakes.Calories), float64(intakes.Protein), float64(intakes.Carbs), float64(intakes.Fat)}
+	if style == LabelStyleEU {
+		names := []string{"Energy", "Protein", "Carbohydrate", "Fat"}
+		euRow := func(k int, amount float64) NutritionLabelRow {
+			if k == 0 {
+				return euEnergyRow(amount)
+			}
+			return euNutrientRow(names[k], amount)
+		}
+		// EU declarations list fat before carbohydrate and protein.
+		for _, k := range []int{0, 3, 2, 1} {
+			row := euRow(k, serving[k])
+			if weight > 0 {
+				row.Per100g = euRow(k, totals[k]*100/weight).Declared
+			}
+			row.DailyValue = dailyValue(serving[k], references[k])
+			label.Rows = append(label.Rows, row)
+		}
+		return label
+	}
+	label.Rows = []NutritionLabelRow{
+		usCaloriesRow(serving[0]),
+		usFatRow(serving[3]),
+		usGramsRow("Total Carbohydrate", serving[2]),
+		usGramsRow("Protein", serving[1]),
+	}
+	// Calories get no percentage on US labels, their reference only goes into
+	// the footnote.
+	for index, k := range []int{3, 2, 1} {
+		label.Rows[index+1].DailyValue = dailyValue(serving[k], references[k])
+	}
+	return label
+}
+
+// dailyValue returns the amount as a percentage of the reference rounded to
+// the nearest percent, based on the amount before rounding.
+func dailyValue(amount, reference float64) *int {
+	if reference <= 0 {
+		return nil
+	}
+	percent := int(math.Round(amount / reference * 100))
+	return &percent
+}
+
+// roundTo rounds the value to the nearest multiple of step.
+func roundTo(value, step float64) float64 {
+	return math.Round(value/step) * step
+}
+
+func formatLabelAmount(amount float64) string {
+	return strconv.FormatFloat(amount, 'f', -1, 64)
+}
+
+// usCaloriesRow rounds calories below 5 to 0, up to 50 to the nearest 5 and
+// above that to the nearest 10.
+func usCaloriesRow(calories float64) NutritionLabelRow {
+	switch {
+	case calories < 5:
+		calories = 0
+	case calories <= 50:
+		calories = roundTo(calories, 5)
+	default:
+		calories = roundTo(calories, 10)
+	}
+	return NutritionLabelRow{Nutrient: "Calories", Unit: "kcal", Amount: float32(calories), Declared: formatLabelAmount(calories)}
+}
+
+// usFatRow rounds fat below 0.5 g to 0, below 5 g to the nearest 0.5 g and
+// above that to the nearest gram.
+func usFatRow(fat float64) NutritionLabelRow {
+	switch {
+	case fat < 0.5:
+		fat = 0
+	case fat < 5:
+		fat = roundTo(fat, 0.5)
+	default:
+		fat = roundTo(fat, 1)
+	}
+	return NutritionLabelRow{Nutrient: "Total Fat", Unit: "g", Amount: float32(fat), Declared: formatLabelAmount(fat) + " g"}
+}
+
+// usGramsRow rounds carbohydrates and protein below 0.5 g to 0, declares
+// them as less than 1 g below 1 g and rounds to the nearest gram above that.
+func usGramsRow(nutrient string, grams float64) NutritionLabelRow {
+	row := NutritionLabelRow{Nutrient: nutrient, Unit: "g"}
+	switch {
+	case grams < 0.5:
+		row.Declared = "0 g"
+	case grams < 1:
+		row.Amount, row.LessThan, row.Declared = 1, true, "<1 g"
+	default:
+		row.Amount = float32(roundTo(grams, 1))
+		row.Declared = formatLabelAmount(float64(row.Amount)) + " g"
+	}
+	return row
+}
+
+// Kilojoules converts kilocalories to kilojoules rounded to a whole number.
+func Kilojoules(kcal float32) float64 {
+	return math.Round(float64(kcal) * kilojoulesPerKilocalorie)
+}
+
+// euEnergyRow declares energy in kilojoules and kilocalories, both rounded
+// to whole numbers.
+func euEnergyRow(calories float64) NutritionLabelRow {
+	kcal := math.Round(calories)
+	kj := math.Round(calories * kilojoulesPerKilocalorie)
+	return NutritionLabelRow{Nutrient: "Energy", Unit: "kcal", Amount: float32(kcal), Declared: fmt.Sprintf("%g kJ / %g kcal", kj, kcal)}
+}
+
+// euNutrientRow rounds amounts of 10 g and more to the nearest gram and
+// smaller ones to the nearest 0.1 g, amounts of 0.5 g or less are declared as
+// less than 0.5 g. Amounts just below 10 g that round up to it are declared
+// as whole grams.
+func euNutrientRow(nutrient string, grams float64) NutritionLabelRow {
+	row := NutritionLabelRow{Nutrient: nutrient, Unit: "g"}
+	switch {
+	case grams <= 0:
+		row.Declared = "0 g"
+	case grams <= 0.5:
+		row.Amount, row.LessThan, row.Declared = 0.5, true, "<0.5 g"
+	case roundTo(grams, 0.1) < 10:
+		row.Amount = float32(roundTo(grams, 0.1))
+		row.Declared = strconv.FormatFloat(roundTo(grams, 0.1), 'f', 1, 64) + " g"
+	default:
+		row.Amount = float32(roundTo(grams, 1))
+		row.Declared = formatLabelAmount(roundTo(grams, 1)) + " g"
+	}
+	return row
+}
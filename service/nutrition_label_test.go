@@ -0,0 +1,49 @@
+package service
+
+import "testing"
+
+func TestLabelRows(t *testing.T) {
+	tests := []struct {
+		name     string
+		row      NutritionLabelRow
+		amount   float32
+		lessThan bool
+		declared string
+	}{
+		{"us calories below 5", usCaloriesRow(4.9), 0, false, "0"},
+		{"us calories at 5", usCaloriesRow(5), 5, false, "5"},
+		{"us calories to nearest 5", usCaloriesRow(7.5), 10, false, "10"},
+		{"us calories at 50", usCaloriesRow(50), 50, false, "50"},
+		{"us calories above 50", usCaloriesRow(54.9), 50, false, "50"},
+		{"us calories to nearest 10", usCaloriesRow(55), 60, false, "60"},
+
+		{"us fat below 0.5 g", usFatRow(0.49), 0, false, "0 g"},
+		{"us fat at 0.5 g", usFatRow(0.5), 0.5, false, "0.5 g"},
+		{"us fat to nearest 0.5 g", usFatRow(4.7), 4.5, false, "4.5 g"},
+		{"us fat at 5 g", usFatRow(5), 5, false, "5 g"},
+		{"us fat to nearest gram", usFatRow(5.5), 6, false, "6 g"},
+
+		{"us grams below 0.5 g", usGramsRow("Protein", 0.49), 0, false, "0 g"},
+		{"us grams at 0.5 g", usGramsRow("Protein", 0.5), 1, true, "<1 g"},
+		{"us grams below 1 g", usGramsRow("Protein", 0.99), 1, true, "<1 g"},
+		{"us grams at 1 g", usGramsRow("Protein", 1), 1, false, "1 g"},
+		{"us grams to nearest gram", usGramsRow("Protein", 1.5), 2, false, "2 g"},
+
+		{"eu nutrient of 0 g", euNutrientRow("Fat", 0), 0, false, "0 g"},
+		{"eu nutrient at 0.5 g", euNutrientRow("Fat", 0.5), 0.5, true, "<0.5 g"},
+		{"eu nutrient above 0.5 g", euNutrientRow("Fat", 0.51), 0.5, false, "0.5 g"},
+		{"eu nutrient below 10 g", euNutrientRow("Fat", 9.94), 9.9, false, "9.9 g"},
+		{"eu nutrient rounding up to 10 g", euNutrientRow("Fat", 9.96), 10, false, "10 g"},
+		{"eu nutrient at 10 g", euNutrientRow("Fat", 10), 10, false, "10 g"},
+		{"eu nutrient to nearest gram", euNutrientRow("Fat", 10.5), 11, false, "11 g"},
+
+		{"eu energy", euEnergyRow(100), 100, false, "418 kJ / 100 kcal"},
+		{"eu energy below 0.5 kcal", euEnergyRow(0.4), 0, false, "2 kJ / 0 kcal"},
+		{"eu energy at 0.5 kcal", euEnergyRow(0.5), 1, false, "2 kJ / 1 kcal"},
+	}
+	for _, test := range tests {
+		if test.row.Amount != test.amount || test.row.LessThan != test.lessThan || test.row.Declared != test.declared {
+			t.Errorf("%s: got %v, %t, %q, want %v, %t, %q", test.name, test.row.Amount, test.row.LessThan, test.row.Declared, test.amount, test.lessThan, test.declared)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package service
+
+type PantryItemGet struct {
+	Id         int64      `json:"id"`
+	Ingredient Ingredient `json:"ingredient"`
+	Quantity   Quantity   `json:"quantity"`
+	ExpiresOn  *Date      `json:"expires_on"`
+	Location   string     `json:"location"`
+}
+
+type PantryItemCreate struct {
+	Id           int64    `json:"id"`
+	IngredientId int64    `json:"ingredient_id"`
+	Quantity     Quantity `json:"quantity"`
+	ExpiresOn    *Date    `json:"expires_on"`
+	Location     string   `json:"location"`
+}
+
+// IngredientAmount is an amount of an ingredient in the given unit.
+type IngredientAmount struct {
+	IngredientId int64   `json:"ingredient_id"`
+	Name         string  `json:"name"`
+	Amount       float32 `json:"amount"`
+	Unit         string  `json:"unit"`
+}
+
+// PantryDeduction is the amount taken out of a pantry item, in its unit.
+type PantryDeduction struct {
+	PantryItemId int64 `json:"pantry_item_id"`
+	IngredientAmount
+}
+
+// CookedEntry reports what cooking a meal plan entry took out of the pantry
+// and the amounts the pantry was missing.
+type CookedEntry struct {
+	Deducted []PantryDeduction  `json:"deducted"`
+	Missing  []IngredientAmount `json:"missing"`
+}
+
+// ShoppingListItem is an ingredient needed by the uncooked entries of a meal
+// plan. Amounts of the same ingredient are converted to the unit first used,
+// amounts that can't be converted get an item of their own.
+type ShoppingListItem struct {
+	IngredientId int64   `json:"ingredient_id"`
+	Name         string  `json:"name"`
+	Unit         string  `json:"unit"`
+	Needed       float32 `json:"needed"`
+	InPantry     float32 `json:"in_pantry"`
+	ToBuy        float32 `json:"to_buy"`
+	// Cost of the amount to buy, nil if the ingredient has no usable price.
+	Cost *float32 `json:"cost"`
+}
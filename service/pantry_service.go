@@ -0,0 +1,173 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cookbook/repository"
+)
+
+// maxLocationLength limits the length of pantry locations.
+const maxLocationLength = 64
+
+type PantryService interface {
+	Get(int64) (PantryItemGet, error)
+	GetAll() ([]PantryItemGet, error)
+	GetByIngredients([]int64) ([]PantryItemGet, error)
+	Create(PantryItemCreate) (int64, error)
+	Update(PantryItemCreate) error
+	Delete(int64) error
+}
+
+type PantryServiceImpl struct {
+	repo       *repository.PantryRepository
+	ingService IngredientService
+}
+
+func NewPantryService(r *repository.PantryRepository, is IngredientService) PantryService {
+	return PantryServiceImpl{
+		repo:       r,
+		ingService: is,
+	}
+}
+
+func (s PantryServiceImpl) Get(id int64) (PantryItemGet, error) {
+	rItem, err := s.repo.Get(id)
+	if err != nil {
+		return PantryItemGet{}, handleError(err)
+	}
+	items, err := s.convertRepoModel(rItem)
+	if err != nil {
+		return PantryItemGet{}, err
+	}
+	return items[0], nil
+}
+
+// GetAll returns the pantry items, the ones expiring first first.
+func (s PantryServiceImpl) GetAll() ([]PantryItemGet, error) {
+	rItems, err := s.repo.GetAll()
+	if err != nil {
+		return []PantryItemGet{}, handleError(err)
+	}
+	return s.convertRepoModel(rItems...)
+}
+
+// GetByIngredients returns the pantry items of the ingredients, the ones
+// expiring first first.
+func (s PantryServiceImpl) GetByIngredients(ingredientIds []int64) ([]PantryItemGet, error) {
+	rItems, err := s.repo.GetByIngredients(ingredientIds)
+	if err != nil {
+		return []PantryItemGet{}, handleError(err)
+	}
+	return s.convertRepoModel(rItems...)
+}
+
+func (s PantryServiceImpl) Create(item PantryItemCreate) (int64, error) {
+	err := s.validate(item)
+	if err != nil {
+		return 0, err
+	}
+	id, err := s.repo.Create(toRepoPantryItem(item))
+	if err != nil {
+		return 0, handleError(err)
+	}
+	return id, nil
+}
+
+func (s PantryServiceImpl) Update(item PantryItemCreate) error {
+	err := s.validate(item)
+	if err != nil {
+		return err
+	}
+	err = s.repo.Update(toRepoPantryItem(item))
+	if err != nil {
+		return handleError(err)
+	}
+	return nil
+}
+
+func (s PantryServiceImpl) Delete(id int64) error {
+	err := s.repo.Delete(id)
+	if err != nil {
+		return handleError(err)
+	}
+	return nil
+}
+
+func (s PantryServiceImpl) convertRepoModel(rItems ...repository.PantryItem) ([]PantryItemGet, error) {
+	items := make([]PantryItemGet, len(rItems))
+	if len(rItems) == 0 {
+		return items, nil
+	}
+	var ids []int64
+	for _, rItem := range rItems {
+		ids = append(ids, rItem.IngredientId)
+	}
+	ingredients, err := s.ingService.GetList(ids)
+	if err != nil {
+		return []PantryItemGet{}, err
+	}
+	byId := make(map[int64]Ingredient)
+	for _, ing := range ingredients {
+		byId[ing.Id] = ing
+	}
+	for index, rItem := range rItems {
+		items[index] = PantryItemGet{
+			Id:         rItem.Id,
+			Ingredient: byId[rItem.IngredientId],
+			Quantity:   Quantity{Amount: rItem.Amount, Unit: rItem.Unit},
+			Location:   rItem.Location,
+		}
+		if rItem.ExpiresOn != nil {
+			expiresOn := NewDate(*rItem.ExpiresOn)
+			items[index].ExpiresOn = &expiresOn
+		}
+	}
+	return items, nil
+}
+
+func toRepoPantryItem(item PantryItemCreate) repository.PantryItem {
+	rItem := repository.PantryItem{
+		Id:           item.Id,
+		IngredientId: item.IngredientId,
+		Amount:       item.Quantity.Amount,
+		Unit:         item.Quantity.Unit,
+		Location:     item.Location,
+	}
+	if item.ExpiresOn != nil {
+		rItem.ExpiresOn = &item.ExpiresOn.Time
+	}
+	return rItem
+}
+
+func (s PantryServiceImpl) validate(item PantryItemCreate) error {
+	err := validatePantryItem(item)
+	if err != nil {
+		return err
+	}
+	return referenceError([]int64{item.IngredientId}, "Ingredient", func(int) string {
+		return "ingredient_id"
+	}, s.ingService.GetExistingIds)
+}
+
+func validatePantryItem(item PantryItemCreate) error {
+	var fields []FieldError
+	if !isUnitValid(item.Quantity.Unit) {
+		fields = append(fields, FieldError{"quantity.unit", fmt.Sprintf("Invalid measurement unit %s", item.Quantity.Unit)})
+	}
+	if item.Quantity.Amount <= 0 {
+		fields = append(fields, FieldError{"quantity.amount", "Amount must be greater then 0"})
+	}
+	if len(item.Location) > maxLocationLength {
+		fields = append(fields, FieldError{"location", fmt.Sprintf("Location must be at most %d characters long", maxLocationLength)})
+	}
+	if len(fields) > 0 {
+		return &ValidationError{fields: fields}
+	}
+	return nil
+}
+
+// inStock reports whether the pantry item can still be used on the date.
+func (item PantryItemGet) inStock(date time.Time) bool {
+	return item.ExpiresOn == nil || !item.ExpiresOn.Before(NewDate(date).Time)
+}
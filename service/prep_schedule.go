@@ -0,0 +1,558 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Defaults for steps whose text gives no duration and whose recipe has no
+// prep or cook time to share among them.
+const (
+	defaultActiveMinutes  = 5
+	defaultPassiveMinutes = 15
+	defaultPreheatMinutes = 15
+	maxCooks              = 10
+)
+
+// PrepQuery selects the meal plan entries to prep for: those neither cooked
+// yet nor leftovers between From and To, both inclusive and ignored if zero.
+// The dishes are to be ready at ServeAt, Cooks is the number of people doing
+// the hands-on work.
+type PrepQuery struct {
+	ServeAt time.Time
+	From    Date
+	To      Date
+	Cooks   int
+}
+
+// PrepSchedule is a timeline of the steps of the recipes to prep, counting
+// back from ServeAt. StartAt is when the first task starts.
+type PrepSchedule struct {
+	ServeAt      time.Time     `json:"serve_at"`
+	StartAt      time.Time     `json:"start_at"`
+	Cooks        int           `json:"cooks"`
+	Recipes      []PrepRecipe  `json:"recipes"`
+	Tasks        []PrepTask    `json:"tasks"`
+	OvenSessions []OvenSession `json:"oven_sessions"`
+}
+
+// PrepRecipe is a recipe of the schedule, Servings adds up the servings of it
+// cooked for the meal plan entries.
+type PrepRecipe struct {
+	Id       int64   `json:"id"`
+	Name     string  `json:"name"`
+	Servings float32 `json:"servings"`
+}
+
+// PrepTask is one or more steps done together. HandsOn tasks keep a cook
+// busy, the others like baking or simmering run alongside other tasks.
+// OvenTemperature is in degrees Celsius, 0 if the task doesn't use the oven.
+// Estimated is set if the duration isn't given by the step itself.
+type PrepTask struct {
+	Start           time.Time     `json:"start"`
+	End             time.Time     `json:"end"`
+	Minutes         int           `json:"minutes"`
+	Description     string        `json:"description"`
+	HandsOn         bool          `json:"hands_on"`
+	OvenTemperature int           `json:"oven_temperature,omitempty"`
+	Estimated       bool          `json:"estimated"`
+	Steps           []PrepStepRef `json:"steps"`
+}
+
+// PrepStepRef points to a step of a recipe, Step counts from 1 and is 0 for
+// recipes without steps.
+type PrepStepRef struct {
+	RecipeId   int64  `json:"recipe_id"`
+	RecipeName string `json:"recipe_name"`
+	Step       int    `json:"step"`
+	Text       string `json:"text"`
+}
+
+// OvenSession is a span the oven is used at a temperature.
+type OvenSession struct {
+	Temperature int       `json:"temperature"`
+	Start       time.Time `json:"start"`
+	End         time.Time `json:"end"`
+}
+
+var (
+	stepDuration    = regexp.MustCompile(`(?i)(\d+(?:[.,]\d+)?)(?:\s*(?:-|–|to)\s*(\d+(?:[.,]\d+)?))?\s*(hours?|hrs?|h|minutes?|mins?|m)\b`)
+	stepTemperature = regexp.MustCompile(`(?i)(\d{2,3})\s*(?:°|º|degrees?)?\s*([CF])?\b`)
+	stepWord        = regexp.MustCompile(`[\p{L}-]+`)
+)
+
+// passiveWords mark steps that need no attention while they run.
+var passiveWords = []string{
+	"bake", "baking", "roast", "simmer", "rest", "chill", "marinate", "rise", "prove", "proof",
+	"cool", "refrigerate", "soak", "braise", "steep", "set", "freeze", "let", "leave", "preheat", "pre-heat",
+}
+
+var ovenWords = []string{"oven", "bake", "baking", "roast", "broil", "preheat", "pre-heat"}
+
+// prepVerbs mark steps preparing ingredients, the same prep across recipes is
+// done in one go.
+var prepVerbs = []string{"chop", "dice", "mince", "slice", "grate", "peel", "julienne", "crush", "zest", "trim", "cube", "shred"}
+
+var prepAdverbs = []string{"finely", "roughly", "thinly", "coarsely", "then"}
+
+// GetPrepSchedule plans prepping the recipes of the meal plan entries the
+// query selects at once, so that they are all ready at the serving time.
+func (s MealPlanServiceImpl) GetPrepSchedule(id int64, query PrepQuery) (PrepSchedule, error) {
+	if query.ServeAt.IsZero() {
+		return PrepSchedule{}, &ValidationError{fields: []FieldError{{"serve_at", "Serving time is required"}}}
+	}
+	if query.Cooks == 0 {
+		query.Cooks = 1
+	}
+	if query.Cooks < 1 || query.Cooks > maxCooks {
+		return PrepSchedule{}, &ValidationError{fields: []FieldError{{"cooks", fmt.Sprintf("Cooks must be between 1 and %d", maxCooks)}}}
+	}
+	if !query.From.IsZero() && !query.To.IsZero() && query.To.Before(query.From.Time) {
+		return PrepSchedule{}, &ValidationError{fields: []FieldError{{"to", "To must not be before from"}}}
+	}
+	mealPlan, err := s.Get(id)
+	if err != nil {
+		return PrepSchedule{}, err
+	}
+	var recipes []PrepRecipe
+	var details []RecipeGet
+	indexes := make(map[int64]int)
+	for _, entry := range mealPlan.Entries {
+		if entry.Cooked || entry.Leftover || !query.From.IsZero() && entry.Date.Before(query.From.Time) || !query.To.IsZero() && entry.Date.After(query.To.Time) {
+			continue
+		}
+		for _, recipe := range entry.Meal.Recipes {
+			index, ok := indexes[recipe.Id]
+			if !ok {
+				index = len(recipes)
+				indexes[recipe.Id] = index
+				recipes = append(recipes, PrepRecipe{Id: recipe.Id, Name: recipe.Name})
+				details = append(details, recipe)
+			}
+			recipes[index].Servings += entry.CookedShare(recipe) * recipeGetServings(recipe)
+		}
+	}
+	if recipes == nil {
+		recipes = []PrepRecipe{}
+	}
+	return buildPrepSchedule(recipes, details, query.ServeAt, query.Cooks), nil
+}
+
+// prepStep is a step of a recipe with what its text tells about it.
+type prepStep struct {
+	ref      PrepStepRef
+	minutes  int
+	handsOn  bool
+	oven     int
+	preheat  bool
+	prepVerb string
+	usesOven bool
+	// estimated is set if the text gives no duration.
+	estimated bool
+	// prepIngredients are the ids of the ingredients a prep step names.
+	prepIngredients []int64
+	prepNames       []string
+}
+
+// analyzeStep reads the duration, the oven temperature and the kind of the
+// step from its text. A range of durations counts as its upper bound.
+func analyzeStep(text string) prepStep {
+	step := prepStep{handsOn: true}
+	lower := strings.ToLower(text)
+	words := stepWord.FindAllString(lower, -1)
+	for _, word := range words {
+		if contains(passiveWords, word) || contains(passiveWords, strings.TrimSuffix(word, "s")) {
+			step.handsOn = false
+		}
+		if word == "preheat" || word == "pre-heat" {
+			step.preheat = true
+		}
+	}
+	var minutes float64
+	for _, match := range stepDuration.FindAllStringSubmatch(text, -1) {
+		amount, _ := strconv.ParseFloat(strings.Replace(match[1], ",", ".", 1), 64)
+		if match[2] != "" {
+			amount, _ = strconv.ParseFloat(strings.Replace(match[2], ",", ".", 1), 64)
+		}
+		if strings.HasPrefix(strings.ToLower(match[3]), "h") {
+			amount *= 60
+		}
+		minutes += amount
+	}
+	step.minutes = int(math.Ceil(minutes))
+	for _, word := range words {
+		if contains(ovenWords, word) {
+			step.usesOven = true
+		}
+	}
+	if step.usesOven {
+		for _, match := range stepTemperature.FindAllStringSubmatch(text, -1) {
+			value, _ := strconv.Atoi(match[1])
+			unit := strings.ToUpper(match[2])
+			if unit == "" && !strings.ContainsAny(match[0], "°º") && !strings.Contains(strings.ToLower(match[0]), "degree") {
+				continue
+			}
+			// Without a unit temperatures above any Celsius oven setting are
+			// Fahrenheit, converted to the nearest oven setting.
+			if unit == "F" || unit == "" && value > 260 {
+				value = int(math.Round(float64(value-32)*5/9/5)) * 5
+			}
+			step.oven = value
+			break
+		}
+	}
+	for index, word := range words {
+		if index > 1 {
+			break
+		}
+		if contains(prepAdverbs, word) {
+			continue
+		}
+		for _, verb := range prepVerbs {
+			if word == verb || word == verb+"s" || word == verb+"ped" || word == verb+"d" {
+				step.prepVerb = verb
+			}
+		}
+		break
+	}
+	return step
+}
+
+// recipePrepSteps analyzes the steps of the recipe and estimates the
+// durations the texts don't give by sharing the prep time among the hands-on
+// steps and the cook time among the others.
+func recipePrepSteps(recipe RecipeGet) []prepStep {
+	texts := SplitSteps(recipe.Steps)
+	if len(texts) == 0 {
+		var steps []prepStep
+		if recipe.PrepMinutes > 0 {
+			steps = append(steps, prepStep{ref: PrepStepRef{RecipeId: recipe.Id, RecipeName: recipe.Name, Text: "Prepare " + recipe.Name}, minutes: int(recipe.PrepMinutes), handsOn: true})
+		}
+		if recipe.CookMinutes > 0 {
+			steps = append(steps, prepStep{ref: PrepStepRef{RecipeId: recipe.Id, RecipeName: recipe.Name, Text: "Cook " + recipe.Name}, minutes: int(recipe.CookMinutes)})
+		}
+		return steps
+	}
+	steps := make([]prepStep, len(texts))
+	budget := map[bool]int{true: int(recipe.PrepMinutes), false: int(recipe.CookMinutes)}
+	unknown := map[bool]int{}
+	for index, text := range texts {
+		steps[index] = analyzeStep(text)
+		steps[index].ref = PrepStepRef{RecipeId: recipe.Id, RecipeName: recipe.Name, Step: index + 1, Text: text}
+		if steps[index].preheat {
+			continue
+		}
+		if steps[index].minutes > 0 {
+			budget[steps[index].handsOn] -= steps[index].minutes
+		} else {
+			unknown[steps[index].handsOn]++
+		}
+		if steps[index].prepVerb != "" {
+			for _, ing := range recipe.Ingredients {
+				if _, _, ok := findMention(text, ing.Name, nil); ok {
+					steps[index].prepIngredients = append(steps[index].prepIngredients, ing.Id)
+					steps[index].prepNames = append(steps[index].prepNames, strings.ToLower(ing.Name))
+				}
+			}
+		}
+	}
+	// Steps using the oven without a temperature use the last one given.
+	oven := 0
+	for index := range steps {
+		step := &steps[index]
+		if step.oven > 0 {
+			oven = step.oven
+		} else if step.usesOven {
+			step.oven = oven
+		}
+		if step.minutes > 0 {
+			continue
+		}
+		switch {
+		case step.preheat:
+			step.minutes = defaultPreheatMinutes
+		case budget[step.handsOn] > 0:
+			step.minutes = int(math.Ceil(float64(budget[step.handsOn]) / float64(unknown[step.handsOn])))
+		case step.handsOn:
+			step.minutes = defaultActiveMinutes
+		default:
+			step.minutes = defaultPassiveMinutes
+		}
+		step.estimated = true
+	}
+	return steps
+}
+
+// prepTask is a task of the schedule in minutes relative to the serving
+// time. Successors must not start before it ends.
+type prepTask struct {
+	PrepTask
+	minutes    int
+	estimated  bool
+	successors []*prepTask
+	// preheat is how long the oven heats up before an oven task, it's kept
+	// at the temperature of the task from then on.
+	preheat    int
+	start, end int
+	scheduled  bool
+}
+
+// buildPrepSchedule plans the steps of the recipes backwards from serveAt so
+// every dish is ready then. Steps of a recipe follow each other, except for
+// prep steps like chopping which only have to be done before the next step.
+// The same prep and preheating the oven to the same temperature are merged
+// across recipes. Hands-on tasks never keep more than cooks people busy and
+// the oven is only used at one temperature at a time.
+func buildPrepSchedule(recipes []PrepRecipe, details []RecipeGet, serveAt time.Time, cooks int) PrepSchedule {
+	if cooks < 1 {
+		cooks = 1
+	}
+	var tasks []*prepTask
+	groups := make(map[string]*prepTask)
+	// task returns a new task for the step, or with a key the task of the
+	// steps with the same key the step is merged into. Preheating takes as
+	// long as the longest of its steps, prep as long as all of them.
+	task := func(key string, step prepStep, minutes int, description string) *prepTask {
+		if group, ok := groups[key]; ok && key != "" {
+			group.Steps = append(group.Steps, step.ref)
+			group.estimated = group.estimated || step.estimated
+			if step.preheat {
+				group.minutes = maxInt(group.minutes, minutes)
+			} else {
+				group.minutes += minutes
+			}
+			return group
+		}
+		created := &prepTask{
+			PrepTask: PrepTask{
+				Description:     description,
+				HandsOn:         step.handsOn,
+				OvenTemperature: step.oven,
+				Steps:           []PrepStepRef{step.ref},
+			},
+			minutes:   minutes,
+			estimated: step.estimated,
+		}
+		if key != "" {
+			groups[key] = created
+		}
+		tasks = append(tasks, created)
+		return created
+	}
+	for _, recipe := range details {
+		// Going backwards next is the step following the current one and
+		// ovenNext the following steps using the oven per temperature.
+		var next *prepTask
+		ovenNext := make(map[int]*prepTask)
+		steps := recipePrepSteps(recipe)
+		for index := len(steps) - 1; index >= 0; index-- {
+			step := steps[index]
+			switch {
+			case step.preheat && step.oven > 0:
+				// Preheating only has to be done by the time the oven is
+				// used.
+				preheat := task(fmt.Sprintf("preheat %d", step.oven), step, step.minutes, fmt.Sprintf("Preheat the oven to %d°C", step.oven))
+				if successor := ovenNext[step.oven]; successor != nil {
+					preheat.addSuccessor(successor)
+					successor.preheat = maxInt(successor.preheat, step.minutes)
+				} else {
+					preheat.addSuccessor(next)
+				}
+			case step.prepVerb != "" && len(step.prepIngredients) > 0:
+				// Each ingredient is prepped on its own to do it for all
+				// recipes at once. Prep only has to be done by the next step.
+				minutes := int(math.Ceil(float64(step.minutes) / float64(len(step.prepIngredients))))
+				for i, id := range step.prepIngredients {
+					prep := task(fmt.Sprintf("%s %d", step.prepVerb, id), step, minutes, strings.Title(step.prepVerb)+" "+step.prepNames[i])
+					prep.addSuccessor(next)
+				}
+			default:
+				current := task("", step, step.minutes, step.ref.Text)
+				current.addSuccessor(next)
+				next = current
+				if step.oven > 0 {
+					ovenNext[step.oven] = current
+				}
+			}
+		}
+	}
+	for _, task := range tasks {
+		if recipes := countRecipes(task.Steps); recipes > 1 && task.OvenTemperature == 0 {
+			task.Description += fmt.Sprintf(" for %d recipes", recipes)
+		}
+	}
+	scheduleBackwards(tasks, cooks)
+
+	schedule := PrepSchedule{ServeAt: serveAt, StartAt: serveAt, Cooks: cooks, Recipes: recipes, Tasks: []PrepTask{}, OvenSessions: []OvenSession{}}
+	at := func(minutes int) time.Time {
+		return serveAt.Add(time.Duration(minutes) * time.Minute)
+	}
+	sort.SliceStable(tasks, func(i, j int) bool {
+		if tasks[i].start != tasks[j].start {
+			return tasks[i].start < tasks[j].start
+		}
+		return tasks[i].end < tasks[j].end
+	})
+	for _, task := range tasks {
+		task.Start, task.End = at(task.start), at(task.end)
+		task.Minutes = task.minutes
+		task.Estimated = task.estimated
+		schedule.Tasks = append(schedule.Tasks, task.PrepTask)
+		if task.Start.Before(schedule.StartAt) {
+			schedule.StartAt = task.Start
+		}
+	}
+	schedule.OvenSessions = ovenSessions(tasks, at)
+	return schedule
+}
+
+func (task *prepTask) addSuccessor(successor *prepTask) {
+	if successor == nil || successor == task {
+		return
+	}
+	for _, existing := range task.successors {
+		if existing == successor {
+			return
+		}
+	}
+	task.successors = append(task.successors, successor)
+}
+
+func countRecipes(steps []PrepStepRef) int {
+	seen := make(map[int64]bool)
+	for _, step := range steps {
+		seen[step.RecipeId] = true
+	}
+	return len(seen)
+}
+
+// scheduleBackwards places each task as late as possible, going backwards
+// from the tasks without successors which end at the serving time. Among the
+// tasks whose successors are all placed the one due latest goes first.
+func scheduleBackwards(tasks []*prepTask, cooks int) {
+	var placed []*prepTask
+	for range tasks {
+		var best *prepTask
+		bestDue := 0
+		for _, task := range tasks {
+			if task.scheduled {
+				continue
+			}
+			due, ready := 0, true
+			for _, successor := range task.successors {
+				if !successor.scheduled {
+					ready = false
+					break
+				}
+				if successor.start < due {
+					due = successor.start
+				}
+			}
+			if !ready {
+				continue
+			}
+			if best == nil || due > bestDue || due == bestDue && task.minutes > best.minutes {
+				best, bestDue = task, due
+			}
+		}
+		best.end = latestEnd(best, bestDue, placed, cooks)
+		best.start = best.end - best.minutes
+		best.scheduled = true
+		placed = append(placed, best)
+	}
+}
+
+// latestEnd returns the latest end no later than due at which the task fits
+// next to the placed ones. A task can only end later than due at the start of
+// another task it conflicts with, so those are the candidates.
+func latestEnd(task *prepTask, due int, placed []*prepTask, cooks int) int {
+	candidates := []int{due}
+	for _, other := range placed {
+		if other.start < due {
+			candidates = append(candidates, other.start)
+		}
+		if other.preheat > 0 && other.ovenStart() < due {
+			candidates = append(candidates, other.ovenStart())
+		}
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(candidates)))
+	for _, end := range candidates {
+		if fits(task, end-task.minutes, end, placed, cooks) {
+			return end
+		}
+	}
+	// Every task fits before all placed ones.
+	return candidates[len(candidates)-1]
+}
+
+func fits(task *prepTask, start, end int, placed []*prepTask, cooks int) bool {
+	if task.OvenTemperature > 0 {
+		ovenStart := start - task.preheat
+		for _, other := range placed {
+			if other.OvenTemperature > 0 && other.OvenTemperature != task.OvenTemperature && other.ovenStart() < end && ovenStart < other.end {
+				return false
+			}
+		}
+	}
+	if !task.HandsOn {
+		return true
+	}
+	// The busiest moment of the span is at its start or where another
+	// hands-on task starts within it.
+	points := []int{start}
+	for _, other := range placed {
+		if other.HandsOn && other.start > start && other.start < end {
+			points = append(points, other.start)
+		}
+	}
+	for _, point := range points {
+		busy := 0
+		for _, other := range placed {
+			if other.HandsOn && other.start <= point && point < other.end {
+				busy++
+			}
+		}
+		if busy >= cooks {
+			return false
+		}
+	}
+	return true
+}
+
+// ovenStart is when the oven starts being used for the task, preheat included.
+func (task *prepTask) ovenStart() int {
+	return task.start - task.preheat
+}
+
+// ovenSessions merges the oven tasks at the same temperature that overlap or
+// follow each other.
+func ovenSessions(tasks []*prepTask, at func(int) time.Time) []OvenSession {
+	var oven []*prepTask
+	for _, task := range tasks {
+		if task.OvenTemperature > 0 {
+			oven = append(oven, task)
+		}
+	}
+	sort.SliceStable(oven, func(i, j int) bool { return oven[i].ovenStart() < oven[j].ovenStart() })
+	sessions := []OvenSession{}
+	var start, end, temperature int
+	for index, task := range oven {
+		if index > 0 && task.OvenTemperature == temperature && task.ovenStart() <= end {
+			end = maxInt(end, task.end)
+			continue
+		}
+		if index > 0 {
+			sessions = append(sessions, OvenSession{Temperature: temperature, Start: at(start), End: at(end)})
+		}
+		start, end, temperature = task.ovenStart(), task.end, task.OvenTemperature
+	}
+	if len(oven) > 0 {
+		sessions = append(sessions, OvenSession{Temperature: temperature, Start: at(start), End: at(end)})
+	}
+	return sessions
+}
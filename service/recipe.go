@@ -1,25 +1,129 @@
 package service
 
+import (
+	"strings"
+	"time"
+)
+
 type IngredientShort struct {
 	Id     int64   `json:"id"`
-	Amount float32 `json:"amount"`
-	Unit   string  `json:"unit"`
+	Amount float32 `json:"amount" validate:"gt=0"`
+	Unit   string  `json:"unit" validate:"required,unit"`
+}
+
+// Clone names the copy of a recipe or meal, it's named after the original if
+// Name is empty.
+type Clone struct {
+	Name string `json:"name"`
+}
+
+// cloneName returns the name of a copy, the original's marked as a copy if
+// none is given.
+func cloneName(original, name string) string {
+	if name == "" {
+		return original + " (copy)"
+	}
+	return name
 }
 
 type RecipeCreate struct {
 	Id          int64             `json:"id"`
-	Name        string            `json:"name"`
+	Name        string            `json:"name" validate:"required"`
 	Steps       string            `json:"steps"`
+	PrepMinutes int32             `json:"prep_minutes" validate:"gte=0"`
+	CookMinutes int32             `json:"cook_minutes" validate:"gte=0"`
+	Servings    int32             `json:"servings" validate:"gte=0"`
+	Tags        []string          `json:"tags"`
 	Ingredients []IngredientShort `json:"ingredients"`
 }
 
 type RecipeGet struct {
-	Id          int64        `json:"id"`
-	Name        string       `json:"name"`
-	Calories    float32      `json:"calories"`
-	Protein     float32      `json:"protein"`
-	Carbs       float32      `json:"carbs"`
-	Fat         float32      `json:"fat"`
-	Steps       string       `json:"steps"`
-	Ingredients []Ingredient `json:"ingredients"`
+	Id             int64   `json:"id"`
+	Name           string  `json:"name"`
+	Calories       float32 `json:"calories"`
+	Protein        float32 `json:"protein"`
+	Carbs          float32 `json:"carbs"`
+	Fat            float32 `json:"fat"`
+	Steps          string  `json:"steps"`
+	PrepMinutes    int32   `json:"prep_minutes"`
+	CookMinutes    int32   `json:"cook_minutes"`
+	Servings       int32   `json:"servings"`
+	Cost           Cost    `json:"cost"`
+	CostPerServing float32 `json:"cost_per_serving"`
+	DietLabels
+	Tags []string `json:"tags"`
+	// Rating is the average rating of the reviews, nil if there are none.
+	Rating      *float32 `json:"rating"`
+	RatingCount int      `json:"rating_count"`
+	// LastCooked is the latest date in the cook log, nil if it's empty.
+	LastCooked  *Date         `json:"last_cooked"`
+	TimesCooked int           `json:"times_cooked"`
+	Images      []RecipeImage `json:"images"`
+	Ingredients []Ingredient  `json:"ingredients"`
+}
+
+// SplitSteps returns the steps of a recipe, one per non-blank line.
+func SplitSteps(steps string) []string {
+	var split []string
+	for _, line := range strings.Split(steps, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			split = append(split, line)
+		}
+	}
+	return split
+}
+
+// RecipeFilter selects the recipes passing Diet and having all of Tags. If
+// NotCookedWithin is positive, recipes cooked within that many days before
+// today are left out.
+type RecipeFilter struct {
+	Diet            DietFilter
+	Tags            []string
+	NotCookedWithin int
+}
+
+func (f RecipeFilter) IsEmpty() bool {
+	return f.Diet.IsEmpty() && len(f.Tags) == 0 && f.NotCookedWithin <= 0
+}
+
+func (f RecipeFilter) Matches(recipe RecipeGet) bool {
+	for _, tag := range normalizeTags(f.Tags) {
+		if !contains(recipe.Tags, tag) {
+			return false
+		}
+	}
+	if f.NotCookedWithin > 0 && recipe.LastCooked != nil {
+		if NewDate(time.Now()).DaysSince(*recipe.LastCooked) < f.NotCookedWithin {
+			return false
+		}
+	}
+	return f.Diet.Matches(recipe.Allergens, recipe.Diets)
+}
+
+// RecipeFacets counts the recipes passing a filter, in total and per tag.
+type RecipeFacets struct {
+	Total int        `json:"total"`
+	Tags  []TagCount `json:"tags"`
+}
+
+type TagCount struct {
+	Tag   string `json:"tag"`
+	Count int    `json:"count"`
+}
+
+// SuggestionQuery selects the ingredients recipe suggestions should use up:
+// the given ingredients and those of pantry items expiring within the given
+// number of days, if positive.
+type SuggestionQuery struct {
+	IngredientIds  []int64
+	ExpiringWithin int
+	Limit          int
+}
+
+// RecipeSuggestion is a recipe using some of the ingredients to use up. Missing
+// lists the ingredients it needs that are neither given nor in the pantry.
+type RecipeSuggestion struct {
+	Recipe  RecipeGet          `json:"recipe"`
+	Uses    []IngredientAmount `json:"uses"`
+	Missing []IngredientAmount `json:"missing"`
 }
@@ -0,0 +1,32 @@
+package service
+
+import "time"
+
+// RecipeImage is an uploaded photo of a recipe's dish, or of one of its steps
+// if Step is set. Url serves the image as uploaded, Thumbnails the copies
+// scaled down to ThumbnailSizes.
+type RecipeImage struct {
+	Id          int64       `json:"id"`
+	RecipeId    int64       `json:"recipe_id"`
+	Step        int32       `json:"step"`
+	ContentType string      `json:"content_type"`
+	Width       int32       `json:"width"`
+	Height      int32       `json:"height"`
+	Size        int64       `json:"size"`
+	Created     time.Time   `json:"created"`
+	Url         string      `json:"url"`
+	Thumbnails  []Thumbnail `json:"thumbnails"`
+}
+
+type Thumbnail struct {
+	Size   string `json:"size"`
+	Width  int32  `json:"width"`
+	Height int32  `json:"height"`
+	Url    string `json:"url"`
+}
+
+// ThumbnailSize names a thumbnail scaled to fit a square of MaxSide pixels.
+type ThumbnailSize struct {
+	Name    string
+	MaxSide int
+}
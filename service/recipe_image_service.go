@@ -0,0 +1,239 @@
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/cookbook/repository"
+	"github.com/cookbook/storage"
+)
+
+const (
+	MaxImageSize = 10 << 20
+	// maxImagePixels guards against small files decoding into huge images.
+	maxImagePixels = 40000000
+)
+
+var imageContentTypes = []string{"image/jpeg", "image/png", "image/gif"}
+
+type RecipeImageService interface {
+	Get(int64) (RecipeImage, error)
+	GetAll(recipeId int64) ([]RecipeImage, error)
+	Upload(recipeId int64, step int32, r io.Reader) (RecipeImage, error)
+	Open(id int64, size string) (io.ReadCloser, string, error)
+	Delete(int64) error
+}
+
+type RecipeImageServiceImpl struct {
+	repo       *repository.RecipeImageRepository
+	rcpService RecipeService
+	blobs      storage.BlobStore
+}
+
+func NewRecipeImageService(r *repository.RecipeImageRepository, rs RecipeService, bs storage.BlobStore) RecipeImageService {
+	return RecipeImageServiceImpl{
+		repo:       r,
+		rcpService: rs,
+		blobs:      bs,
+	}
+}
+
+func (s RecipeImageServiceImpl) Get(id int64) (RecipeImage, error) {
+	rImage, err := s.repo.Get(id)
+	if err != nil {
+		return RecipeImage{}, handleError(err)
+	}
+	return convertRepoRecipeImage(rImage), nil
+}
+
+// GetAll returns the images of the recipe, the dish first, then by step.
+func (s RecipeImageServiceImpl) GetAll(recipeId int64) ([]RecipeImage, error) {
+	rImages, err := s.repo.GetAll(recipeId)
+	if err != nil {
+		return []RecipeImage{}, handleError(err)
+	}
+	return convertRepoRecipeImages(rImages), nil
+}
+
+// Upload stores an image of the recipe, of the dish if step is 0 and of that
+// step otherwise, along with its thumbnails. The content type is sniffed from
+// the data, only JPEG, PNG and GIF images up to MaxImageSize are accepted.
+func (s RecipeImageServiceImpl) Upload(recipeId int64, step int32, r io.Reader) (RecipeImage, error) {
+	recipe, err := s.rcpService.Get(recipeId)
+	if err != nil {
+		return RecipeImage{}, err
+	}
+	if steps := SplitSteps(recipe.Steps); step < 0 || int(step) > len(steps) {
+		return RecipeImage{}, &ValidationError{fields: []FieldError{{"step", fmt.Sprintf("Recipe %d has no step %d", recipeId, step)}}}
+	}
+	data, err := ioutil.ReadAll(io.LimitReader(r, MaxImageSize+1))
+	if err != nil {
+		return RecipeImage{}, &ValidationError{fields: []FieldError{{"image", "Image could not be read: " + err.Error()}}}
+	}
+	img, contentType, err := decodeImage(data)
+	if err != nil {
+		return RecipeImage{}, err
+	}
+	bounds := img.Bounds()
+	rImage := repository.RecipeImage{
+		RecipeId:    recipeId,
+		Step:        step,
+		ContentType: contentType,
+		Width:       int32(bounds.Dx()),
+		Height:      int32(bounds.Dy()),
+		Size:        int64(len(data)),
+		Created:     time.Now(),
+	}
+	blobs := map[string][]byte{"": data}
+	for _, size := range ThumbnailSizes {
+		blobs[size.Name], err = encodeThumbnail(img, size.MaxSide, contentType)
+		if err != nil {
+			return RecipeImage{}, &InternalError{"Failed to create thumbnail: " + err.Error()}
+		}
+	}
+	rImage.Id, err = s.repo.Create(rImage)
+	if err != nil {
+		return RecipeImage{}, handleError(err)
+	}
+	for size, blob := range blobs {
+		err = s.blobs.Put(imageKey(rImage.Id, size), bytes.NewReader(blob))
+		if err != nil {
+			log.Println(err.Error())
+			deleteImageBlobs(s.blobs, rImage.Id)
+			s.repo.Delete(rImage.Id)
+			return RecipeImage{}, &InternalError{"Failed to store image: " + err.Error()}
+		}
+	}
+	return convertRepoRecipeImage(rImage), nil
+}
+
+// decodeImage checks the sniffed content type and the dimensions of the image
+// before decoding it.
+func decodeImage(data []byte) (image.Image, string, error) {
+	if len(data) > MaxImageSize {
+		return nil, "", &ValidationError{fields: []FieldError{{"image", fmt.Sprintf("Image must not be larger than %d MB", MaxImageSize>>20)}}}
+	}
+	contentType := http.DetectContentType(data)
+	if !contains(imageContentTypes, contentType) {
+		return nil, "", &ValidationError{fields: []FieldError{{"image", "Image must be a JPEG, PNG or GIF image, got " + contentType}}}
+	}
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", &ValidationError{fields: []FieldError{{"image", "Image could not be decoded: " + err.Error()}}}
+	}
+	if config.Width*config.Height > maxImagePixels {
+		return nil, "", &ValidationError{fields: []FieldError{{"image", fmt.Sprintf("Image must not have more than %d pixels", maxImagePixels)}}}
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", &ValidationError{fields: []FieldError{{"image", "Image could not be decoded: " + err.Error()}}}
+	}
+	return img, contentType, nil
+}
+
+// Open returns the data of the image as uploaded if size is empty, of its
+// thumbnail of that size otherwise, and its content type.
+func (s RecipeImageServiceImpl) Open(id int64, size string) (io.ReadCloser, string, error) {
+	recipeImage, err := s.Get(id)
+	if err != nil {
+		return nil, "", err
+	}
+	contentType := recipeImage.ContentType
+	if size != "" {
+		if !isThumbnailSize(size) {
+			return nil, "", &ValidationError{fields: []FieldError{{"size", "Size must be one of " + strings.Join(thumbnailSizeNames(), ", ")}}}
+		}
+		contentType = thumbnailContentType(contentType)
+	}
+	blob, err := s.blobs.Open(imageKey(id, size))
+	if err == storage.ErrNotFound {
+		return nil, "", &NotFound{fmt.Sprintf("Image %d has no data stored", id)}
+	}
+	if err != nil {
+		log.Println(err.Error())
+		return nil, "", &InternalError{"Failed to read image: " + err.Error()}
+	}
+	return blob, contentType, nil
+}
+
+func (s RecipeImageServiceImpl) Delete(id int64) error {
+	err := s.repo.Delete(id)
+	if err != nil {
+		return handleError(err)
+	}
+	deleteImageBlobs(s.blobs, id)
+	return nil
+}
+
+// deleteImageBlobs removes the image and its thumbnails from the blob store.
+// Blobs that fail to be removed are only logged, the image is gone either way.
+func deleteImageBlobs(blobs storage.BlobStore, id int64) {
+	sizes := append([]string{""}, thumbnailSizeNames()...)
+	for _, size := range sizes {
+		if err := blobs.Delete(imageKey(id, size)); err != nil {
+			log.Println(err.Error())
+		}
+	}
+}
+
+// imageKey returns the blob key of the image, of its thumbnail if size is
+// set.
+func imageKey(id int64, size string) string {
+	if size == "" {
+		size = "original"
+	}
+	return fmt.Sprintf("images/%d/%s", id, size)
+}
+
+func isThumbnailSize(name string) bool {
+	return contains(thumbnailSizeNames(), name)
+}
+
+func thumbnailSizeNames() []string {
+	names := make([]string, len(ThumbnailSizes))
+	for index, size := range ThumbnailSizes {
+		names[index] = size.Name
+	}
+	return names
+}
+
+func convertRepoRecipeImages(rImages []repository.RecipeImage) []RecipeImage {
+	images := make([]RecipeImage, len(rImages))
+	for index, rImage := range rImages {
+		images[index] = convertRepoRecipeImage(rImage)
+	}
+	return images
+}
+
+// convertRepoRecipeImage fills in the URLs the image and its thumbnails are
+// served at.
+func convertRepoRecipeImage(rImage repository.RecipeImage) RecipeImage {
+	recipeImage := RecipeImage{
+		Id:          rImage.Id,
+		RecipeId:    rImage.RecipeId,
+		Step:        rImage.Step,
+		ContentType: rImage.ContentType,
+		Width:       rImage.Width,
+		Height:      rImage.Height,
+		Size:        rImage.Size,
+		Created:     rImage.Created,
+		Url:         fmt.Sprintf("/images/%d", rImage.Id),
+	}
+	for _, size := range ThumbnailSizes {
+		width, height := thumbnailSize(int(rImage.Width), int(rImage.Height), size.MaxSide)
+		recipeImage.Thumbnails = append(recipeImage.Thumbnails, Thumbnail{
+			Size:   size.Name,
+			Width:  int32(width),
+			Height: int32(height),
+			Url:    fmt.Sprintf("/images/%d?size=%s", rImage.Id, size.Name),
+		})
+	}
+	return recipeImage
+}
@@ -3,28 +3,45 @@ package service
 import (
 	"fmt"
 	"log"
+	"strings"
 
 	"github.com/cookbook/repository"
+	"github.com/cookbook/storage"
 )
 
 type RecipeService interface {
 	Get(int64) (RecipeGet, error)
 	GetList([]int64) ([]RecipeGet, error)
 	GetAll() ([]RecipeGet, error)
-	Create(RecipeCreate) error
+	GetIdsByIngredients([]int64) (map[int64][]int64, error)
+	Suggest(SuggestionQuery) ([]RecipeSuggestion, error)
+	Substitute(id int64, substitutionIds []int64) (SubstitutedRecipe, error)
+	Facets(RecipeFilter) (RecipeFacets, error)
+	ParseText(format, text string) (RecipeCreate, error)
+	Create(RecipeCreate) (int64, error)
 	Update(RecipeCreate) error
-	Delete(int64) error
+	Clone(id int64, name string) (int64, error)
+	GetExistingIds([]int64) (map[int64]bool, error)
+	Delete(int64, DeleteOptions) error
 }
 
 type RecipeServiceImpl struct {
-	repo       *repository.RecipeRepository
-	ingService IngredientService
+	repo          *repository.RecipeRepository
+	ingService    IngredientService
+	pantryService PantryService
+	priceService  IngredientPriceService
+	subService    SubstitutionService
+	blobs         storage.BlobStore
 }
 
-func NewRecipeService(r *repository.RecipeRepository, is IngredientService) RecipeService {
+func NewRecipeService(r *repository.RecipeRepository, is IngredientService, ps PantryService, prs IngredientPriceService, ss SubstitutionService, bs storage.BlobStore) RecipeService {
 	return RecipeServiceImpl{
-		repo:       r,
-		ingService: is,
+		repo:          r,
+		ingService:    is,
+		pantryService: ps,
+		priceService:  prs,
+		subService:    ss,
+		blobs:         bs,
 	}
 }
 
@@ -60,14 +77,56 @@ func (s RecipeServiceImpl) GetAll() ([]RecipeGet, error) {
 	return s.convertRepoModel(rRecipes...)
 }
 
-func (s RecipeServiceImpl) Create(recipe RecipeCreate) (err error) {
+// Substitute returns the recipe with the substitutions applied. The recipe
+// itself is left unchanged.
+func (s RecipeServiceImpl) Substitute(id int64, substitutionIds []int64) (SubstitutedRecipe, error) {
+	rRecipe, err := s.repo.Get(id)
+	if err != nil {
+		return SubstitutedRecipe{}, handleError(err)
+	}
+	subs, err := s.subService.GetList(substitutionIds)
+	if err != nil {
+		return SubstitutedRecipe{}, err
+	}
+	byId := make(map[int64]Substitution)
+	for _, sub := range subs {
+		byId[sub.Id] = sub
+	}
+	applied := make([]Substitution, 0, len(substitutionIds))
+	for index, subId := range substitutionIds {
+		sub, ok := byId[subId]
+		if !ok {
+			return SubstitutedRecipe{}, &ValidationError{fields: []FieldError{{fmt.Sprintf("substitutions[%d]", index), fmt.Sprintf("Substitution with id %d doesn't exist", subId)}}}
+		}
+		applied = append(applied, sub)
+	}
+	err = applySubstitutions(&rRecipe, applied)
+	if err != nil {
+		return SubstitutedRecipe{}, err
+	}
+	recipes, err := s.convertRepoModel(rRecipe)
+	if err != nil {
+		return SubstitutedRecipe{}, err
+	}
+	return SubstitutedRecipe{Recipe: recipes[0], Applied: applied}, nil
+}
+
+func (s RecipeServiceImpl) Create(recipe RecipeCreate) (id int64, err error) {
 	err = validateRecipe(recipe)
 	if err != nil {
-		return err
+		return 0, err
+	}
+	err = s.validateReferences(recipe)
+	if err != nil {
+		return 0, err
 	}
 	rRecipe := repository.Recipe{
-		Name:  recipe.Name,
-		Steps: recipe.Steps,
+		Name:        recipe.Name,
+		Steps:       recipe.Steps,
+		PrepMinutes: recipe.PrepMinutes,
+		CookMinutes: recipe.CookMinutes,
+		Servings:    recipeServings(recipe),
+		Tags:        normalizeTags(recipe.Tags),
 	}
 	for _, ing := range recipe.Ingredients {
 		rRecipe.Ingredients = append(rRecipe.Ingredients, repository.IngredientShort{
@@ -76,7 +135,7 @@ func (s RecipeServiceImpl) Create(recipe RecipeCreate) (err error) {
 			Unit:   ing.Unit,
 		})
 	}
-	err = s.repo.Create(rRecipe)
+	id, err = s.repo.Create(rRecipe)
 	if err != nil {
 		err = handleError(err)
 		log.Println(err.Error())
@@ -89,10 +148,18 @@ func (s RecipeServiceImpl) Update(recipe RecipeCreate) (err error) {
 	if err != nil {
 		return err
 	}
+	err = s.validateReferences(recipe)
+	if err != nil {
+		return err
+	}
 	rRecipe := repository.Recipe{
-		Id:    recipe.Id,
-		Name:  recipe.Name,
-		Steps: recipe.Steps,
+		Id:          recipe.Id,
+		Name:        recipe.Name,
+		Steps:       recipe.Steps,
+		PrepMinutes: recipe.PrepMinutes,
+		CookMinutes: recipe.CookMinutes,
+		Servings:    recipeServings(recipe),
+		Tags:        normalizeTags(recipe.Tags),
 	}
 	for _, ing := range recipe.Ingredients {
 		rRecipe.Ingredients = append(rRecipe.Ingredients, repository.IngredientShort{
@@ -108,12 +175,83 @@ func (s RecipeServiceImpl) Update(recipe RecipeCreate) (err error) {
 	return
 }
 
-func (s RecipeServiceImpl) Delete(id int64) (err error) {
-	err = s.repo.Delete(id)
+// Clone copies the recipe with its ingredients and tags.
+func (s RecipeServiceImpl) Clone(id int64, name string) (int64, error) {
+	if name == "" {
+		recipe, err := s.repo.Get(id)
+		if err != nil {
+			return 0, handleError(err)
+		}
+		name = cloneName(recipe.Name, name)
+	}
+	cloneId, err := s.repo.Clone(id, name)
 	if err != nil {
-		err = handleError(err)
+		return 0, handleError(err)
 	}
-	return
+	return cloneId, nil
+}
+
+// ParseText reads a recipe in one of the RecipeFormats and matches its
+// ingredients to the catalogue by name.
+func (s RecipeServiceImpl) ParseText(format, text string) (RecipeCreate, error) {
+	var recipe textRecipe
+	var fields []FieldError
+	switch format {
+	case RecipeFormatMarkdown:
+		recipe, fields = parseMarkdownRecipe(text)
+	case RecipeFormatCooklang:
+		recipe, fields = parseCooklangRecipe(text)
+	default:
+		return RecipeCreate{}, &ValidationError{fields: []FieldError{{"format", "Format must be one of " + strings.Join(RecipeFormats, ", ")}}}
+	}
+	catalogue, err := s.ingService.GetAll()
+	if err != nil {
+		return RecipeCreate{}, err
+	}
+	created, matchFields := matchIngredients(recipe, catalogue)
+	fields = append(fields, matchFields...)
+	if len(fields) > 0 {
+		return RecipeCreate{}, &ValidationError{fields: fields}
+	}
+	return created, nil
+}
+
+// GetIdsByIngredients returns the ids of the recipes using each of the given ingredients.
+func (s RecipeServiceImpl) GetIdsByIngredients(ingredientIds []int64) (map[int64][]int64, error) {
+	ids, err := s.repo.GetIdsByIngredients(ingredientIds)
+	if err != nil {
+		return nil, handleError(err)
+	}
+	return ids, nil
+}
+
+func (s RecipeServiceImpl) GetExistingIds(ids []int64) (map[int64]bool, error) {
+	existing, err := s.repo.GetExistingIds(ids)
+	if err != nil {
+		return nil, handleError(err)
+	}
+	return existing, nil
+}
+
+func (s RecipeServiceImpl) Delete(id int64, opts DeleteOptions) (err error) {
+	err = validateDeleteOptions(id, opts, s.GetExistingIds)
+	if err != nil {
+		return err
+	}
+	// The images are owned by the recipe and deleted along with it, leaving
+	// their blobs behind.
+	rRecipe, err := s.repo.Get(id)
+	if err != nil {
+		return handleError(err)
+	}
+	err = s.repo.Delete(id, repository.DeleteOptions{Cascade: opts.Cascade, ReplaceWith: opts.ReplaceWith})
+	if err != nil {
+		return handleError(err)
+	}
+	for _, image := range rRecipe.Images {
+		deleteImageBlobs(s.blobs, image.Id)
+	}
+	return nil
 }
 
 func (s RecipeServiceImpl) convertRepoModel(repoRecipes ...repository.Recipe) ([]RecipeGet, error) {
@@ -122,13 +260,31 @@ func (s RecipeServiceImpl) convertRepoModel(repoRecipes ...repository.Recipe) ([
 	if err != nil {
 		return []RecipeGet{}, handleError(err)
 	}
+	var ingredientIds []int64
+	for id := range *usedIngredients {
+		ingredientIds = append(ingredientIds, id)
+	}
+	prices, err := s.priceService.GetLatest(ingredientIds)
+	if err != nil {
+		return []RecipeGet{}, err
+	}
 	for index, rRecipe := range repoRecipes {
 		recipes[index] = RecipeGet{
-			Id:    rRecipe.Id,
-			Name:  rRecipe.Name,
-			Steps: rRecipe.Steps,
+			Id:          rRecipe.Id,
+			Name:        rRecipe.Name,
+			Steps:       rRecipe.Steps,
+			PrepMinutes: rRecipe.PrepMinutes,
+			CookMinutes: rRecipe.CookMinutes,
+			Servings:    rRecipe.Servings,
+			Cost:        Cost{Unpriced: []int64{}},
+			Tags:        append([]string{}, rRecipe.Tags...),
+			Rating:      rRecipe.Rating,
+			RatingCount: int(rRecipe.RatingCount),
+			TimesCooked: int(rRecipe.TimesCooked),
+			Images:      convertRepoRecipeImages(rRecipe.Images),
 		}
 		for _, ing := range rRecipe.Ingredients {
+			recipes[index].Cost.add(ing.Id, Quantity{Amount: ing.Amount, Unit: ing.Unit}, prices)
 			rIng := (*usedIngredients)[ing.Id]
 			rIng, err = scaleIngredient(rIng, Quantity{Amount: ing.Amount, Unit: ing.Unit})
 			if err != nil {
@@ -140,10 +296,34 @@ func (s RecipeServiceImpl) convertRepoModel(repoRecipes ...repository.Recipe) ([
 			recipes[index].Carbs += rIng.Carbs
 			recipes[index].Fat += rIng.Fat
 		}
+		if rRecipe.LastCooked != nil {
+			lastCooked := NewDate(*rRecipe.LastCooked)
+			recipes[index].LastCooked = &lastCooked
+		}
+		recipes[index].DietLabels = combineDietLabels(ingredientDietLabels(recipes[index].Ingredients)...)
+		if rRecipe.Servings > 0 {
+			recipes[index].CostPerServing = recipes[index].Cost.Total / float32(rRecipe.Servings)
+		}
 	}
 	return recipes, nil
 }
 
+func ingredientDietLabels(ingredients []Ingredient) []DietLabels {
+	labels := make([]DietLabels, len(ingredients))
+	for index, ing := range ingredients {
+		labels[index] = ing.DietLabels
+	}
+	return labels
+}
+
+// recipeServings returns the servings the recipe makes, one if not given.
+func recipeServings(recipe RecipeCreate) int32 {
+	if recipe.Servings == 0 {
+		return 1
+	}
+	return recipe.Servings
+}
+
 func (s RecipeServiceImpl) getAllIngredients(recipes ...repository.Recipe) (*map[int64]Ingredient, error) {
 	ingredients := make(map[int64]Ingredient)
 	var ids []int64
@@ -165,10 +345,18 @@ func (s RecipeServiceImpl) getAllIngredients(recipes ...repository.Recipe) (*map
 	return &ingredients, nil
 }
 
+// quantityScale returns how many times final is bigger than base.
+func quantityScale(base, final Quantity) (float32, error) {
+	unitScale, err := ConvertUnit(base.Unit, final.Unit)
+	if err != nil {
+		return 0, err
+	}
+	return final.Amount / (base.Amount * unitScale), nil
+}
+
 func scaleIngredient(i Ingredient, finalQuantity Quantity) (Ingredient, error) {
-	unitScale, err := ConvertUnit(i.Unit, finalQuantity.Unit)
+	nutritionScale, err := quantityScale(i.Quantity, finalQuantity)
 	if err == nil {
-		nutritionScale := finalQuantity.Amount / (i.Amount * unitScale)
 		i.Quantity = finalQuantity
 		i.Calories *= nutritionScale
 		i.Protein *= nutritionScale
@@ -178,21 +366,63 @@ func scaleIngredient(i Ingredient, finalQuantity Quantity) (Ingredient, error) {
 	return i, err
 }
 
+// ScaleRecipe returns the recipe with its ingredients, nutrition and cost
+// scaled to make the given number of servings.
+func ScaleRecipe(recipe RecipeGet, servings int32) RecipeGet {
+	base := recipe.Servings
+	if base == 0 {
+		base = 1
+	}
+	scale := float32(servings) / float32(base)
+	ingredients := make([]Ingredient, len(recipe.Ingredients))
+	for index, ing := range recipe.Ingredients {
+		ingredients[index], _ = scaleIngredient(ing, Quantity{Amount: ing.Quantity.Amount * scale, Unit: ing.Quantity.Unit})
+	}
+	recipe.Ingredients = ingredients
+	recipe.Servings = servings
+	recipe.Calories *= scale
+	recipe.Protein *= scale
+	recipe.Carbs *= scale
+	recipe.Fat *= scale
+	recipe.Cost.Total *= scale
+	return recipe
+}
+
+func (s RecipeServiceImpl) validateReferences(recipe RecipeCreate) error {
+	ids := make([]int64, len(recipe.Ingredients))
+	for index, ing := range recipe.Ingredients {
+		ids[index] = ing.Id
+	}
+	return referenceError(ids, "Ingredient", func(index int) string {
+		return fmt.Sprintf("ingredients[%d].id", index)
+	}, s.ingService.GetExistingIds)
+}
+
 func validateRecipe(recipe RecipeCreate) error {
-	var messages []string
+	var fields []FieldError
 	if recipe.Name == "" {
-		messages = append(messages, "Recipe name must be provided")
+		fields = append(fields, FieldError{"name", "Recipe name must be provided"})
+	}
+	if recipe.PrepMinutes < 0 {
+		fields = append(fields, FieldError{"prep_minutes", "Preparation time must not be negative"})
+	}
+	if recipe.CookMinutes < 0 {
+		fields = append(fields, FieldError{"cook_minutes", "Cooking time must not be negative"})
+	}
+	if recipe.Servings < 0 {
+		fields = append(fields, FieldError{"servings", "Servings must not be negative"})
 	}
-	for _, ingredient := range recipe.Ingredients {
+	fields = append(fields, validateTags(recipe.Tags)...)
+	for index, ingredient := range recipe.Ingredients {
 		if !isUnitValid(ingredient.Unit) {
-			messages = append(messages, fmt.Sprintf("Invalid measurement unit %s for %d", ingredient.Unit, ingredient.Id))
+			fields = append(fields, FieldError{fmt.Sprintf("ingredients[%d].unit", index), fmt.Sprintf("Invalid measurement unit %s for %d", ingredient.Unit, ingredient.Id)})
 		}
 		if ingredient.Amount <= 0.0 {
-			messages = append(messages, fmt.Sprintf("Ingredient amount must be greater then 0 for %d", ingredient.Id))
+			fields = append(fields, FieldError{fmt.Sprintf("ingredients[%d].amount", index), fmt.Sprintf("Ingredient amount must be greater then 0 for %d", ingredient.Id)})
 		}
 	}
-	if len(messages) > 0 {
-		return &ValidationError{messages: messages}
+	if len(fields) > 0 {
+		return &ValidationError{fields: fields}
 	}
 	return nil
 }
@@ -0,0 +1,65 @@
+package service
+
+import (
+	"sort"
+	"strings"
+)
+
+// RecipeSortKeys are the keys recipe lists can be sorted by.
+var RecipeSortKeys = []string{"id", "name", "rating", "last_cooked", "times_cooked"}
+
+// RecipeSort orders recipe lists by Key, descending if Desc is set. Recipes
+// without a rating or never cooked come last either way.
+type RecipeSort struct {
+	Key  string
+	Desc bool
+}
+
+// ParseRecipeSort parses a sort key optionally prefixed with - for
+// descending order, ok is false for unknown keys.
+func ParseRecipeSort(value string) (order RecipeSort, ok bool) {
+	order.Key = strings.TrimPrefix(value, "-")
+	order.Desc = order.Key != value
+	return order, contains(RecipeSortKeys, order.Key)
+}
+
+// SortRecipes sorts the recipes in place, ties are broken by id.
+func SortRecipes(recipes []RecipeGet, order RecipeSort) {
+	sort.SliceStable(recipes, func(i, j int) bool {
+		a, b := recipes[i], recipes[j]
+		var cmp int
+		switch order.Key {
+		case "name":
+			cmp = strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+		case "rating":
+			if a.Rating == nil || b.Rating == nil {
+				return a.Rating != nil && b.Rating == nil
+			}
+			cmp = compareFloat(*a.Rating, *b.Rating)
+		case "last_cooked":
+			if a.LastCooked == nil || b.LastCooked == nil {
+				return a.LastCooked != nil && b.LastCooked == nil
+			}
+			cmp = a.LastCooked.DaysSince(*b.LastCooked)
+		case "times_cooked":
+			cmp = a.TimesCooked - b.TimesCooked
+		}
+		if order.Desc {
+			cmp = -cmp
+		}
+		if cmp == 0 {
+			return a.Id < b.Id
+		}
+		return cmp < 0
+	})
+}
+
+func compareFloat(a, b float32) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
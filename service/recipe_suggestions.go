@@ -0,0 +1,88 @@
+package service
+
+import (
+	"sort"
+	"time"
+)
+
+// Suggest ranks the recipes using any of the ingredients to use up by how
+// many of them they use, then by how few ingredients are missing to cook
+// them.
+func (s RecipeServiceImpl) Suggest(query SuggestionQuery) ([]RecipeSuggestion, error) {
+	if len(query.IngredientIds) == 0 && query.ExpiringWithin <= 0 {
+		return nil, &ValidationError{fields: []FieldError{{"ingredients", "Ingredients or expiring_within must be given"}}}
+	}
+	use := make(map[int64]bool)
+	for _, id := range query.IngredientIds {
+		use[id] = true
+	}
+	pantry, err := s.pantryService.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	today := NewDate(time.Now())
+	have := make(map[int64]bool)
+	for _, item := range pantry {
+		if !item.inStock(today.Time) {
+			continue
+		}
+		have[item.Ingredient.Id] = true
+		if query.ExpiringWithin > 0 && item.ExpiresOn != nil && item.ExpiresOn.DaysSince(today) <= query.ExpiringWithin {
+			use[item.Ingredient.Id] = true
+		}
+	}
+	var useIds []int64
+	for id := range use {
+		useIds = append(useIds, id)
+		have[id] = true
+	}
+	ids, err := s.GetIdsByIngredients(useIds)
+	if err != nil {
+		return nil, err
+	}
+	var recipeIds []int64
+	seen := make(map[int64]bool)
+	for _, list := range ids {
+		for _, id := range list {
+			if !seen[id] {
+				seen[id] = true
+				recipeIds = append(recipeIds, id)
+			}
+		}
+	}
+	suggestions := []RecipeSuggestion{}
+	if len(recipeIds) == 0 {
+		return suggestions, nil
+	}
+	recipes, err := s.GetList(recipeIds)
+	if err != nil {
+		return nil, err
+	}
+	for _, recipe := range recipes {
+		suggestion := RecipeSuggestion{Recipe: recipe, Uses: []IngredientAmount{}, Missing: []IngredientAmount{}}
+		for _, ing := range recipe.Ingredients {
+			amount := IngredientAmount{IngredientId: ing.Id, Name: ing.Name, Amount: ing.Quantity.Amount, Unit: ing.Quantity.Unit}
+			switch {
+			case use[ing.Id]:
+				suggestion.Uses = append(suggestion.Uses, amount)
+			case !have[ing.Id]:
+				suggestion.Missing = append(suggestion.Missing, amount)
+			}
+		}
+		suggestions = append(suggestions, suggestion)
+	}
+	sort.Slice(suggestions, func(i, j int) bool {
+		a, b := suggestions[i], suggestions[j]
+		if len(a.Uses) != len(b.Uses) {
+			return len(a.Uses) > len(b.Uses)
+		}
+		if len(a.Missing) != len(b.Missing) {
+			return len(a.Missing) < len(b.Missing)
+		}
+		return a.Recipe.Id < b.Recipe.Id
+	})
+	if query.Limit > 0 && len(suggestions) > query.Limit {
+		suggestions = suggestions[:query.Limit]
+	}
+	return suggestions, nil
+}
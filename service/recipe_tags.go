@@ -0,0 +1,66 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const maxTagLength = 32
+
+// normalizeTags lower cases and trims the tags, dropping duplicates. The
+// result is sorted.
+func normalizeTags(tags []string) []string {
+	normalized := []string{}
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if !contains(normalized, tag) {
+			normalized = append(normalized, tag)
+		}
+	}
+	sort.Strings(normalized)
+	return normalized
+}
+
+func validateTags(tags []string) []FieldError {
+	var fields []FieldError
+	for index, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if tag == "" {
+			fields = append(fields, FieldError{fmt.Sprintf("tags[%d]", index), "Tag must not be empty"})
+		} else if len(tag) > maxTagLength {
+			fields = append(fields, FieldError{fmt.Sprintf("tags[%d]", index), fmt.Sprintf("Tag must be at most %d characters long", maxTagLength)})
+		}
+	}
+	return fields
+}
+
+// Facets counts the recipes passing the filter and how many of them have each
+// tag, the most used tags first.
+func (s RecipeServiceImpl) Facets(filter RecipeFilter) (RecipeFacets, error) {
+	recipes, err := s.GetAll()
+	if err != nil {
+		return RecipeFacets{}, err
+	}
+	facets := RecipeFacets{Tags: []TagCount{}}
+	counts := make(map[string]int)
+	for _, recipe := range recipes {
+		if !filter.Matches(recipe) {
+			continue
+		}
+		facets.Total++
+		for _, tag := range recipe.Tags {
+			counts[tag]++
+		}
+	}
+	for tag, count := range counts {
+		facets.Tags = append(facets.Tags, TagCount{Tag: tag, Count: count})
+	}
+	sort.Slice(facets.Tags, func(i, j int) bool {
+		if facets.Tags[i].Count != facets.Tags[j].Count {
+			return facets.Tags[i].Count > facets.Tags[j].Count
+		}
+		return facets.Tags[i].Tag < facets.Tags[j].Tag
+	})
+	return facets, nil
+}
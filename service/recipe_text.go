@@ -0,0 +1,688 @@
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Text formats recipes are exported to and imported from.
+const (
+	RecipeFormatMarkdown = "markdown"
+	RecipeFormatCooklang = "cooklang"
+)
+
+var RecipeFormats = []string{RecipeFormatMarkdown, RecipeFormatCooklang}
+
+// textIngredient is an ingredient of a recipe read from text, referenced by
+// its name until it is matched to the catalogue.
+type textIngredient struct {
+	name   string
+	line   int
+	amount float32
+	unit   string
+}
+
+// textRecipe is a recipe read from text, its ingredients are left out of the
+// RecipeCreate until their names are matched.
+type textRecipe struct {
+	RecipeCreate
+	ingredients []textIngredient
+}
+
+// recipeFrontMatter is the YAML front matter of a Markdown recipe.
+type recipeFrontMatter struct {
+	Name        string   `yaml:"name,omitempty"`
+	Servings    int32    `yaml:"servings,omitempty"`
+	PrepMinutes int32    `yaml:"prep_minutes,omitempty"`
+	CookMinutes int32    `yaml:"cook_minutes,omitempty"`
+	Tags        []string `yaml:"tags,omitempty"`
+}
+
+// unitAliases maps spelled out units to the supported ones.
+var unitAliases = map[string]string{
+	"gram":        "g",
+	"grams":       "g",
+	"kilogram":    "kg",
+	"kilograms":   "kg",
+	"pound":       "lb",
+	"pounds":      "lb",
+	"lbs":         "lb",
+	"ounce":       "oz",
+	"ounces":      "oz",
+	"milliliter":  "ml",
+	"milliliters": "ml",
+	"millilitre":  "ml",
+	"millilitres": "ml",
+	"liter":       "l",
+	"liters":      "l",
+	"litre":       "l",
+	"litres":      "l",
+	"teaspoon":    "tsp",
+	"teaspoons":   "tsp",
+	"tablespoon":  "tbsp",
+	"tablespoons": "tbsp",
+	"cup":         "c",
+	"cups":        "c",
+	"quart":       "qt",
+	"quarts":      "qt",
+	"pint":        "pt",
+	"pints":       "pt",
+	"gallon":      "gal",
+	"gallons":     "gal",
+}
+
+// normalizeUnit returns the supported unit the text stands for, false if
+// there is none.
+func normalizeUnit(unit string) (string, bool) {
+	unit = strings.ToLower(strings.TrimSpace(unit))
+	if alias, ok := unitAliases[unit]; ok {
+		unit = alias
+	}
+	return unit, isUnitValid(unit)
+}
+
+var unicodeFractions = map[rune]float64{'¼': 0.25, '½': 0.5, '¾': 0.75, '⅓': 1.0 / 3, '⅔': 2.0 / 3, '⅛': 0.125}
+
+// parseAmount reads decimals, with a point or a comma, fractions like 1/2 and
+// mixed numbers like 1 1/2 or 1½.
+func parseAmount(text string) (float32, bool) {
+	fields := strings.Fields(text)
+	if len(fields) == 0 || len(fields) > 2 {
+		return 0, false
+	}
+	var total float64
+	for index, field := range fields {
+		value, ok := parseAmountField(field)
+		if !ok || index == 1 && !strings.ContainsAny(field, "/¼½¾⅓⅔⅛") {
+			return 0, false
+		}
+		total += value
+	}
+	return float32(total), true
+}
+
+func parseAmountField(field string) (float64, bool) {
+	var fraction float64
+	for r, value := range unicodeFractions {
+		if strings.HasSuffix(field, string(r)) {
+			field = strings.TrimSuffix(field, string(r))
+			fraction = value
+			break
+		}
+	}
+	if field == "" {
+		return fraction, fraction > 0
+	}
+	if parts := strings.Split(field, "/"); len(parts) == 2 {
+		numerator, err1 := strconv.ParseFloat(parts[0], 64)
+		denominator, err2 := strconv.ParseFloat(parts[1], 64)
+		if err1 != nil || err2 != nil || denominator == 0 {
+			return 0, false
+		}
+		return numerator/denominator + fraction, true
+	}
+	value, err := strconv.ParseFloat(strings.Replace(field, ",", ".", 1), 64)
+	if err != nil {
+		return 0, false
+	}
+	return value + fraction, true
+}
+
+func formatTextAmount(amount float32) string {
+	return strconv.FormatFloat(float64(amount), 'f', -1, 32)
+}
+
+// RecipeMarkdown writes the recipe as Markdown, with its details in YAML
+// front matter followed by the ingredients and the numbered steps.
+func RecipeMarkdown(recipe RecipeGet) string {
+	var b bytes.Buffer
+	b.WriteString("---\n")
+	encoder := yaml.NewEncoder(&b)
+	encoder.SetIndent(2)
+	encoder.Encode(recipeFrontMatter{
+		Name:        recipe.Name,
+		Servings:    recipe.Servings,
+		PrepMinutes: recipe.PrepMinutes,
+		CookMinutes: recipe.CookMinutes,
+		Tags:        recipe.Tags,
+	})
+	encoder.Close()
+	fmt.Fprintf(&b, "---\n\n# %s\n", recipe.Name)
+	if len(recipe.Ingredients) > 0 {
+		b.WriteString("\n## Ingredients\n\n")
+		for _, ing := range recipe.Ingredients {
+			fmt.Fprintf(&b, "- %s %s %s\n", formatTextAmount(ing.Quantity.Amount), ing.Quantity.Unit, ing.Name)
+		}
+	}
+	if steps := SplitSteps(recipe.Steps); len(steps) > 0 {
+		b.WriteString("\n## Steps\n\n")
+		for index, step := range steps {
+			fmt.Fprintf(&b, "%d. %s\n", index+1, step)
+		}
+	}
+	return b.String()
+}
+
+var (
+	markdownListItem = regexp.MustCompile(`^\s*(?:[-*+]|\d+[.)])\s+`)
+	markdownHeading  = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*\s*$`)
+)
+
+// splitFrontMatter returns the YAML between the --- lines opening the text
+// and the rest of it, along with the number of lines the front matter took.
+func splitFrontMatter(lines []string) (frontMatter string, rest []string, offset int) {
+	if len(lines) == 0 || strings.TrimSpace(lines[0]) != "---" {
+		return "", lines, 0
+	}
+	for index := 1; index < len(lines); index++ {
+		if line := strings.TrimSpace(lines[index]); line == "---" || line == "..." {
+			return strings.Join(lines[1:index], "\n"), lines[index+1:], index + 1
+		}
+	}
+	return "", lines, 0
+}
+
+func textLines(text string) []string {
+	text = strings.TrimPrefix(text, "\ufeff")
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	return strings.Split(text, "\n")
+}
+
+// parseMarkdownRecipe reads a recipe written as RecipeMarkdown does. The name
+// is taken from the front matter or the first top level heading, ingredients
+// and steps from the list items below headings naming them. Ingredient lines
+// start with the amount and unit, anything after a comma in the name is a
+// note and left out.
+func parseMarkdownRecipe(text string) (textRecipe, []FieldError) {
+	var recipe textRecipe
+	var fields []FieldError
+	frontMatter, lines, offset := splitFrontMatter(textLines(text))
+	if frontMatter != "" {
+		var meta recipeFrontMatter
+		if err := yaml.Unmarshal([]byte(frontMatter), &meta); err != nil {
+			fields = append(fields, FieldError{"front_matter", "Invalid front matter: " + err.Error()})
+		}
+		recipe.Name = meta.Name
+		recipe.Servings = meta.Servings
+		recipe.PrepMinutes = meta.PrepMinutes
+		recipe.CookMinutes = meta.CookMinutes
+		recipe.Tags = meta.Tags
+	}
+	const (
+		noSection = iota
+		ingredientSection
+		stepSection
+	)
+	section := noSection
+	var steps []string
+	continuing := false
+	for index, line := range lines {
+		number := offset + index + 1
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			continuing = false
+			continue
+		}
+		if heading := markdownHeading.FindStringSubmatch(trimmed); heading != nil {
+			continuing = false
+			title := strings.ToLower(heading[2])
+			switch {
+			case len(heading[1]) == 1:
+				if recipe.Name == "" {
+					recipe.Name = heading[2]
+				}
+				section = noSection
+			case len(heading[1]) > 2:
+				// Subheadings group the lines of the section they're in.
+			case strings.Contains(title, "ingredient"):
+				section = ingredientSection
+			case strings.Contains(title, "step") || strings.Contains(title, "direction") || strings.Contains(title, "method") ||
+				strings.Contains(title, "instruction") || strings.Contains(title, "preparation"):
+				section = stepSection
+			default:
+				section = noSection
+			}
+			continue
+		}
+		item := markdownListItem.FindString(line)
+		content := strings.TrimSpace(line[len(item):])
+		switch section {
+		case ingredientSection:
+			ing, err := parseIngredientLine(content)
+			if err != "" {
+				fields = append(fields, FieldError{fmt.Sprintf("line %d", number), err})
+				continue
+			}
+			ing.line = number
+			recipe.ingredients = append(recipe.ingredients, ing)
+		case stepSection:
+			if continuing && item == "" {
+				steps[len(steps)-1] += " " + content
+			} else {
+				steps = append(steps, content)
+			}
+			continuing = true
+		}
+	}
+	recipe.Steps = strings.Join(steps, "\n")
+	return recipe, fields
+}
+
+// parseIngredientLine reads an ingredient written as its amount, unit and
+// name, like "200 g flour", "1 1/2 cups milk" or "500g potatoes, peeled".
+func parseIngredientLine(line string) (textIngredient, string) {
+	words := strings.Fields(line)
+	if len(words) == 0 {
+		return textIngredient{}, "Missing ingredient"
+	}
+	// The unit may be written right after the amount, as in 200g.
+	if split := strings.IndexFunc(words[0], unicode.IsLetter); split > 0 {
+		if _, ok := normalizeUnit(words[0][split:]); ok {
+			words = append([]string{words[0][:split], words[0][split:]}, words[1:]...)
+		}
+	}
+	var ing textIngredient
+	amountWords := 1
+	amount, ok := parseAmount(words[0])
+	if len(words) > 1 {
+		if mixed, mixedOk := parseAmount(words[0] + " " + words[1]); mixedOk {
+			amount, ok, amountWords = mixed, true, 2
+		}
+	}
+	if !ok || len(words) < amountWords+2 {
+		return textIngredient{}, fmt.Sprintf("Ingredient %q must start with an amount and a unit", line)
+	}
+	ing.amount = amount
+	unit, ok := normalizeUnit(words[amountWords])
+	if !ok {
+		return textIngredient{}, fmt.Sprintf("Unknown unit %s in %q, expected one of %s", words[amountWords], line, strings.Join(Units(), ", "))
+	}
+	ing.unit = unit
+	name := strings.Join(words[amountWords+1:], " ")
+	name = strings.TrimPrefix(name, "of ")
+	if comma := strings.Index(name, ","); comma >= 0 {
+		name = name[:comma]
+	}
+	ing.name = strings.TrimSpace(name)
+	if ing.name == "" {
+		return textIngredient{}, fmt.Sprintf("Ingredient %q has no name", line)
+	}
+	return ing, ""
+}
+
+// cooklangIngredientsPrefix opens the step RecipeCooklang lists the
+// ingredients no step mentions in, it's left out of the steps on import.
+const cooklangIngredientsPrefix = "Ingredients: "
+
+// cooklangMarkup is a span of a step replaced by Cooklang markup.
+type cooklangMarkup struct {
+	start, end int
+	markup     string
+}
+
+// RecipeCooklang writes the recipe in the Cooklang format. Each ingredient is
+// marked up where a step first mentions it by name, ingredients no step
+// mentions are listed in a step of their own opening the recipe.
+func RecipeCooklang(recipe RecipeGet) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, ">> title: %s\n", recipe.Name)
+	fmt.Fprintf(&b, ">> servings: %d\n", recipe.Servings)
+	if recipe.PrepMinutes > 0 {
+		fmt.Fprintf(&b, ">> prep time: %d minutes\n", recipe.PrepMinutes)
+	}
+	if recipe.CookMinutes > 0 {
+		fmt.Fprintf(&b, ">> cook time: %d minutes\n", recipe.CookMinutes)
+	}
+	if len(recipe.Tags) > 0 {
+		fmt.Fprintf(&b, ">> tags: %s\n", strings.Join(recipe.Tags, ", "))
+	}
+
+	steps := SplitSteps(recipe.Steps)
+	markups := make([][]cooklangMarkup, len(steps))
+	// Longer names go first so "olive oil" is marked up before "oil".
+	order := make([]int, len(recipe.Ingredients))
+	for index := range order {
+		order[index] = index
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return len(recipe.Ingredients[order[i]].Name) > len(recipe.Ingredients[order[j]].Name)
+	})
+	var unmentioned []int
+	for _, index := range order {
+		ing := recipe.Ingredients[index]
+		mentioned := false
+		for s, step := range steps {
+			if start, end, ok := findMention(step, ing.Name, markups[s]); ok {
+				markups[s] = append(markups[s], cooklangMarkup{start, end, cooklangIngredient(step[start:end], ing.Quantity)})
+				mentioned = true
+				break
+			}
+		}
+		if !mentioned {
+			unmentioned = append(unmentioned, index)
+		}
+	}
+	if len(unmentioned) > 0 {
+		sort.Ints(unmentioned)
+		listed := make([]string, len(unmentioned))
+		for i, index := range unmentioned {
+			listed[i] = cooklangIngredient(recipe.Ingredients[index].Name, recipe.Ingredients[index].Quantity)
+		}
+		fmt.Fprintf(&b, "\n%s%s\n", cooklangIngredientsPrefix, strings.Join(listed, ", "))
+	}
+	for s, step := range steps {
+		sort.Slice(markups[s], func(i, j int) bool { return markups[s][i].start < markups[s][j].start })
+		b.WriteString("\n")
+		last := 0
+		for _, m := range markups[s] {
+			b.WriteString(step[last:m.start])
+			b.WriteString(m.markup)
+			last = m.end
+		}
+		b.WriteString(step[last:])
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+func cooklangIngredient(name string, quantity Quantity) string {
+	return fmt.Sprintf("@%s{%s%%%s}", name, formatTextAmount(quantity.Amount), quantity.Unit)
+}
+
+// findMention returns where the step mentions the name, or its plural, as a
+// whole word, case insensitively and outside of the spans already marked up.
+func findMention(step, name string, taken []cooklangMarkup) (int, int, bool) {
+	isWord := func(r rune) bool {
+		return unicode.IsLetter(r) || unicode.IsDigit(r)
+	}
+	for _, form := range []string{name, name + "s", name + "es"} {
+		for start := 0; start+len(form) <= len(step); start++ {
+			end := start + len(form)
+			if !strings.EqualFold(step[start:end], form) {
+				continue
+			}
+			before, _ := utf8.DecodeLastRuneInString(step[:start])
+			after, _ := utf8.DecodeRuneInString(step[end:])
+			if isWord(before) || isWord(after) {
+				continue
+			}
+			free := true
+			for _, m := range taken {
+				if start < m.end && end > m.start {
+					free = false
+					break
+				}
+			}
+			if free {
+				return start, end, true
+			}
+		}
+	}
+	return 0, 0, false
+}
+
+var (
+	cooklangBlockComment = regexp.MustCompile(`(?s)\[-.*?-\]`)
+	// cooklangLineComment matches -- starting a line or following whitespace,
+	// so ranges like 10--15 min are kept.
+	cooklangLineComment = regexp.MustCompile(`(?:^|\s)--.*$`)
+	// cooklangComponent matches ingredients (@), cookware (#) and timers (~).
+	// Names of more than one word end at the braces, single words need none.
+	// An optional note in parentheses may follow the braces.
+	cooklangComponent = regexp.MustCompile(`([@#~])(?:([^@#~{}\n]*)\{([^}]*)\}(?:\([^)]*\))?|([\p{L}\p{N}_-]+))`)
+)
+
+// Cooklang leaves the amount of ingredients like @salt or @pepper{} to the
+// cook, they're imported as a teaspoon so the nutrition and shopping lists
+// still count them.
+const (
+	cooklangDefaultAmount = 1
+	cooklangDefaultUnit   = "tsp"
+)
+
+// parseCooklangRecipe reads a recipe in the Cooklang format. Steps are
+// separated by blank lines, metadata is read from >> lines or YAML front
+// matter. Ingredients must have an amount and a unit or neither, cookware and
+// timers are kept as plain text.
+func parseCooklangRecipe(text string) (textRecipe, []FieldError) {
+	var recipe textRecipe
+	var fields []FieldError
+	text = cooklangBlockComment.ReplaceAllStringFunc(text, func(comment string) string {
+		return strings.Repeat("\n", strings.Count(comment, "\n"))
+	})
+	frontMatter, lines, offset := splitFrontMatter(textLines(text))
+	if frontMatter != "" {
+		var meta map[string]interface{}
+		if err := yaml.Unmarshal([]byte(frontMatter), &meta); err != nil {
+			fields = append(fields, FieldError{"front_matter", "Invalid front matter: " + err.Error()})
+		}
+		keys := make([]string, 0, len(meta))
+		for key := range meta {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			value := fmt.Sprint(meta[key])
+			if list, ok := meta[key].([]interface{}); ok {
+				values := make([]string, len(list))
+				for index, v := range list {
+					values[index] = fmt.Sprint(v)
+				}
+				value = strings.Join(values, ",")
+			}
+			if err := applyCooklangMetadata(&recipe.RecipeCreate, key, value); err != "" {
+				fields = append(fields, FieldError{"front_matter", err})
+			}
+		}
+	}
+	var steps []string
+	var step []string
+	stepLine := 0
+	endStep := func() {
+		if len(step) == 0 {
+			return
+		}
+		content, ingredients, errs := parseCooklangStep(strings.Join(step, " "), stepLine)
+		fields = append(fields, errs...)
+		recipe.ingredients = append(recipe.ingredients, ingredients...)
+		if !strings.HasPrefix(strings.Join(step, " "), cooklangIngredientsPrefix) || !isIngredientList(content, ingredients) {
+			steps = append(steps, content)
+		}
+		step = nil
+	}
+	for index, line := range lines {
+		number := offset + index + 1
+		line = cooklangLineComment.ReplaceAllString(line, "")
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(trimmed, ">>"):
+			endStep()
+			parts := strings.SplitN(strings.TrimPrefix(trimmed, ">>"), ":", 2)
+			if len(parts) != 2 {
+				fields = append(fields, FieldError{fmt.Sprintf("line %d", number), "Metadata must be written as >> key: value"})
+				continue
+			}
+			if err := applyCooklangMetadata(&recipe.RecipeCreate, parts[0], parts[1]); err != "" {
+				fields = append(fields, FieldError{fmt.Sprintf("line %d", number), err})
+			}
+		case trimmed == "", strings.HasPrefix(trimmed, ">"), strings.HasPrefix(trimmed, "="):
+			// Blank lines end steps, notes and section names aren't kept.
+			endStep()
+		default:
+			if len(step) == 0 {
+				stepLine = number
+			}
+			step = append(step, trimmed)
+		}
+	}
+	endStep()
+	recipe.Steps = strings.Join(steps, "\n")
+	return recipe, fields
+}
+
+// parseCooklangStep replaces the markup of the step with plain text and
+// returns the ingredients it references.
+func parseCooklangStep(step string, line int) (string, []textIngredient, []FieldError) {
+	var ingredients []textIngredient
+	var fields []FieldError
+	content := cooklangComponent.ReplaceAllStringFunc(step, func(markup string) string {
+		match := cooklangComponent.FindStringSubmatch(markup)
+		name, braces := strings.TrimSpace(match[2]+match[4]), match[4] == ""
+		switch match[1] {
+		case "~":
+			if braces {
+				return strings.TrimSpace(strings.Replace(match[3], "%", " ", 1))
+			}
+		case "@":
+			if name == "" {
+				return markup
+			}
+			ing := textIngredient{name: name, line: line}
+			if strings.TrimSpace(match[3]) == "" {
+				ing.amount, ing.unit = cooklangDefaultAmount, cooklangDefaultUnit
+				ingredients = append(ingredients, ing)
+				return name
+			}
+			parts := strings.SplitN(match[3], "%", 2)
+			amount, ok := parseAmount(parts[0])
+			if !ok || len(parts) != 2 {
+				fields = append(fields, FieldError{fmt.Sprintf("line %d", line), fmt.Sprintf("Ingredient %s must have an amount and a unit, as in @%s{200%%g}", name, name)})
+				return name
+			}
+			unit, ok := normalizeUnit(parts[1])
+			if !ok {
+				fields = append(fields, FieldError{fmt.Sprintf("line %d", line), fmt.Sprintf("Unknown unit %s of %s, expected one of %s", parts[1], name, strings.Join(Units(), ", "))})
+				return name
+			}
+			ing.amount, ing.unit = amount, unit
+			ingredients = append(ingredients, ing)
+		}
+		return name
+	})
+	return strings.Join(strings.Fields(content), " "), ingredients, fields
+}
+
+// isIngredientList tells if the step only lists the ingredients, as the step
+// RecipeCooklang adds for ingredients no step mentions.
+func isIngredientList(content string, ingredients []textIngredient) bool {
+	names := make([]string, len(ingredients))
+	for index, ing := range ingredients {
+		names[index] = ing.name
+	}
+	return len(ingredients) > 0 && content == cooklangIngredientsPrefix+strings.Join(names, ", ")
+}
+
+var durationPart = regexp.MustCompile(`(\d+(?:[.,]\d+)?)\s*([a-z]*)`)
+
+// applyCooklangMetadata sets the recipe field the metadata key stands for,
+// unknown keys are ignored.
+func applyCooklangMetadata(recipe *RecipeCreate, key, value string) string {
+	key = strings.ToLower(strings.TrimSpace(key))
+	value = strings.TrimSpace(value)
+	switch key {
+	case "title", "name":
+		recipe.Name = value
+	case "servings", "serves", "yield":
+		// Servings may list the amounts the recipe scales to, as in 2|4|8.
+		digits := strings.TrimLeftFunc(strings.SplitN(value, "|", 2)[0], unicode.IsSpace)
+		if end := strings.IndexFunc(digits, func(r rune) bool { return !unicode.IsDigit(r) }); end >= 0 {
+			digits = digits[:end]
+		}
+		servings, err := strconv.Atoi(digits)
+		if err != nil {
+			return fmt.Sprintf("Servings must be a number, got %s", value)
+		}
+		recipe.Servings = int32(servings)
+	case "tags":
+		recipe.Tags = nil
+		for _, tag := range strings.Split(value, ",") {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				recipe.Tags = append(recipe.Tags, tag)
+			}
+		}
+	case "prep time", "prep_time", "preparation time":
+		minutes, ok := parseMinutes(value)
+		if !ok {
+			return fmt.Sprintf("Invalid preparation time %s", value)
+		}
+		recipe.PrepMinutes = minutes
+	case "cook time", "cook_time", "cooking time":
+		minutes, ok := parseMinutes(value)
+		if !ok {
+			return fmt.Sprintf("Invalid cooking time %s", value)
+		}
+		recipe.CookMinutes = minutes
+	}
+	return ""
+}
+
+// parseMinutes reads durations like "45", "20 minutes", "1 hour 30 min" or
+// "1h30m" as minutes.
+func parseMinutes(value string) (int32, bool) {
+	value = strings.ToLower(value)
+	parts := durationPart.FindAllStringSubmatch(value, -1)
+	if len(parts) == 0 {
+		return 0, false
+	}
+	var minutes float64
+	for _, part := range parts {
+		amount, _ := strconv.ParseFloat(strings.Replace(part[1], ",", ".", 1), 64)
+		switch part[2] {
+		case "", "m", "min", "mins", "minute", "minutes":
+			minutes += amount
+		case "h", "hr", "hrs", "hour", "hours":
+			minutes += amount * 60
+		default:
+			return 0, false
+		}
+	}
+	return int32(minutes + 0.5), true
+}
+
+// matchIngredients looks up the ingredients of the recipe in the catalogue by
+// name, case insensitively and allowing for plurals. Ingredients used more
+// than once are added up.
+func matchIngredients(recipe textRecipe, catalogue []Ingredient) (RecipeCreate, []FieldError) {
+	var fields []FieldError
+	byName := make(map[string]int64, len(catalogue))
+	for _, ing := range catalogue {
+		byName[strings.ToLower(ing.Name)] = ing.Id
+	}
+	positions := make(map[int64]int)
+	for _, ing := range recipe.ingredients {
+		name := strings.ToLower(ing.name)
+		var id int64
+		for _, candidate := range []string{name, name + "s", name + "es", strings.TrimSuffix(name, "s"), strings.TrimSuffix(name, "es")} {
+			var ok bool
+			if id, ok = byName[candidate]; ok {
+				break
+			}
+		}
+		if id == 0 {
+			fields = append(fields, FieldError{fmt.Sprintf("line %d", ing.line), fmt.Sprintf("No ingredient named %s in the catalogue", ing.name)})
+			continue
+		}
+		position, ok := positions[id]
+		if !ok {
+			positions[id] = len(recipe.Ingredients)
+			recipe.Ingredients = append(recipe.Ingredients, IngredientShort{Id: id, Amount: ing.amount, Unit: ing.unit})
+			continue
+		}
+		existing := &recipe.Ingredients[position]
+		scale, err := ConvertUnit(ing.unit, existing.Unit)
+		if err != nil {
+			fields = append(fields, FieldError{fmt.Sprintf("line %d", ing.line), fmt.Sprintf("%s is measured in both %s and %s, which can't be added up", ing.name, existing.Unit, ing.unit)})
+			continue
+		}
+		existing.Amount += ing.amount * scale
+	}
+	return recipe.RecipeCreate, fields
+}
@@ -0,0 +1,33 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseCooklangRecipe(t *testing.T) {
+	text := `>> title: Soup
+-- a comment line
+Boil @water{1%l} for ~{10--15%minutes}. -- until it bubbles
+Season with @salt and @black pepper{}, then add @leeks{2}.`
+	recipe, fields := parseCooklangRecipe(text)
+	if recipe.Name != "Soup" {
+		t.Errorf("name %q", recipe.Name)
+	}
+	wantSteps := "Boil water for 10--15 minutes. Season with salt and black pepper, then add leeks."
+	if recipe.Steps != wantSteps {
+		t.Errorf("steps %q, want %q", recipe.Steps, wantSteps)
+	}
+	wantIngredients := []textIngredient{
+		{name: "water", line: 3, amount: 1, unit: "l"},
+		{name: "salt", line: 3, amount: cooklangDefaultAmount, unit: cooklangDefaultUnit},
+		{name: "black pepper", line: 3, amount: cooklangDefaultAmount, unit: cooklangDefaultUnit},
+	}
+	if !reflect.DeepEqual(recipe.ingredients, wantIngredients) {
+		t.Errorf("ingredients %+v, want %+v", recipe.ingredients, wantIngredients)
+	}
+	// An amount without a unit can't be converted, it's still an error.
+	if len(fields) != 1 || fields[0].Field != "line 3" {
+		t.Errorf("fields %+v, want the missing unit of leeks", fields)
+	}
+}
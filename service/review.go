@@ -0,0 +1,23 @@
+package service
+
+// Review is a user's rating of a recipe from 1 to 5 with an optional review
+// text. Each user reviews a recipe once, Date defaults to today.
+type Review struct {
+	Id       int64  `json:"id"`
+	RecipeId int64  `json:"recipe_id"`
+	User     string `json:"user" validate:"required"`
+	Rating   int32  `json:"rating" validate:"gte=1,lte=5"`
+	Text     string `json:"text"`
+	Date     Date   `json:"date"`
+}
+
+// CookLogEntry records a recipe being cooked on a date, with how it was
+// changed and turned out. Servings defaults to 1 when left at 0.
+type CookLogEntry struct {
+	Id            int64   `json:"id"`
+	RecipeId      int64   `json:"recipe_id"`
+	Date          Date    `json:"date" validate:"required"`
+	Servings      float32 `json:"servings" validate:"gte=0"`
+	Notes         string  `json:"notes"`
+	Modifications string  `json:"modifications"`
+}
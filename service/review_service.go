@@ -0,0 +1,156 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cookbook/repository"
+)
+
+const (
+	minRating         = 1
+	maxRating         = 5
+	maxUserNameLength = 64
+)
+
+type ReviewService interface {
+	Get(int64) (Review, error)
+	GetAll(recipeId int64) ([]Review, error)
+	Create(Review) (int64, error)
+	Update(Review) error
+	Delete(int64) error
+}
+
+type ReviewServiceImpl struct {
+	repo       *repository.ReviewRepository
+	rcpService RecipeService
+}
+
+func NewReviewService(r *repository.ReviewRepository, rs RecipeService) ReviewService {
+	return ReviewServiceImpl{
+		repo:       r,
+		rcpService: rs,
+	}
+}
+
+func (s ReviewServiceImpl) Get(id int64) (Review, error) {
+	rReview, err := s.repo.Get(id)
+	if err != nil {
+		return Review{}, handleError(err)
+	}
+	return convertRepoReview(rReview), nil
+}
+
+// GetAll returns the reviews of the recipe, or of all recipes if recipeId is
+// 0, the most recent first.
+func (s ReviewServiceImpl) GetAll(recipeId int64) ([]Review, error) {
+	rReviews, err := s.repo.GetAll(recipeId)
+	if err != nil {
+		return []Review{}, handleError(err)
+	}
+	reviews := make([]Review, len(rReviews))
+	for index, rReview := range rReviews {
+		reviews[index] = convertRepoReview(rReview)
+	}
+	return reviews, nil
+}
+
+func (s ReviewServiceImpl) Create(review Review) (int64, error) {
+	err := s.validate(review)
+	if err != nil {
+		return 0, err
+	}
+	id, err := s.repo.Create(toRepoReview(review))
+	if err != nil {
+		return 0, handleError(err)
+	}
+	return id, nil
+}
+
+func (s ReviewServiceImpl) Update(review Review) error {
+	err := s.validate(review)
+	if err != nil {
+		return err
+	}
+	err = s.repo.Update(toRepoReview(review))
+	if err != nil {
+		return handleError(err)
+	}
+	return nil
+}
+
+func (s ReviewServiceImpl) Delete(id int64) error {
+	err := s.repo.Delete(id)
+	if err != nil {
+		return handleError(err)
+	}
+	return nil
+}
+
+func convertRepoReview(rReview repository.Review) Review {
+	return Review{
+		Id:       rReview.Id,
+		RecipeId: rReview.RecipeId,
+		User:     rReview.User,
+		Rating:   rReview.Rating,
+		Text:     rReview.Text,
+		Date:     NewDate(rReview.Date),
+	}
+}
+
+func toRepoReview(review Review) repository.Review {
+	date := review.Date
+	if date.IsZero() {
+		date = NewDate(time.Now())
+	}
+	return repository.Review{
+		Id:       review.Id,
+		RecipeId: review.RecipeId,
+		User:     strings.TrimSpace(review.User),
+		Rating:   review.Rating,
+		Text:     review.Text,
+		Date:     date.Time,
+	}
+}
+
+// validate also makes sure the user hasn't reviewed the recipe in another
+// review, returning a Conflict if they did.
+func (s ReviewServiceImpl) validate(review Review) error {
+	err := validateReview(review)
+	if err != nil {
+		return err
+	}
+	err = referenceError([]int64{review.RecipeId}, "Recipe", func(int) string {
+		return "recipe_id"
+	}, s.rcpService.GetExistingIds)
+	if err != nil {
+		return err
+	}
+	user := strings.TrimSpace(review.User)
+	existing, err := s.repo.GetIdByUser(review.RecipeId, user)
+	if err != nil {
+		return handleError(err)
+	}
+	if existing != 0 && existing != review.Id {
+		return &Conflict{message: fmt.Sprintf("%s already reviewed recipe %d in review %d", user, review.RecipeId, existing)}
+	}
+	return nil
+}
+
+func validateReview(review Review) error {
+	var fields []FieldError
+	user := strings.TrimSpace(review.User)
+	if user == "" {
+		fields = append(fields, FieldError{"user", "User must be provided"})
+	} else if len(user) > maxUserNameLength {
+		fields = append(fields, FieldError{"user", fmt.Sprintf("User must be at most %d characters long", maxUserNameLength)})
+	}
+	if review.Rating < minRating || review.Rating > maxRating {
+		fields = append(fields, FieldError{"rating", fmt.Sprintf("Rating must be between %d and %d", minRating, maxRating)})
+	}
+	if len(fields) > 0 {
+		return &ValidationError{fields: fields}
+	}
+	return nil
+}
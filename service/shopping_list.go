@@ -0,0 +1,214 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cookbook/repository"
+)
+
+// GetShoppingList returns the ingredients needed by the meal plan entries
+// that weren't cooked yet, less what the pantry holds before it expires,
+// priced at the latest price of each ingredient.
+func (s MealPlanServiceImpl) GetShoppingList(id int64) ([]ShoppingListItem, error) {
+	return s.GetShoppingListBetween(id, Date{}, Date{})
+}
+
+// GetShoppingListBetween returns the shopping list of the entries between
+// from and to, both inclusive and ignored if zero.
+func (s MealPlanServiceImpl) GetShoppingListBetween(id int64, from, to Date) ([]ShoppingListItem, error) {
+	mealPlan, err := s.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	var entries []MealPlanEntryGet
+	for _, entry := range mealPlan.Entries {
+		if entry.Cooked || !from.IsZero() && entry.Date.Before(from.Time) || !to.IsZero() && entry.Date.After(to.Time) {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	return s.shoppingListFor(entries)
+}
+
+// shoppingListFor returns the ingredients needed by the entries less what the
+// pantry holds before it expires, priced at the latest price of each
+// ingredient.
+func (s MealPlanServiceImpl) shoppingListFor(entries []MealPlanEntryGet) ([]ShoppingListItem, error) {
+	needed := neededIngredients(entries...)
+	pantry, err := s.pantryService.GetByIngredients(ingredientIds(needed))
+	if err != nil {
+		return nil, err
+	}
+	prices, err := s.priceService.GetLatest(ingredientIds(needed))
+	if err != nil {
+		return nil, err
+	}
+	stock := newPantryStock(pantry, time.Now())
+	list := make([]ShoppingListItem, len(needed))
+	for index, need := range needed {
+		taken, _ := stock.take(need)
+		list[index] = ShoppingListItem{
+			IngredientId: need.IngredientId,
+			Name:         need.Name,
+			Unit:         need.Unit,
+			Needed:       need.Amount,
+			InPantry:     taken,
+		}
+		if taken < need.Amount {
+			list[index].ToBuy = need.Amount - taken
+		}
+		if price, ok := prices[need.IngredientId]; ok {
+			if cost, err := priceQuantity(price, Quantity{Amount: list[index].ToBuy, Unit: need.Unit}); err == nil {
+				list[index].Cost = &cost
+			}
+		}
+	}
+	return list, nil
+}
+
+// Cook marks the meal plan entry at index as cooked and takes its
+// ingredients out of the pantry, using the items expiring first. Leftovers
+// are marked as eaten.
+func (s MealPlanServiceImpl) Cook(id int64, index int) (CookedEntry, error) {
+	mealPlan, err := s.Get(id)
+	if err != nil {
+		return CookedEntry{}, err
+	}
+	if index < 0 || index >= len(mealPlan.Entries) {
+		return CookedEntry{}, &NotFound{fmt.Sprintf("Meal plan %d has no entry %d", id, index)}
+	}
+	entry := mealPlan.Entries[index]
+	if entry.Cooked {
+		return CookedEntry{}, &Conflict{message: fmt.Sprintf("Entry %d of meal plan %d was already cooked", index, id)}
+	}
+	// Leftovers take nothing out of the pantry, but their batch has to be
+	// cooked first.
+	if entry.Leftover {
+		for _, other := range mealPlan.Entries[:index] {
+			if other.Batch == entry.Batch && !other.Leftover && !other.Cooked {
+				return CookedEntry{}, &Conflict{message: fmt.Sprintf("Batch %d of meal plan %d wasn't cooked yet", entry.Batch, id)}
+			}
+		}
+	}
+	needed := neededIngredients(entry)
+	pantry, err := s.pantryService.GetByIngredients(ingredientIds(needed))
+	if err != nil {
+		return CookedEntry{}, err
+	}
+	stock := newPantryStock(pantry, entry.Date.Time)
+	cooked := CookedEntry{Deducted: []PantryDeduction{}, Missing: []IngredientAmount{}}
+	deductions := make(map[int64]float32)
+	for _, need := range needed {
+		taken, deducted := stock.take(need)
+		for _, deduction := range deducted {
+			deductions[deduction.PantryItemId] += deduction.Amount
+			cooked.Deducted = append(cooked.Deducted, deduction)
+		}
+		if taken < need.Amount {
+			need.Amount -= taken
+			cooked.Missing = append(cooked.Missing, need)
+		}
+	}
+	err = s.repo.MarkCooked(id, index, deductions)
+	// The entry may have changed since it was read above.
+	switch err.(type) {
+	case nil:
+	case *repository.NotFound:
+		return CookedEntry{}, &NotFound{fmt.Sprintf("Meal plan %d has no entry %d", id, index)}
+	case *repository.Conflict:
+		return CookedEntry{}, &Conflict{message: fmt.Sprintf("Entry %d of meal plan %d was already cooked", index, id)}
+	default:
+		return CookedEntry{}, handleError(err)
+	}
+	return cooked, nil
+}
+
+// neededIngredients sums the ingredients of the entries' recipes, scaled by
+// the share of each recipe cooked for the entry so batches count once.
+// Amounts of an ingredient are converted to the unit it's first used in,
+// amounts that can't be converted are kept apart.
+func neededIngredients(entries ...MealPlanEntryGet) []IngredientAmount {
+	var needed []IngredientAmount
+	for _, entry := range entries {
+		for _, recipe := range entry.Meal.Recipes {
+			for _, ing := range recipe.Ingredients {
+				amount := ing.Quantity.Amount * entry.CookedShare(recipe)
+				added := false
+				for i := range needed {
+					if needed[i].IngredientId != ing.Id {
+						continue
+					}
+					if scale, err := ConvertUnit(ing.Quantity.Unit, needed[i].Unit); err == nil {
+						needed[i].Amount += amount * scale
+						added = true
+						break
+					}
+				}
+				if !added {
+					needed = append(needed, IngredientAmount{IngredientId: ing.Id, Name: ing.Name, Amount: amount, Unit: ing.Quantity.Unit})
+				}
+			}
+		}
+	}
+	return needed
+}
+
+func ingredientIds(amounts []IngredientAmount) []int64 {
+	var ids []int64
+	for _, amount := range amounts {
+		ids = append(ids, amount.IngredientId)
+	}
+	return ids
+}
+
+// pantryStock tracks what is left of the pantry items while amounts are
+// taken out of them.
+type pantryStock struct {
+	items     []PantryItemGet
+	remaining map[int64]float32
+}
+
+// newPantryStock holds the items that haven't expired by the date, in the
+// order they should be used.
+func newPantryStock(items []PantryItemGet, date time.Time) *pantryStock {
+	stock := &pantryStock{remaining: make(map[int64]float32)}
+	for _, item := range items {
+		if item.inStock(date) {
+			stock.items = append(stock.items, item)
+			stock.remaining[item.Id] = item.Quantity.Amount
+		}
+	}
+	return stock
+}
+
+// take takes up to the needed amount out of the items of the ingredient with
+// a compatible unit. It returns the amount taken, in the unit of need, and
+// what was taken from each item, in the units of the items.
+func (stock *pantryStock) take(need IngredientAmount) (float32, []PantryDeduction) {
+	var taken float32
+	var deductions []PantryDeduction
+	for _, item := range stock.items {
+		if taken >= need.Amount {
+			break
+		}
+		if item.Ingredient.Id != need.IngredientId || stock.remaining[item.Id] <= 0 {
+			continue
+		}
+		scale, err := ConvertUnit(need.Unit, item.Quantity.Unit)
+		if err != nil {
+			continue
+		}
+		amount := (need.Amount - taken) * scale
+		if amount > stock.remaining[item.Id] {
+			amount = stock.remaining[item.Id]
+		}
+		stock.remaining[item.Id] -= amount
+		taken += amount / scale
+		deductions = append(deductions, PantryDeduction{
+			PantryItemId:     item.Id,
+			IngredientAmount: IngredientAmount{IngredientId: need.IngredientId, Name: need.Name, Amount: amount, Unit: item.Quantity.Unit},
+		})
+	}
+	return taken, deductions
+}
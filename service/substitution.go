@@ -0,0 +1,31 @@
+package service
+
+// Substitution records that the substitute can replace the ingredient. Ratio
+// is the amount of the substitute to use per amount of the ingredient, in the
+// same unit, it defaults to 1 when left at 0. Diets lists the diets the
+// substitution is meant for, e.g. vegan for replacing eggs.
+type Substitution struct {
+	Id           int64    `json:"id"`
+	IngredientId int64    `json:"ingredient_id"`
+	SubstituteId int64    `json:"substitute_id"`
+	Ratio        float32  `json:"ratio" validate:"gte=0"`
+	Notes        string   `json:"notes"`
+	Diets        []string `json:"diets" validate:"diet"`
+}
+
+// SubstituteSuggestion is a substitute for an ingredient. Substitute is
+// scaled to replace the quantity the ingredient's nutritional value is given
+// for. Distance measures how far its nutritional value is from the
+// original's, it is nil if the units of the two can't be converted.
+type SubstituteSuggestion struct {
+	Substitution Substitution `json:"substitution"`
+	Substitute   Ingredient   `json:"substitute"`
+	Distance     *float32     `json:"distance"`
+}
+
+// SubstitutedRecipe is a recipe with substitutions applied, its nutrition,
+// cost and diet labels recomputed.
+type SubstitutedRecipe struct {
+	Recipe  RecipeGet      `json:"recipe"`
+	Applied []Substitution `json:"applied"`
+}
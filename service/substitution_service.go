@@ -0,0 +1,249 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/cookbook/repository"
+)
+
+type SubstitutionService interface {
+	Get(int64) (Substitution, error)
+	GetAll() ([]Substitution, error)
+	GetList([]int64) ([]Substitution, error)
+	Suggest(ingredientId int64, filter DietFilter) ([]SubstituteSuggestion, error)
+	Create(Substitution) (int64, error)
+	Update(Substitution) error
+	Delete(int64) error
+}
+
+type SubstitutionServiceImpl struct {
+	repo       *repository.SubstitutionRepository
+	ingService IngredientService
+}
+
+func NewSubstitutionService(r *repository.SubstitutionRepository, is IngredientService) SubstitutionService {
+	return SubstitutionServiceImpl{
+		repo:       r,
+		ingService: is,
+	}
+}
+
+func (s SubstitutionServiceImpl) Get(id int64) (Substitution, error) {
+	rSub, err := s.repo.Get(id)
+	if err != nil {
+		return Substitution{}, handleError(err)
+	}
+	return convertRepoSubstitution(rSub), nil
+}
+
+func (s SubstitutionServiceImpl) GetAll() ([]Substitution, error) {
+	rSubs, err := s.repo.GetAll()
+	if err != nil {
+		return []Substitution{}, handleError(err)
+	}
+	return convertRepoSubstitutions(rSubs), nil
+}
+
+func (s SubstitutionServiceImpl) GetList(ids []int64) ([]Substitution, error) {
+	rSubs, err := s.repo.GetList(ids)
+	if err != nil {
+		return []Substitution{}, handleError(err)
+	}
+	return convertRepoSubstitutions(rSubs), nil
+}
+
+// Suggest returns the substitutes of the ingredient passing the filter, the
+// ones closest to its nutritional value first. The diets a substitution is
+// meant for count as diets of its substitute.
+func (s SubstitutionServiceImpl) Suggest(ingredientId int64, filter DietFilter) ([]SubstituteSuggestion, error) {
+	original, err := s.ingService.Get(ingredientId)
+	if err != nil {
+		return nil, err
+	}
+	rSubs, err := s.repo.GetByIngredient(ingredientId)
+	if err != nil {
+		return nil, handleError(err)
+	}
+	subs := convertRepoSubstitutions(rSubs)
+	ids := make([]int64, len(subs))
+	for index, sub := range subs {
+		ids[index] = sub.SubstituteId
+	}
+	substitutes, err := s.ingService.GetList(ids)
+	if err != nil {
+		return nil, err
+	}
+	byId := make(map[int64]Ingredient)
+	for _, ing := range substitutes {
+		byId[ing.Id] = ing
+	}
+	suggestions := []SubstituteSuggestion{}
+	for _, sub := range subs {
+		substitute, ok := byId[sub.SubstituteId]
+		if !ok || !filter.Matches(substitute.Allergens, normalizeDiets(append(append([]string{}, substitute.Diets...), sub.Diets...))) {
+			continue
+		}
+		suggestion := SubstituteSuggestion{Substitution: sub, Substitute: substitute}
+		scaled, err := scaleIngredient(substitute, Quantity{Amount: original.Amount * sub.Ratio, Unit: original.Unit})
+		if err == nil {
+			distance := nutritionDistance(original.NutritionalValue, scaled.NutritionalValue)
+			suggestion.Substitute = scaled
+			suggestion.Distance = &distance
+		}
+		suggestions = append(suggestions, suggestion)
+	}
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		a, b := suggestions[i].Distance, suggestions[j].Distance
+		if a == nil || b == nil {
+			return b == nil && a != nil
+		}
+		return *a < *b
+	})
+	return suggestions, nil
+}
+
+// nutritionDistance is the root of the summed squared differences of the
+// calories and macronutrients, each relative to the original value. Values
+// below 1 count as 1 so small amounts don't dominate.
+func nutritionDistance(original, substitute NutritionalValue) float32 {
+	var sum float64
+	values := [][2]float32{
+		{original.Calories, substitute.Calories},
+		{original.Protein, substitute.Protein},
+		{original.Carbs, substitute.Carbs},
+		{original.Fat, substitute.Fat},
+	}
+	for _, v := range values {
+		d := float64(v[1]-v[0]) / math.Max(float64(v[0]), 1)
+		sum += d * d
+	}
+	return float32(math.Sqrt(sum))
+}
+
+func (s SubstitutionServiceImpl) Create(sub Substitution) (int64, error) {
+	err := s.validate(sub)
+	if err != nil {
+		return 0, err
+	}
+	id, err := s.repo.Create(toRepoSubstitution(sub))
+	if err != nil {
+		return 0, handleError(err)
+	}
+	return id, nil
+}
+
+func (s SubstitutionServiceImpl) Update(sub Substitution) error {
+	err := s.validate(sub)
+	if err != nil {
+		return err
+	}
+	err = s.repo.Update(toRepoSubstitution(sub))
+	if err != nil {
+		return handleError(err)
+	}
+	return nil
+}
+
+func (s SubstitutionServiceImpl) Delete(id int64) error {
+	err := s.repo.Delete(id)
+	if err != nil {
+		return handleError(err)
+	}
+	return nil
+}
+
+func convertRepoSubstitutions(rSubs []repository.Substitution) []Substitution {
+	subs := make([]Substitution, len(rSubs))
+	for index, rSub := range rSubs {
+		subs[index] = convertRepoSubstitution(rSub)
+	}
+	return subs
+}
+
+func convertRepoSubstitution(rSub repository.Substitution) Substitution {
+	return Substitution{
+		Id:           rSub.Id,
+		IngredientId: rSub.IngredientId,
+		SubstituteId: rSub.SubstituteId,
+		Ratio:        rSub.Ratio,
+		Notes:        rSub.Notes,
+		Diets:        append([]string{}, rSub.Diets...),
+	}
+}
+
+func toRepoSubstitution(sub Substitution) repository.Substitution {
+	ratio := sub.Ratio
+	if ratio == 0 {
+		ratio = 1
+	}
+	return repository.Substitution{
+		Id:           sub.Id,
+		IngredientId: sub.IngredientId,
+		SubstituteId: sub.SubstituteId,
+		Ratio:        ratio,
+		Notes:        sub.Notes,
+		Diets:        normalizeDiets(sub.Diets),
+	}
+}
+
+func (s SubstitutionServiceImpl) validate(sub Substitution) error {
+	err := validateSubstitution(sub)
+	if err != nil {
+		return err
+	}
+	return referenceError([]int64{sub.IngredientId, sub.SubstituteId}, "Ingredient", func(index int) string {
+		return []string{"ingredient_id", "substitute_id"}[index]
+	}, s.ingService.GetExistingIds)
+}
+
+func validateSubstitution(sub Substitution) error {
+	var fields []FieldError
+	if sub.Ratio < 0 {
+		fields = append(fields, FieldError{"ratio", "Ratio must not be negative"})
+	}
+	if sub.IngredientId == sub.SubstituteId {
+		fields = append(fields, FieldError{"substitute_id", "An ingredient can't substitute itself"})
+	}
+	fields = append(fields, validateDietLabels(DietLabels{Diets: sub.Diets})...)
+	if len(fields) > 0 {
+		return &ValidationError{fields: fields}
+	}
+	return nil
+}
+
+// applySubstitutions replaces the ingredients of the recipe with their
+// substitutes. Each substitution must replace a different ingredient of the
+// recipe.
+func applySubstitutions(recipe *repository.Recipe, subs []Substitution) error {
+	var fields []FieldError
+	original := make([]int64, len(recipe.Ingredients))
+	for i, ing := range recipe.Ingredients {
+		original[i] = ing.Id
+	}
+	replaced := make(map[int64]bool)
+	for index, sub := range subs {
+		field := fmt.Sprintf("substitutions[%d]", index)
+		if replaced[sub.IngredientId] {
+			fields = append(fields, FieldError{field, fmt.Sprintf("Ingredient %d is already substituted", sub.IngredientId)})
+			continue
+		}
+		found := false
+		for i, id := range original {
+			if id == sub.IngredientId {
+				recipe.Ingredients[i].Id = sub.SubstituteId
+				recipe.Ingredients[i].Amount *= sub.Ratio
+				found = true
+			}
+		}
+		if !found {
+			fields = append(fields, FieldError{field, fmt.Sprintf("Recipe %d doesn't use ingredient %d replaced by substitution %d", recipe.Id, sub.IngredientId, sub.Id)})
+		}
+		replaced[sub.IngredientId] = true
+	}
+	if len(fields) > 0 {
+		return &ValidationError{fields: fields}
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"bytes"
+	"image"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+
+	// Register the GIF decoder, GIF uploads get PNG thumbnails.
+	_ "image/gif"
+)
+
+var ThumbnailSizes = []ThumbnailSize{
+	{Name: "small", MaxSide: 160},
+	{Name: "medium", MaxSide: 480},
+	{Name: "large", MaxSide: 1024},
+}
+
+const thumbnailQuality = 85
+
+// thumbnailSize returns the dimensions of an image of width and height scaled
+// down to fit maxSide, keeping the aspect ratio. Smaller images keep their
+// size.
+func thumbnailSize(width, height, maxSide int) (int, int) {
+	if width <= maxSide && height <= maxSide {
+		return width, height
+	}
+	if width >= height {
+		return maxSide, maxInt(1, height*maxSide/width)
+	}
+	return maxInt(1, width*maxSide/height), maxSide
+}
+
+// thumbnailContentType returns the format thumbnails of images of the content
+// type are encoded in. JPEG photos stay JPEG, other formats become PNG to
+// keep their transparency.
+func thumbnailContentType(contentType string) string {
+	if contentType == "image/jpeg" {
+		return "image/jpeg"
+	}
+	return "image/png"
+}
+
+// encodeThumbnail scales the image down to fit maxSide and encodes it in the
+// thumbnail format of contentType.
+func encodeThumbnail(img image.Image, maxSide int, contentType string) ([]byte, error) {
+	bounds := img.Bounds()
+	width, height := thumbnailSize(bounds.Dx(), bounds.Dy(), maxSide)
+	scaled := scaleImage(img, width, height)
+	var buf bytes.Buffer
+	var err error
+	if thumbnailContentType(contentType) == "image/jpeg" {
+		err = jpeg.Encode(&buf, scaled, &jpeg.Options{Quality: thumbnailQuality})
+	} else {
+		err = png.Encode(&buf, scaled)
+	}
+	return buf.Bytes(), err
+}
+
+// scaleImage scales the image down to width and height by averaging the
+// source pixels covered by each pixel of the result.
+func scaleImage(img image.Image, width, height int) *image.RGBA {
+	bounds := img.Bounds()
+	src := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(src, src.Bounds(), img, bounds.Min, draw.Src)
+	if width == bounds.Dx() && height == bounds.Dy() {
+		return src
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	srcWidth, srcHeight := bounds.Dx(), bounds.Dy()
+	for y := 0; y < height; y++ {
+		y0, y1 := y*srcHeight/height, maxInt((y+1)*srcHeight/height, y*srcHeight/height+1)
+		for x := 0; x < width; x++ {
+			x0, x1 := x*srcWidth/width, maxInt((x+1)*srcWidth/width, x*srcWidth/width+1)
+			var sum [4]int
+			for sy := y0; sy < y1; sy++ {
+				row := src.Pix[sy*src.Stride+x0*4 : sy*src.Stride+x1*4]
+				for i := 0; i < len(row); i += 4 {
+					sum[0] += int(row[i])
+					sum[1] += int(row[i+1])
+					sum[2] += int(row[i+2])
+					sum[3] += int(row[i+3])
+				}
+			}
+			count := (y1 - y0) * (x1 - x0)
+			offset := y*dst.Stride + x*4
+			for c := range sum {
+				dst.Pix[offset+c] = uint8(sum[c] / count)
+			}
+		}
+	}
+	return dst
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,12 @@
+package service
+
+// Vocabularies holds the values of the validate rules restricting a field to
+// a fixed set. On a list they restrict its items.
+var Vocabularies = map[string][]string{
+	"unit":           Units(),
+	"allergen":       Allergens,
+	"diet":           Diets,
+	"sex":            Sexes,
+	"activity_level": ActivityLevels,
+	"goal":           Goals,
+}
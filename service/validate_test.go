@@ -0,0 +1,296 @@
+package service
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// validModels holds a valid value of every request model with validate tags,
+// the constraints the tags document for each field are checked against it.
+var validModels = []interface{}{
+	Ingredient{
+		Name: "Flour",
+		NutritionalValue: NutritionalValue{
+			Quantity: Quantity{Amount: 100, Unit: "g"},
+			Calories: 364,
+			Protein:  10,
+			Carbs:    76,
+			Fat:      1,
+		},
+		DietLabels: DietLabels{Allergens: []string{"gluten"}, Diets: []string{"vegan"}},
+	},
+	RecipeCreate{
+		Name:        "Pancakes",
+		Steps:       "Mix.\nFry.",
+		PrepMinutes: 10,
+		CookMinutes: 20,
+		Servings:    4,
+		Tags:        []string{"breakfast"},
+		Ingredients: []IngredientShort{{Id: 1, Amount: 200, Unit: "g"}},
+	},
+	MealCreate{Name: "Brunch", Recipes: []int64{1, 2}},
+	MealPlanCreate{
+		Name:        "Week",
+		DateStarted: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		Entries: []MealPlanEntryCreate{{
+			Date:     testDate(2024, 1, 2),
+			Slot:     SlotDinner,
+			MealId:   1,
+			Servings: 1,
+			People:   2,
+			Members:  []int64{1, 2},
+		}},
+	},
+	MealPlanClone{Name: "Next week", DateStarted: testDate(2024, 1, 8)},
+	MealPlanRepeat{Times: 2},
+	MealPlanGenerate{DateStarted: testDate(2024, 1, 1), Days: 7, MealsPerDay: 3, MaxRepeats: 2},
+	PantryItemCreate{
+		IngredientId: 1,
+		Quantity:     Quantity{Amount: 1, Unit: "kg"},
+		Location:     "pantry",
+	},
+	IngredientPrice{
+		IngredientId: 1,
+		Price:        2.5,
+		Quantity:     Quantity{Amount: 1, Unit: "kg"},
+		Store:        "Market",
+		Date:         testDate(2024, 1, 1),
+	},
+	Substitution{IngredientId: 1, SubstituteId: 2, Ratio: 1, Diets: []string{"vegan"}},
+	CollectionCreate{Name: "Favourites", Recipes: []int64{1, 2}},
+	Review{RecipeId: 1, User: "Sam", Rating: 4, Date: testDate(2024, 1, 1)},
+	CookLogEntry{RecipeId: 1, Date: testDate(2024, 1, 1), Servings: 2},
+	MemberCreate{
+		Name:          "Sam",
+		BirthDate:     testDate(1990, 1, 1),
+		Sex:           "female",
+		Weight:        60,
+		Height:        165,
+		ActivityLevel: ActivityModerate,
+		Goal:          GoalMaintain,
+		Allergies:     []string{"peanut"},
+	},
+}
+
+func testDate(year int, month time.Month, day int) Date {
+	return NewDate(time.Date(year, month, day, 0, 0, 0, 0, time.UTC))
+}
+
+// validate checks the fields of a model read from a request body the way the
+// service does before saving it, without looking up the entities it
+// references.
+func validate(model interface{}) error {
+	switch m := model.(type) {
+	case Ingredient:
+		return validateIngredient(m)
+	case RecipeCreate:
+		return validateRecipe(m)
+	case MealCreate:
+		return validateMeal(m)
+	case MealPlanCreate:
+		return validateMealPlan(m)
+	case MealPlanClone:
+		return validateMealPlanClone(m)
+	case MealPlanRepeat:
+		return validateMealPlanRepeat(m)
+	case MealPlanGenerate:
+		return validateMealPlanGenerate(m)
+	case PantryItemCreate:
+		return validatePantryItem(m)
+	case IngredientPrice:
+		return validatePrice(m)
+	case Substitution:
+		return validateSubstitution(m)
+	case CollectionCreate:
+		return validateCollection(m)
+	case Review:
+		return validateReview(m)
+	case CookLogEntry:
+		return validateCookLogEntry(m)
+	case MemberCreate:
+		return validateMember(defaultMember(m))
+	}
+	panic("no validation for " + reflect.TypeOf(model).Name())
+}
+
+// TestValidateTags checks the validate tags, which the OpenAPI spec documents,
+// against the validation of the service: a constraint of a tag must be
+// enforced and a value within all of them must pass.
+func TestValidateTags(t *testing.T) {
+	for _, model := range validModels {
+		model := model
+		t.Run(reflect.TypeOf(model).Name(), func(t *testing.T) {
+			if err := validate(model); err != nil {
+				t.Fatalf("valid model fails validation: %v", err)
+			}
+			c := validationCheck{t: t, model: reflect.New(reflect.TypeOf(model)).Elem()}
+			c.model.Set(reflect.ValueOf(model))
+			c.fields(c.model, "")
+		})
+	}
+}
+
+// rules are the constraints of a validate tag.
+type rules struct {
+	required     bool
+	min, max     *float64
+	exclusiveMin bool
+	exclusiveMax bool
+	vocabulary   []string
+}
+
+func parseRules(tag string) rules {
+	var r rules
+	for _, rule := range strings.Split(tag, ",") {
+		bound := func() *float64 {
+			var n float64
+			fmt.Sscanf(rule[strings.Index(rule, "=")+1:], "%g", &n)
+			return &n
+		}
+		switch {
+		case rule == "required":
+			r.required = true
+		case Vocabularies[rule] != nil:
+			r.vocabulary = Vocabularies[rule]
+		case strings.HasPrefix(rule, "gt="), strings.HasPrefix(rule, "gte="):
+			r.min = bound()
+			r.exclusiveMin = strings.HasPrefix(rule, "gt=")
+		case strings.HasPrefix(rule, "lt="), strings.HasPrefix(rule, "lte="):
+			r.max = bound()
+			r.exclusiveMax = strings.HasPrefix(rule, "lt=")
+		}
+	}
+	return r
+}
+
+func (r rules) allows(n float64) bool {
+	if r.min != nil && (n < *r.min || r.exclusiveMin && n == *r.min) {
+		return false
+	}
+	if r.max != nil && (n > *r.max || r.exclusiveMax && n == *r.max) {
+		return false
+	}
+	return true
+}
+
+type validationCheck struct {
+	t     *testing.T
+	model reflect.Value
+}
+
+// fields checks the fields of the struct, named by their JSON names the way
+// the OpenAPI schema names them.
+func (c validationCheck) fields(value reflect.Value, prefix string) {
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		if field.PkgPath != "" && !field.Anonymous {
+			continue
+		}
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+		if field.Anonymous && name == "" {
+			c.fields(value.Field(i), prefix)
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		c.field(prefix+name, parseRules(field.Tag.Get("validate")), value.Field(i))
+	}
+}
+
+func isStruct(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && t != reflect.TypeOf(Date{}) && t != reflect.TypeOf(time.Time{})
+}
+
+func (c validationCheck) field(path string, r rules, value reflect.Value) {
+	if isStruct(value.Type()) {
+		c.fields(value, path+".")
+		return
+	}
+	c.expect(path, value, reflect.Zero(value.Type()), !r.required && r.allows(0), "zero value")
+	switch value.Kind() {
+	case reflect.Int, reflect.Int32, reflect.Int64, reflect.Float32, reflect.Float64:
+		c.bounds(path, r, value)
+	case reflect.String:
+		c.vocabulary(path, r.vocabulary, value)
+	case reflect.Slice:
+		if value.Len() == 0 {
+			return
+		}
+		item := value.Index(0)
+		if isStruct(item.Type()) {
+			c.fields(item, path+"[0].")
+		} else if item.Kind() == reflect.String {
+			c.vocabulary(path+"[0]", r.vocabulary, item)
+		}
+	}
+}
+
+func (c validationCheck) bounds(path string, r rules, value reflect.Value) {
+	number := func(n float64) reflect.Value {
+		v := reflect.New(value.Type()).Elem()
+		if value.Kind() == reflect.Float32 || value.Kind() == reflect.Float64 {
+			v.SetFloat(n)
+		} else {
+			v.SetInt(int64(n))
+		}
+		return v
+	}
+	if r.min != nil {
+		c.expect(path, value, number(*r.min-1), false, "value below the minimum")
+		if !r.exclusiveMin && *r.min != 0 {
+			c.expect(path, value, number(*r.min), true, "minimum")
+		}
+	}
+	if r.max != nil {
+		c.expect(path, value, number(*r.max+1), false, "value above the maximum")
+		if !r.exclusiveMax {
+			c.expect(path, value, number(*r.max), true, "maximum")
+		}
+	}
+}
+
+// vocabulary checks that a string accepts exactly the values of its
+// vocabulary, any string if it has none.
+func (c validationCheck) vocabulary(path string, vocabulary []string, value reflect.Value) {
+	c.expect(path, value, reflect.ValueOf("not-in-the-vocabulary"), len(vocabulary) == 0, "value")
+	for _, allowed := range vocabulary {
+		c.expect(path, value, reflect.ValueOf(allowed), true, "value")
+	}
+}
+
+// expect sets the field to the value, validates the model and restores the
+// field. An invalid value must be reported on the field or one of its parents.
+func (c validationCheck) expect(path string, field, to reflect.Value, valid bool, what string) {
+	original := reflect.New(field.Type()).Elem()
+	original.Set(field)
+	field.Set(to)
+	err := validate(c.model.Interface())
+	field.Set(original)
+	switch {
+	case valid && err != nil:
+		c.t.Errorf("%s: %s %v is allowed by the validate tag but fails validation: %v", path, what, to, err)
+	case !valid && !reportsField(err, path):
+		c.t.Errorf("%s: %s %v is refused by the validate tag but passes validation", path, what, to)
+	}
+}
+
+func reportsField(err error, path string) bool {
+	validationErr, ok := err.(*ValidationError)
+	if !ok {
+		return false
+	}
+	for _, field := range validationErr.Fields() {
+		if field.Field == path || strings.HasPrefix(path, field.Field+".") || strings.HasPrefix(path, field.Field+"[") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,21 @@
+package storage
+
+import (
+	"errors"
+	"io"
+)
+
+// ErrNotFound is returned when opening a key that holds no blob.
+var ErrNotFound = errors.New("blob not found")
+
+// BlobStore stores binary objects under slash separated keys such as
+// images/12/original.
+type BlobStore interface {
+	// Put stores the content read from r under key, replacing any blob
+	// stored there before.
+	Put(key string, r io.Reader) error
+	Open(key string) (io.ReadCloser, error)
+	// Delete removes the blob under key, deleting a missing blob is not an
+	// error.
+	Delete(key string) error
+}